@@ -8,8 +8,10 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 
 	"github.com/brads3290/cclogviewer/internal/mcp"
+	"github.com/brads3290/cclogviewer/internal/service"
 )
 
 var (
@@ -23,6 +25,14 @@ func main() {
 	showVersion := flag.Bool("version", false, "Show version information")
 	claudeDir := flag.String("claude-dir", "", "Path to Claude directory (default: ~/.claude)")
 	debug := flag.Bool("debug", false, "Enable debug logging")
+	noiseTools := flag.String("noise-tools", "", "Comma-separated tools (e.g. TodoWrite) left out of summary and tool-stats counts and reported separately")
+	healthWeights := flag.String("health-weights", "", "Health score weight overrides, e.g. errors=40,interruptions=10 (keys: errors, tool_failures, retries, interruptions)")
+	heavyReadBytes := flag.Int("heavy-read-bytes", service.DefaultHeavyReadBytes, "Read results at or above this many bytes count as heavy reads")
+	recursive := flag.Bool("recursive", false, "Also find session files in project subdirectories (subagents/ excluded)")
+	discoveryDepth := flag.Int("discovery-depth", service.DefaultDiscoveryDepth, "Subdirectory levels searched with --recursive")
+	maxSubagentFiles := flag.Int("max-subagent-files", 0, "Load at most this many subagent files per session, most recently modified first (0 for unlimited)")
+	maxSubagentBytes := flag.Int64("max-subagent-bytes", 0, "Load at most this many bytes of subagent files per session, most recently modified first (0 for unlimited)")
+	maxResponseBytes := flag.Int("max-response-bytes", mcp.DefaultMaxResponseBytes, "Truncate tool responses larger than this many bytes (0 to disable)")
 	flag.Parse()
 
 	if *showVersion {
@@ -38,9 +48,21 @@ func main() {
 
 	// Create services
 	services := mcp.NewServices(*claudeDir)
+	services.Session.SetNoiseTools(strings.Split(*noiseTools, ","))
+	weights, err := service.ParseHealthWeights(*healthWeights)
+	if err != nil {
+		log.Fatalf("Invalid -health-weights: %v", err)
+	}
+	services.Session.SetHealthWeights(weights)
+	services.Session.SetHeavyReadBytes(*heavyReadBytes)
+	if *recursive {
+		services.Session.SetDiscoveryDepth(*discoveryDepth)
+	}
+	services.Session.SetSubagentLimits(*maxSubagentFiles, *maxSubagentBytes)
 
 	// Create and configure server
 	server := mcp.NewServer()
+	server.SetMaxResponseBytes(*maxResponseBytes)
 	mcp.RegisterAllTools(server, services)
 
 	// Run server
@@ -3,13 +3,17 @@ package commands
 import (
 	"flag"
 	"fmt"
+
+	"github.com/brads3290/cclogviewer/internal/service"
 )
 
 // AgentSessionsCmd implements the agent-sessions command.
 type AgentSessionsCmd struct {
-	Project string
-	Days    int
-	Limit   int
+	Project        string
+	Days           int
+	Limit          int
+	MaxConcurrency int
+	Sort           string
 }
 
 func (c *AgentSessionsCmd) Name() string {
@@ -24,6 +28,8 @@ func (c *AgentSessionsCmd) Setup(fs *flag.FlagSet) {
 	fs.StringVar(&c.Project, "project", "", "Limit search to a specific project")
 	fs.IntVar(&c.Days, "days", 0, "Only search sessions from the last N days")
 	fs.IntVar(&c.Limit, "limit", 20, "Maximum sessions to return")
+	fs.IntVar(&c.MaxConcurrency, "max-concurrency", service.DefaultMaxConcurrency, "Maximum projects to scan in parallel")
+	fs.StringVar(&c.Sort, "sort", service.SortByProject, "Result order: project (by name, newest session first) or time (newest first across projects)")
 }
 
 func (c *AgentSessionsCmd) Run(ctx *Context, args []string) error {
@@ -32,7 +38,17 @@ func (c *AgentSessionsCmd) Run(ctx *Context, args []string) error {
 	}
 
 	agentType := args[0]
-	sessions, err := ctx.Services.Session.FindSessionsByAgentType(agentType, c.Project, c.Days, c.Limit)
+	// Report progress on stderr so it never mixes with table or JSON output
+	progress := func(done, total int) {
+		if total > 1 {
+			fmt.Fprintf(ctx.ErrOutput, "\rScanned %d/%d projects", done, total)
+			if done == total {
+				fmt.Fprintln(ctx.ErrOutput)
+			}
+		}
+	}
+
+	sessions, report, err := ctx.Services.Session.FindSessionsByAgentType(agentType, c.Project, c.Days, c.Limit, c.MaxConcurrency, c.Sort, progress)
 	if err != nil {
 		return err
 	}
@@ -41,12 +57,16 @@ func (c *AgentSessionsCmd) Run(ctx *Context, args []string) error {
 
 	if ctx.Config.JSONOutput {
 		return out.WriteJSON(map[string]interface{}{
-			"agent_type": agentType,
-			"sessions":   sessions,
-			"count":      len(sessions),
+			"agent_type":       agentType,
+			"sessions":         sessions,
+			"count":            len(sessions),
+			"sessions_scanned": report.SessionsScanned,
+			"failures":         report.Failures,
 		})
 	}
 
+	WriteScanWarning(ctx.ErrOutput, report)
+
 	// Human-readable output
 	if len(sessions) == 0 {
 		out.PrintLine("No sessions found using agent type: %s", agentType)
@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// AgentShowCmd implements the agent-show command.
+type AgentShowCmd struct {
+	Project string
+	Scope   string
+	Raw     bool
+}
+
+func (c *AgentShowCmd) Name() string {
+	return "agent-show"
+}
+
+func (c *AgentShowCmd) Description() string {
+	return "Show an agent definition's frontmatter and full prompt body"
+}
+
+func (c *AgentShowCmd) Setup(fs *flag.FlagSet) {
+	fs.StringVar(&c.Project, "project", "", "Project path to include project-specific agents")
+	fs.StringVar(&c.Scope, "scope", "", "Look only in this scope: global or project (needed when both define the name)")
+	fs.BoolVar(&c.Raw, "raw", false, "Print the definition file exactly as written")
+}
+
+func (c *AgentShowCmd) Run(ctx *Context, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("agent name is required\nUsage: cclogviewer agent-show <name> [flags]")
+	}
+
+	// If project name given, resolve to path
+	projectPath := c.Project
+	if projectPath != "" {
+		project, err := ctx.Services.Project.FindProjectByName(projectPath)
+		if err == nil && project != nil {
+			projectPath = project.Path
+		}
+	}
+
+	agent, err := ctx.Services.Agent.GetAgent(args[0], projectPath, c.Scope)
+	if err != nil {
+		return err
+	}
+	if agent == nil {
+		return fmt.Errorf("agent not found: %s", args[0])
+	}
+
+	out := NewOutputWriter(ctx.Output, ctx.Config.JSONOutput)
+
+	if ctx.Config.JSONOutput {
+		return out.WriteJSON(agent)
+	}
+
+	if c.Raw {
+		fmt.Fprint(ctx.Output, agent.Raw)
+		return nil
+	}
+
+	// Human-readable output
+	out.PrintSection(agent.Name)
+	out.PrintKeyValue("Scope", agent.Scope)
+	out.PrintKeyValue("File", agent.FilePath)
+	out.PrintKeyValue("Description", agent.Description)
+	out.PrintKeyValue("Model", agent.EffectiveModel)
+	if len(agent.Tools) > 0 {
+		out.PrintKeyValue("Tools", strings.Join(agent.Tools, ", "))
+	}
+	if agent.Color != "" {
+		out.PrintKeyValue("Color", agent.Color)
+	}
+
+	out.PrintLine("")
+	out.PrintLine("%s", agent.Body)
+
+	return nil
+}
@@ -53,12 +53,13 @@ func (c *AgentsCmd) Run(ctx *Context, args []string) error {
 		return nil
 	}
 
-	headers := []string{"Name", "Scope", "Description"}
+	headers := []string{"Name", "Scope", "Model", "Description"}
 	var rows [][]string
 	for _, a := range agents {
 		rows = append(rows, []string{
 			a.Name,
 			a.Scope,
+			a.EffectiveModel,
 			Truncate(a.Description, 50),
 		})
 	}
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+)
+
+// BudgetCmd implements the budget command.
+type BudgetCmd struct {
+	Project string
+	Model   string
+}
+
+func (c *BudgetCmd) Name() string {
+	return "budget"
+}
+
+func (c *BudgetCmd) Description() string {
+	return "Estimate the context window headroom left in a session"
+}
+
+func (c *BudgetCmd) Setup(fs *flag.FlagSet) {
+	fs.StringVar(&c.Project, "project", "", "Project name/path (optional)")
+	fs.StringVar(&c.Model, "model", "sonnet", "Model whose context window to measure against (e.g. opus, sonnet, claude-sonnet-4-5[1m])")
+}
+
+func (c *BudgetCmd) Run(ctx *Context, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("session ID is required\nUsage: cclogviewer budget <session-id> [flags]")
+	}
+
+	sessionID := args[0]
+	budget, err := ctx.Services.Session.ContextBudget(sessionID, c.Project, c.Model)
+	if err != nil {
+		return err
+	}
+
+	if budget == nil {
+		return fmt.Errorf("session not found: %s", sessionID)
+	}
+
+	out := NewOutputWriter(ctx.Output, ctx.Config.JSONOutput)
+
+	if ctx.Config.JSONOutput {
+		return out.WriteJSON(budget)
+	}
+
+	// Human-readable output
+	out.PrintLine("Context Budget: %s\n", budget.SessionID)
+	out.PrintKeyValue("Model", budget.Model)
+	out.PrintKeyValue("Window", FormatNumber(budget.ContextWindow))
+	out.PrintKeyValue("Used", fmt.Sprintf("%s (%.1f%%)", FormatNumber(budget.UsedTokens), budget.UsedPercent))
+	out.PrintKeyValue("Remaining", FormatNumber(budget.RemainingTokens))
+
+	if budget.Warning {
+		out.PrintLine("")
+		out.PrintLine("Warning: over %.0f%% of the context window is used", budget.WarnPercent)
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/brads3290/cclogviewer/internal/browser"
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/renderer"
+	"github.com/brads3290/cclogviewer/internal/service"
+)
+
+// CalendarCmd implements the calendar command.
+type CalendarCmd struct {
+	Project     string
+	Days        int
+	HTMLPath    string
+	OpenBrowser bool
+}
+
+func (c *CalendarCmd) Name() string {
+	return "calendar"
+}
+
+func (c *CalendarCmd) Description() string {
+	return "Show a calendar heatmap of session activity"
+}
+
+func (c *CalendarCmd) Setup(fs *flag.FlagSet) {
+	fs.StringVar(&c.Project, "project", "", "Limit to a specific project (default: all projects)")
+	fs.IntVar(&c.Days, "days", 90, "Number of days to include (0 = since the first session)")
+	fs.StringVar(&c.HTMLPath, "html", "", "Write an HTML heatmap to this file instead of printing")
+	fs.BoolVar(&c.OpenBrowser, "open", false, "Open the HTML heatmap in browser (requires --html)")
+}
+
+func (c *CalendarCmd) Run(ctx *Context, args []string) error {
+	calendar, report, err := ctx.Services.Session.ActivityCalendar(c.Project, c.Days)
+	if err != nil {
+		return err
+	}
+	if !ctx.Config.JSONOutput {
+		WriteScanWarning(ctx.ErrOutput, report)
+	}
+
+	out := NewOutputWriter(ctx.Output, ctx.Config.JSONOutput)
+	days := renderer.SortedActivityDays(calendar)
+
+	title := "All projects"
+	if c.Project != "" {
+		title = c.Project
+	}
+
+	if c.HTMLPath != "" {
+		if err := renderer.GenerateCalendarHTML(calendar, title, c.HTMLPath); err != nil {
+			return fmt.Errorf("failed to generate calendar: %w", err)
+		}
+		manifest := service.NewOutputManifest(c.HTMLPath, models.OutputTypeHTML)
+
+		if c.OpenBrowser {
+			if err := browser.OpenInBrowser(c.HTMLPath); err != nil {
+				fmt.Fprintf(ctx.ErrOutput, "Warning: Could not open browser: %v\n", err)
+			} else {
+				manifest.OpenedBrowser = true
+			}
+		}
+		return out.WriteSaved("Calendar", manifest)
+	}
+
+	if ctx.Config.JSONOutput {
+		return out.WriteJSON(map[string]interface{}{
+			"days":             days,
+			"count":            len(days),
+			"sessions_scanned": report.SessionsScanned,
+			"failures":         report.Failures,
+		})
+	}
+
+	// Human-readable output: one row per weekday, one column per week
+	out.PrintLine("Activity: %s\n", title)
+	if len(days) == 0 {
+		out.PrintLine("No activity found")
+		return nil
+	}
+
+	maxSessions, totalSessions := 0, 0
+	for _, d := range days {
+		if d.Sessions > maxSessions {
+			maxSessions = d.Sessions
+		}
+		totalSessions += d.Sessions
+	}
+
+	shades := []string{"·", "░", "▒", "▓", "█"}
+	grid := make([][]string, 7)
+
+	first, err := time.Parse("2006-01-02", days[0].Date)
+	if err != nil {
+		return fmt.Errorf("invalid calendar date %q: %w", days[0].Date, err)
+	}
+	for i := 0; i < int(first.Weekday()); i++ {
+		grid[i] = append(grid[i], " ")
+	}
+
+	for _, d := range days {
+		date, err := time.Parse("2006-01-02", d.Date)
+		if err != nil {
+			continue
+		}
+		weekday := int(date.Weekday())
+		grid[weekday] = append(grid[weekday], shades[renderer.ActivityLevel(d.Sessions, maxSessions)])
+	}
+
+	labels := []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+	for i, row := range grid {
+		out.PrintLine("%s %s", labels[i], strings.Join(row, ""))
+	}
+
+	out.PrintLine("")
+	out.PrintLine("Less %s More", strings.Join(shades, ""))
+	out.PrintKeyValue("Days", FormatNumber(len(days)))
+	out.PrintKeyValue("Sessions", FormatNumber(totalSessions))
+
+	return nil
+}
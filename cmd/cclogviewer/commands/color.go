@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"unicode/utf8"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/utils"
+)
+
+// Color modes accepted by --color.
+const (
+	ColorAuto   = "auto"
+	ColorAlways = "always"
+	ColorNever  = "never"
+)
+
+const (
+	ansiRed    = "\x1b[31m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+	ansiDim    = "\x1b[2m"
+	ansiReset  = "\x1b[0m"
+)
+
+// ResolveColor decides whether human output is colored. "auto" colors only
+// when f is a terminal and NO_COLOR is unset or empty; "always" and "never"
+// apply regardless.
+func ResolveColor(mode string, f *os.File) (bool, error) {
+	switch mode {
+	case ColorAlways:
+		return true, nil
+	case ColorNever:
+		return false, nil
+	case ColorAuto, "":
+		if os.Getenv("NO_COLOR") != "" {
+			return false, nil
+		}
+		return isTerminal(f), nil
+	default:
+		return false, fmt.Errorf("invalid --color %q: must be auto, always, or never", mode)
+	}
+}
+
+// isTerminal reports whether f is a character device such as a TTY.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// SetColor enables ANSI coloring of human-readable output.
+func (o *OutputWriter) SetColor(enabled bool) {
+	o.color = enabled
+}
+
+// Red marks errors and failures.
+func (o *OutputWriter) Red(s string) string {
+	return o.paint(ansiRed, s)
+}
+
+// Green marks successes.
+func (o *OutputWriter) Green(s string) string {
+	return o.paint(ansiGreen, s)
+}
+
+// Yellow marks warnings.
+func (o *OutputWriter) Yellow(s string) string {
+	return o.paint(ansiYellow, s)
+}
+
+// HealthBadge formats a health score as "87/100 (good)", colored by grade.
+func (o *OutputWriter) HealthBadge(score int) string {
+	grade := models.HealthGrade(score)
+	badge := fmt.Sprintf("%d/100 (%s)", score, grade)
+	switch grade {
+	case "good":
+		return o.Green(badge)
+	case "fair":
+		return o.Yellow(badge)
+	}
+	return o.Red(badge)
+}
+
+// Dim de-emphasizes secondary details such as timestamps.
+func (o *OutputWriter) Dim(s string) string {
+	return o.paint(ansiDim, s)
+}
+
+// Status colors a success/failed status word, leaving other values plain.
+func (o *OutputWriter) Status(s string) string {
+	switch s {
+	case "success":
+		return o.Green(s)
+	case "failed", "error":
+		return o.Red(s)
+	}
+	return s
+}
+
+// Count colors a count with paint when it is non-zero, so empty columns stay quiet.
+func (o *OutputWriter) Count(n int, paint func(string) string) string {
+	if n == 0 {
+		return FormatNumber(n)
+	}
+	return paint(FormatNumber(n))
+}
+
+func (o *OutputWriter) paint(code, s string) string {
+	if !o.color || s == "" {
+		return s
+	}
+	return code + s + ansiReset
+}
+
+// visibleWidth is the display width of s with escape codes removed.
+func visibleWidth(s string) int {
+	return utf8.RuneCountInString(utils.StripANSI(s))
+}
@@ -6,7 +6,10 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/brads3290/cclogviewer/internal/models"
 	"github.com/brads3290/cclogviewer/internal/service"
 )
 
@@ -30,6 +33,34 @@ type Config struct {
 	JSONOutput bool
 	// Debug enables debug logging.
 	Debug bool
+	// EncodedProject treats project arguments as encoded directory names (e.g. "-Users-me-app").
+	EncodedProject bool
+	// Timezone is the IANA time zone name used for human-readable timestamps.
+	Timezone string
+	// Location is the resolved Timezone; nil keeps timestamps as logged.
+	Location *time.Location
+	// StripANSI removes terminal escape codes from tool output in text and JSON results.
+	StripANSI bool
+	// NoiseTools is a comma-separated list of tools left out of headline tool-call counts.
+	NoiseTools string
+	// HealthWeights are the resolved --health-weights used for session health scores.
+	HealthWeights models.HealthWeights
+	// HeavyReadBytes is the Read result size counted as a heavy read.
+	HeavyReadBytes int
+	// Recursive finds session files in project subdirectories, down to DiscoveryDepth levels.
+	Recursive bool
+	// DiscoveryDepth bounds the subdirectory levels searched when Recursive is set.
+	DiscoveryDepth int
+	// MaxSubagentFiles caps the subagent files loaded per session; 0 is unlimited.
+	MaxSubagentFiles int
+	// MaxSubagentBytes caps the combined size of subagent files loaded per session; 0 is unlimited.
+	MaxSubagentBytes int64
+	// RecentLimit is recent_limit from the config file; 0 when unset.
+	RecentLimit int
+	// Color is the --color mode: auto, always, or never.
+	Color string
+	// ColorOutput is the resolved Color; true when human output should use ANSI colors.
+	ColorOutput bool
 }
 
 // Context provides the execution context for commands.
@@ -46,14 +77,43 @@ type Context struct {
 
 // NewContext creates a new Context with the given config.
 func NewContext(config *Config) *Context {
+	services := service.NewServices(config.ClaudeDir)
+	services.Project.SetEncodedNames(config.EncodedProject)
+	services.Session.SetLocation(config.Location)
+	services.Session.SetStripANSI(config.StripANSI)
+	services.Session.SetNoiseTools(strings.Split(config.NoiseTools, ","))
+	services.Session.SetHealthWeights(config.HealthWeights)
+	services.Session.SetHeavyReadBytes(config.HeavyReadBytes)
+	if config.Recursive {
+		services.Session.SetDiscoveryDepth(config.DiscoveryDepth)
+	}
+	services.Session.SetSubagentLimits(config.MaxSubagentFiles, config.MaxSubagentBytes)
+	services.Search.SetBookmarkDir(service.DefaultBookmarkDir())
+
 	return &Context{
 		Config:    config,
-		Services:  service.NewServices(config.ClaudeDir),
+		Services:  services,
 		Output:    os.Stdout,
 		ErrOutput: os.Stderr,
 	}
 }
 
+// LoadLocation resolves a time zone name, falling back to $TZ when name is
+// empty. Returns nil if neither is set.
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		name = os.Getenv("TZ")
+	}
+	if name == "" {
+		return nil, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", name, err)
+	}
+	return loc, nil
+}
+
 // Registry holds all registered commands.
 type Registry struct {
 	commands map[string]Command
@@ -108,6 +168,9 @@ func (r *Registry) PrintHelp(w io.Writer) {
 	fmt.Fprintln(w, "    --json         Output results in JSON format (default: human-readable)")
 	fmt.Fprintln(w, "    --claude-dir   Path to Claude directory (default: ~/.claude)")
 	fmt.Fprintln(w, "    --debug        Enable debug logging")
+	fmt.Fprintln(w, "    --encoded      Treat project names as encoded directory names (e.g. -Users-me-app)")
+	fmt.Fprintln(w, "    --strip-ansi   Remove terminal escape codes from tool output (default: true)")
+	fmt.Fprintln(w, "    --color        Color human output: auto, always, or never (default: auto, honors NO_COLOR)")
 	fmt.Fprintln(w, "    --help, -h     Show help for command")
 	fmt.Fprintln(w, "    --version, -v  Show version information")
 	fmt.Fprintln(w)
@@ -118,6 +181,9 @@ func (r *Registry) PrintHelp(w io.Writer) {
 	fmt.Fprintln(w, "    # List recent sessions for a project")
 	fmt.Fprintln(w, "    cclogviewer sessions my-project --days 7 --limit 10")
 	fmt.Fprintln(w)
+	fmt.Fprintln(w, "    # Look up a project by its encoded directory name (use -- before a leading dash)")
+	fmt.Fprintln(w, "    cclogviewer sessions --encoded -- -Users-me-my-project")
+	fmt.Fprintln(w)
 	fmt.Fprintln(w, "    # Get session summary")
 	fmt.Fprintln(w, "    cclogviewer summary abc123-def456 --json")
 	fmt.Fprintln(w)
@@ -130,6 +196,10 @@ func (r *Registry) PrintHelp(w io.Writer) {
 	fmt.Fprintln(w, "    # Legacy mode (backward compatible)")
 	fmt.Fprintln(w, "    cclogviewer -input session.jsonl -output report.html -open")
 	fmt.Fprintln(w)
+	fmt.Fprintln(w, "CONFIG:")
+	fmt.Fprintf(w, "    %s\n", displayConfigPath())
+	fmt.Fprintln(w, "    {\"default_command\": \"recent\", \"recent_limit\": 10} runs 'recent' when no command is given")
+	fmt.Fprintln(w)
 	fmt.Fprintln(w, "Use \"cclogviewer <command> --help\" for detailed help on any command.")
 }
 
@@ -140,16 +210,41 @@ var DefaultRegistry = NewRegistry()
 func RegisterAll(r *Registry) {
 	r.Register(&ProjectsCmd{})
 	r.Register(&SessionsCmd{})
+	r.Register(&RecentCmd{})
 	r.Register(&AgentsCmd{})
+	r.Register(&AgentShowCmd{})
 	r.Register(&AgentSessionsCmd{})
 	r.Register(&SearchCmd{})
+	r.Register(&DuplicatePromptsCmd{})
+	r.Register(&SteeringCmd{})
+	r.Register(&FileEditsCmd{})
+	r.Register(&EditChainCmd{})
 	r.Register(&LogsCmd{})
 	r.Register(&SummaryCmd{})
 	r.Register(&ToolsCmd{})
+	r.Register(&ToolNamesCmd{})
 	r.Register(&ErrorsCmd{})
+	r.Register(&RecurringErrorsCmd{})
+	r.Register(&ErrorTrendCmd{})
 	r.Register(&TimelineCmd{})
 	r.Register(&StatsCmd{})
+	r.Register(&LargestOutputsCmd{})
+	r.Register(&HeavyReadsCmd{})
+	r.Register(&TokenTreeCmd{})
+	r.Register(&GraphCmd{})
 	r.Register(&ContextCmd{})
+	r.Register(&FindCmd{})
+	r.Register(&ContextSizeCmd{})
+	r.Register(&BudgetCmd{})
+	r.Register(&CalendarCmd{})
+	r.Register(&TodosCmd{})
+	r.Register(&HooksCmd{})
+	r.Register(&CorrectionsCmd{})
+	r.Register(&SystemContextCmd{})
+	r.Register(&URLsCmd{})
+	r.Register(&QueryCmd{})
+	r.Register(&ReplayCmd{})
+	r.Register(&DumpCmd{})
 	r.Register(&HTMLCmd{})
 }
 
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// DefaultRecentLimit is how many sessions the recent command lists when
+// neither --limit nor recent_limit is set.
+const DefaultRecentLimit = 10
+
+// FileConfig holds settings read from the user config file.
+type FileConfig struct {
+	// DefaultCommand runs when cclogviewer is invoked with no arguments.
+	// Empty or "help" prints help.
+	DefaultCommand string `json:"default_command"`
+	// RecentLimit is how many sessions the recent command lists by default.
+	RecentLimit int `json:"recent_limit"`
+}
+
+// DefaultConfigPath returns the config file path under the user config
+// directory, or "" if it cannot be determined.
+func DefaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "cclogviewer", "config.json")
+}
+
+// LoadFileConfig reads the config file at path. A missing file, or an empty
+// path, yields an empty config.
+func LoadFileConfig(path string) (*FileConfig, error) {
+	config := &FileConfig{}
+	if path == "" {
+		return config, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return config, nil
+		}
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// displayConfigPath returns the config file path for help output.
+func displayConfigPath() string {
+	if path := DefaultConfigPath(); path != "" {
+		return path
+	}
+	return "<user config dir>/cclogviewer/config.json"
+}
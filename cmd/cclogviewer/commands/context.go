@@ -4,6 +4,9 @@ import (
 	"flag"
 	"fmt"
 	"os"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/service"
 )
 
 // ContextCmd implements the context command.
@@ -11,6 +14,7 @@ type ContextCmd struct {
 	Project           string
 	Offset            int
 	IncludeSidechains bool
+	IncludeHashes     bool
 	OutputPath        string
 }
 
@@ -26,6 +30,7 @@ func (c *ContextCmd) Setup(fs *flag.FlagSet) {
 	fs.StringVar(&c.Project, "project", "", "Project name/path (optional)")
 	fs.IntVar(&c.Offset, "offset", -3, "Direction and count: negative = before target, positive = after target")
 	fs.BoolVar(&c.IncludeSidechains, "include-sidechains", true, "Include sidechain (agent) conversations")
+	fs.BoolVar(&c.IncludeHashes, "include-hashes", false, "Attach a stable content hash to each entry")
 	fs.StringVar(&c.OutputPath, "output", "", "File path to save the logs as JSON")
 }
 
@@ -37,7 +42,7 @@ func (c *ContextCmd) Run(ctx *Context, args []string) error {
 	sessionID := args[0]
 	targetUUID := args[1]
 
-	logs, err := ctx.Services.Session.GetLogsAroundEntry(sessionID, targetUUID, c.Project, c.Offset, c.IncludeSidechains)
+	logs, err := ctx.Services.Session.GetLogsAroundEntry(sessionID, targetUUID, c.Project, c.Offset, c.IncludeSidechains, c.IncludeHashes)
 	if err != nil {
 		return err
 	}
@@ -61,8 +66,7 @@ func (c *ContextCmd) Run(ctx *Context, args []string) error {
 			return fmt.Errorf("failed to write logs: %w", err)
 		}
 
-		out.PrintLine("Logs saved to: %s", c.OutputPath)
-		return nil
+		return out.WriteSaved("Logs", service.NewOutputManifest(c.OutputPath, models.OutputTypeJSON))
 	}
 
 	// Output to stdout
@@ -88,7 +92,7 @@ func (c *ContextCmd) Run(ctx *Context, args []string) error {
 			roleStr = fmt.Sprintf("%s (%s)", e.Role, e.ToolName)
 		}
 
-		out.PrintLine("%s [%+d] %s %s", marker, e.Offset, e.Timestamp, roleStr)
+		out.PrintLine("%s [%+d] %s %s", marker, e.Offset, FormatClock(e.Timestamp, e.LocalTime), roleStr)
 		out.PrintLine("      %s", Truncate(e.Content, 80))
 		if e.IsError {
 			out.PrintLine("      [ERROR]")
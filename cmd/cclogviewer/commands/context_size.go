@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/service"
+)
+
+// ContextSizeCmd implements the context-size command.
+type ContextSizeCmd struct {
+	AgentID    string
+	Project    string
+	FilePath   string
+	OutputPath string
+}
+
+func (c *ContextSizeCmd) Name() string {
+	return "context-size"
+}
+
+func (c *ContextSizeCmd) Description() string {
+	return "Show context window size at each assistant turn"
+}
+
+func (c *ContextSizeCmd) Setup(fs *flag.FlagSet) {
+	fs.StringVar(&c.AgentID, "agent-id", "", "Specific subagent ID to analyze")
+	fs.StringVar(&c.Project, "project", "", "Project name/path (optional)")
+	fs.StringVar(&c.FilePath, "file", "", "Direct path to a JSONL log file (instead of a session ID)")
+	fs.StringVar(&c.OutputPath, "output", "", "File path to save the history as JSON")
+}
+
+func (c *ContextSizeCmd) Run(ctx *Context, args []string) error {
+	if len(args) < 1 && c.FilePath == "" {
+		return fmt.Errorf("session ID is required\nUsage: cclogviewer context-size <session-id> [flags]")
+	}
+
+	var history *models.ContextSizeHistory
+	var err error
+
+	if c.FilePath != "" {
+		history, err = ctx.Services.Session.GetContextSizeHistoryFromFile(c.FilePath)
+	} else {
+		history, err = ctx.Services.Session.GetContextSizeHistory(args[0], c.AgentID, c.Project)
+	}
+	if err != nil {
+		return err
+	}
+
+	if history == nil {
+		return fmt.Errorf("session not found: %s", args[0])
+	}
+
+	out := NewOutputWriter(ctx.Output, ctx.Config.JSONOutput)
+
+	// Save to file if output path specified
+	if c.OutputPath != "" {
+		file, err := os.Create(c.OutputPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+
+		fileOut := NewOutputWriter(file, true)
+		if err := fileOut.WriteJSON(history); err != nil {
+			return fmt.Errorf("failed to write context size history: %w", err)
+		}
+
+		return out.WriteSaved("Context size history", service.NewOutputManifest(c.OutputPath, models.OutputTypeJSON))
+	}
+
+	// Output to stdout
+	if ctx.Config.JSONOutput {
+		return out.WriteJSON(history)
+	}
+
+	// Human-readable output
+	out.PrintLine("Context Size: %s\n", history.SessionID)
+
+	if len(history.Points) == 0 {
+		out.PrintLine("No assistant turns with token usage found")
+		return nil
+	}
+
+	headers := []string{"Step", "Timestamp", "Context", "Delta", ""}
+	var rows [][]string
+	for _, p := range history.Points {
+		marker := ""
+		if p.IsMax {
+			marker = "<- max"
+		}
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", p.Step),
+			p.Timestamp,
+			FormatNumber(p.ContextTokens),
+			fmt.Sprintf("%+d", p.Delta),
+			marker,
+		})
+	}
+	out.WriteTable(headers, rows)
+
+	out.PrintLine("")
+	out.PrintKeyValue("Max", fmt.Sprintf("%s (step %d)", FormatNumber(history.MaxTokens), history.MaxStep))
+	out.PrintKeyValue("Final", FormatNumber(history.FinalTokens))
+
+	return nil
+}
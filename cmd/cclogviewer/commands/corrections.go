@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/service"
+)
+
+// CorrectionsCmd implements the corrections command.
+type CorrectionsCmd struct {
+	Project    string
+	FilePath   string
+	OutputPath string
+	Phrases    string
+}
+
+func (c *CorrectionsCmd) Name() string {
+	return "corrections"
+}
+
+func (c *CorrectionsCmd) Description() string {
+	return "Find assistant turns that correct themselves after a failed tool call"
+}
+
+func (c *CorrectionsCmd) Setup(fs *flag.FlagSet) {
+	fs.StringVar(&c.Project, "project", "", "Project name/path (optional)")
+	fs.StringVar(&c.FilePath, "file", "", "Direct path to a JSONL log file (instead of a session ID)")
+	fs.StringVar(&c.OutputPath, "output", "", "File path to save the corrections as JSON")
+	fs.StringVar(&c.Phrases, "phrases", "", "Comma-separated correction phrases, replacing the defaults: "+strings.Join(service.DefaultCorrectionPhrases, ", "))
+}
+
+func (c *CorrectionsCmd) Run(ctx *Context, args []string) error {
+	if len(args) < 1 && c.FilePath == "" {
+		return fmt.Errorf("session ID is required\nUsage: cclogviewer corrections <session-id> [flags]")
+	}
+
+	if c.Phrases != "" {
+		service.DefaultCorrectionDetector.Configure(strings.Split(c.Phrases, ","))
+	}
+
+	var corrections []models.Correction
+	var err error
+
+	if c.FilePath != "" {
+		corrections, err = ctx.Services.Session.ExtractCorrectionsFromFile(c.FilePath)
+	} else {
+		corrections, err = ctx.Services.Session.ExtractCorrections(args[0], c.Project)
+	}
+	if err != nil {
+		return err
+	}
+
+	if corrections == nil {
+		return fmt.Errorf("session not found: %s", args[0])
+	}
+
+	out := NewOutputWriter(ctx.Output, ctx.Config.JSONOutput)
+
+	// Save to file if output path specified
+	if c.OutputPath != "" {
+		file, err := os.Create(c.OutputPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+
+		fileOut := NewOutputWriter(file, true)
+		if err := fileOut.WriteJSON(corrections); err != nil {
+			return fmt.Errorf("failed to write corrections: %w", err)
+		}
+
+		return out.WriteSaved("Corrections", service.NewOutputManifest(c.OutputPath, models.OutputTypeJSON))
+	}
+
+	// Output to stdout
+	if ctx.Config.JSONOutput {
+		return out.WriteJSON(map[string]interface{}{
+			"corrections": corrections,
+			"count":       len(corrections),
+		})
+	}
+
+	// Human-readable output
+	if len(corrections) == 0 {
+		out.PrintLine("No self-corrections found")
+		return nil
+	}
+
+	headers := []string{"Time", "Failed Tool", "Phrase", "Text"}
+	var rows [][]string
+	for _, cr := range corrections {
+		rows = append(rows, []string{
+			cr.Timestamp,
+			cr.FailedTool,
+			cr.Phrase,
+			Truncate(strings.Join(strings.Fields(cr.Text), " "), 60),
+		})
+	}
+	out.WriteTable(headers, rows)
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/service"
+)
+
+// DumpCmd implements the dump command.
+type DumpCmd struct {
+	Project    string
+	FilePath   string
+	OutputPath string
+}
+
+func (c *DumpCmd) Name() string {
+	return "dump"
+}
+
+func (c *DumpCmd) Description() string {
+	return "Write the processed entry tree as JSON, for debugging the processor"
+}
+
+func (c *DumpCmd) Setup(fs *flag.FlagSet) {
+	fs.StringVar(&c.Project, "project", "", "Project name/path (optional)")
+	fs.StringVar(&c.FilePath, "file", "", "Direct path to a JSONL log file (instead of a session ID)")
+	fs.StringVar(&c.OutputPath, "output", "", "File path to save the dump")
+}
+
+func (c *DumpCmd) Run(ctx *Context, args []string) error {
+	if len(args) < 1 && c.FilePath == "" {
+		return fmt.Errorf("session ID is required\nUsage: cclogviewer dump <session-id> [flags]")
+	}
+
+	var entries []*models.ProcessedEntry
+	var err error
+
+	if c.FilePath != "" {
+		entries, err = ctx.Services.Session.DumpEntriesFromFile(c.FilePath)
+	} else {
+		entries, err = ctx.Services.Session.DumpEntries(args[0], c.Project)
+	}
+	if err != nil {
+		return err
+	}
+
+	if entries == nil {
+		return fmt.Errorf("session not found: %s", args[0])
+	}
+
+	// The dump is always JSON; there is no human-readable form
+	if c.OutputPath != "" {
+		file, err := os.Create(c.OutputPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+
+		if err := NewOutputWriter(file, true).WriteJSON(entries); err != nil {
+			return fmt.Errorf("failed to write dump: %w", err)
+		}
+
+		return NewOutputWriter(ctx.Output, ctx.Config.JSONOutput).WriteSaved("Dump", service.NewOutputManifest(c.OutputPath, models.OutputTypeJSON))
+	}
+
+	return NewOutputWriter(ctx.Output, true).WriteJSON(entries)
+}
@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/service"
+)
+
+// DuplicatePromptsCmd implements the duplicate-prompts command.
+type DuplicatePromptsCmd struct {
+	Project    string
+	Days       int
+	Threshold  float64
+	OutputPath string
+}
+
+func (c *DuplicatePromptsCmd) Name() string {
+	return "duplicate-prompts"
+}
+
+func (c *DuplicatePromptsCmd) Description() string {
+	return "Find similar opening prompts repeated across sessions"
+}
+
+func (c *DuplicatePromptsCmd) Setup(fs *flag.FlagSet) {
+	fs.StringVar(&c.Project, "project", "", "Limit to a specific project (default: all projects)")
+	fs.IntVar(&c.Days, "days", 0, "Only include sessions from the last N days")
+	fs.Float64Var(&c.Threshold, "threshold", service.DefaultPromptSimilarityThreshold, "Minimum word similarity (0-1) for prompts to be grouped")
+	fs.StringVar(&c.OutputPath, "output", "", "File path to save the clusters as JSON")
+}
+
+func (c *DuplicatePromptsCmd) Run(ctx *Context, args []string) error {
+	clusters, err := ctx.Services.Search.FindDuplicatePrompts(c.Project, c.Days, c.Threshold)
+	if err != nil {
+		return err
+	}
+
+	out := NewOutputWriter(ctx.Output, ctx.Config.JSONOutput)
+
+	// Save to file if output path specified
+	if c.OutputPath != "" {
+		file, err := os.Create(c.OutputPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+
+		fileOut := NewOutputWriter(file, true)
+		if err := fileOut.WriteJSON(clusters); err != nil {
+			return fmt.Errorf("failed to write clusters: %w", err)
+		}
+
+		return out.WriteSaved("Duplicate prompts", service.NewOutputManifest(c.OutputPath, models.OutputTypeJSON))
+	}
+
+	// Output to stdout
+	if ctx.Config.JSONOutput {
+		return out.WriteJSON(map[string]interface{}{
+			"clusters": clusters,
+			"count":    len(clusters),
+		})
+	}
+
+	// Human-readable output
+	if len(clusters) == 0 {
+		out.PrintLine("No duplicate prompts found")
+		return nil
+	}
+
+	for _, cluster := range clusters {
+		out.PrintSection(fmt.Sprintf("%dx %s", cluster.Count, Truncate(cluster.Prompt, 60)))
+
+		headers := []string{"Session ID", "Project", "Start Time", "Similarity"}
+		var rows [][]string
+		for _, o := range cluster.Occurrences {
+			rows = append(rows, []string{
+				o.SessionID,
+				Truncate(o.Project, 30),
+				FormatTime(o.StartTime),
+				fmt.Sprintf("%.2f", o.Similarity),
+			})
+		}
+		out.WriteTable(headers, rows)
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/processor/tools/diff"
+	"github.com/brads3290/cclogviewer/internal/service"
+)
+
+// EditChainCmd implements the edit-chain command.
+type EditChainCmd struct {
+	Path       string
+	Project    string
+	FilePath   string
+	OutputPath string
+}
+
+func (c *EditChainCmd) Name() string {
+	return "edit-chain"
+}
+
+func (c *EditChainCmd) Description() string {
+	return "Show every Edit/MultiEdit/Write a session made to one file, in order"
+}
+
+func (c *EditChainCmd) Setup(fs *flag.FlagSet) {
+	fs.StringVar(&c.Path, "path", "", "File whose edits to show: the full path, or a trailing part such as internal/app.go (required)")
+	fs.StringVar(&c.Project, "project", "", "Project name/path (optional)")
+	fs.StringVar(&c.FilePath, "file", "", "Direct path to a JSONL log file (instead of a session ID)")
+	fs.StringVar(&c.OutputPath, "output", "", "File path to save the edit chain as JSON")
+}
+
+func (c *EditChainCmd) Run(ctx *Context, args []string) error {
+	if len(args) < 1 && c.FilePath == "" {
+		return fmt.Errorf("session ID is required\nUsage: cclogviewer edit-chain --path <file> <session-id> [flags]")
+	}
+	if c.Path == "" {
+		return fmt.Errorf("--path is required\nUsage: cclogviewer edit-chain --path <file> <session-id> [flags]")
+	}
+
+	var ops []models.EditOp
+	var err error
+
+	if c.FilePath != "" {
+		ops, err = ctx.Services.Session.FileEditChainFromFile(c.FilePath, c.Path)
+	} else {
+		ops, err = ctx.Services.Session.FileEditChain(args[0], c.Project, c.Path)
+	}
+	if err != nil {
+		return err
+	}
+
+	if ops == nil {
+		return fmt.Errorf("session not found: %s", args[0])
+	}
+
+	out := NewOutputWriter(ctx.Output, ctx.Config.JSONOutput)
+
+	result := map[string]interface{}{
+		"path":  c.Path,
+		"edits": ops,
+		"count": len(ops),
+	}
+
+	// Save to file if output path specified
+	if c.OutputPath != "" {
+		file, err := os.Create(c.OutputPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+
+		fileOut := NewOutputWriter(file, true)
+		if err := fileOut.WriteJSON(result); err != nil {
+			return fmt.Errorf("failed to write edit chain: %w", err)
+		}
+
+		return out.WriteSaved("Edit chain", service.NewOutputManifest(c.OutputPath, models.OutputTypeJSON))
+	}
+
+	// Output to stdout
+	if ctx.Config.JSONOutput {
+		return out.WriteJSON(result)
+	}
+
+	// Human-readable output
+	if len(ops) == 0 {
+		out.PrintLine("No edits found for: %s", c.Path)
+		return nil
+	}
+
+	out.PrintLine("%d edits to %s", len(ops), c.Path)
+
+	for i, op := range ops {
+		title := fmt.Sprintf("#%d  %s  %s", i+1, op.Timestamp, op.Tool)
+		if op.Tool == "MultiEdit" {
+			title += fmt.Sprintf(" [%d]", op.EditIndex+1)
+		}
+		if op.ReplaceAll {
+			title += "  (replace all)"
+		}
+		if op.Failed {
+			title += "  (failed)"
+		}
+		out.PrintSection(title)
+		out.PrintKeyValue("Entry", op.UUID)
+		out.PrintKeyValue("File", op.FilePath)
+		for _, line := range strings.Split(strings.TrimRight(diff.ComputeUnifiedDiff(op.OldString, op.NewString, 0), "\n"), "\n") {
+			out.PrintLine("    %s", line)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// sparkBlocks are the bar heights of a sparkline, lowest first.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// ErrorTrendCmd implements the error-trend command.
+type ErrorTrendCmd struct {
+	Days       int
+	BucketDays int
+}
+
+func (c *ErrorTrendCmd) Name() string {
+	return "error-trend"
+}
+
+func (c *ErrorTrendCmd) Description() string {
+	return "Show how a project's error rate changes over time"
+}
+
+func (c *ErrorTrendCmd) Setup(fs *flag.FlagSet) {
+	fs.IntVar(&c.Days, "days", 56, "Number of days to include (0 = since the first session)")
+	fs.IntVar(&c.BucketDays, "bucket", 7, "Days per bucket")
+}
+
+func (c *ErrorTrendCmd) Run(ctx *Context, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("project name is required\nUsage: cclogviewer error-trend <project> [flags]")
+	}
+
+	project := args[0]
+	trend, report, err := ctx.Services.Session.ErrorTrend(project, c.Days, c.BucketDays)
+	if err != nil {
+		return err
+	}
+
+	out := NewOutputWriter(ctx.Output, ctx.Config.JSONOutput)
+
+	if ctx.Config.JSONOutput {
+		return out.WriteJSON(map[string]interface{}{
+			"project":          project,
+			"trend":            trend,
+			"count":            len(trend),
+			"sessions_scanned": report.SessionsScanned,
+			"failures":         report.Failures,
+		})
+	}
+
+	WriteScanWarning(ctx.ErrOutput, report)
+
+	// Human-readable output
+	out.PrintLine("Error trend for project: %s\n", project)
+
+	perSession := make([]float64, len(trend))
+	headers := []string{"Start", "End", "Sessions", "Messages", "Errors", "Per Session", "Per 1k Msgs"}
+	var rows [][]string
+	for i, p := range trend {
+		perSession[i] = p.ErrorsPerSession
+		rows = append(rows, []string{
+			p.Start,
+			p.End,
+			FormatNumber(p.Sessions),
+			FormatNumber(p.Messages),
+			FormatNumber(p.Errors),
+			fmt.Sprintf("%.2f", p.ErrorsPerSession),
+			fmt.Sprintf("%.1f", p.ErrorsPer1000Messages),
+		})
+	}
+
+	out.PrintKeyValue("Errors/session", Sparkline(perSession))
+	out.PrintLine("")
+	out.WriteTable(headers, rows)
+
+	return nil
+}
+
+// Sparkline draws values as a row of block characters scaled to the largest
+// value. Zero values get the lowest block.
+func Sparkline(values []float64) string {
+	maxValue := 0.0
+	for _, v := range values {
+		if v > maxValue {
+			maxValue = v
+		}
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		level := 0
+		if maxValue > 0 {
+			level = int(v / maxValue * float64(len(sparkBlocks)-1))
+		}
+		b.WriteRune(sparkBlocks[level])
+	}
+	return b.String()
+}
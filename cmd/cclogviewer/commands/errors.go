@@ -4,6 +4,9 @@ import (
 	"flag"
 	"fmt"
 	"os"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/service"
 )
 
 // ErrorsCmd implements the errors command.
@@ -13,6 +16,7 @@ type ErrorsCmd struct {
 	IncludeSidechains bool
 	Limit             int
 	OutputPath        string
+	CountOnly         bool
 }
 
 func (c *ErrorsCmd) Name() string {
@@ -29,6 +33,7 @@ func (c *ErrorsCmd) Setup(fs *flag.FlagSet) {
 	fs.BoolVar(&c.IncludeSidechains, "include-sidechains", true, "Include sidechain (agent) conversations in analysis")
 	fs.IntVar(&c.Limit, "limit", 20, "Maximum number of errors to return")
 	fs.StringVar(&c.OutputPath, "output", "", "File path to save the errors as JSON")
+	fs.BoolVar(&c.CountOnly, "count-only", false, "Print only the total number of errors")
 }
 
 func (c *ErrorsCmd) Run(ctx *Context, args []string) error {
@@ -47,6 +52,11 @@ func (c *ErrorsCmd) Run(ctx *Context, args []string) error {
 	}
 
 	out := NewOutputWriter(ctx.Output, ctx.Config.JSONOutput)
+	out.SetColor(ctx.Config.ColorOutput)
+
+	if c.CountOnly {
+		return out.WriteCount(errors.TotalErrors)
+	}
 
 	// Save to file if output path specified
 	if c.OutputPath != "" {
@@ -61,8 +71,7 @@ func (c *ErrorsCmd) Run(ctx *Context, args []string) error {
 			return fmt.Errorf("failed to write errors: %w", err)
 		}
 
-		out.PrintLine("Errors saved to: %s", c.OutputPath)
-		return nil
+		return out.WriteSaved("Errors", service.NewOutputManifest(c.OutputPath, models.OutputTypeJSON))
 	}
 
 	// Output to stdout
@@ -79,12 +88,21 @@ func (c *ErrorsCmd) Run(ctx *Context, args []string) error {
 		return nil
 	}
 
+	if rc := errors.RootCause; rc != nil {
+		out.PrintSection("Likely Root Cause")
+		out.PrintLine("[%s] %s %s", out.Red(rc.Type), out.Dim(rc.Timestamp), rc.ToolName)
+		out.PrintLine("   Message: %s", out.Red(Truncate(rc.Message, 100)))
+		out.PrintLine("   UUID: %s", rc.UUID)
+		out.PrintLine("   Why: %s", errors.RootCauseReason)
+		out.PrintLine("")
+	}
+
 	for i, e := range errors.Errors {
-		out.PrintLine("%d. [%s] %s", i+1, e.Type, e.Timestamp)
+		out.PrintLine("%d. [%s] %s", i+1, out.Red(e.Type), out.Dim(e.Timestamp))
 		if e.ToolName != "" {
 			out.PrintLine("   Tool: %s", e.ToolName)
 		}
-		out.PrintLine("   Message: %s", Truncate(e.Message, 100))
+		out.PrintLine("   Message: %s", out.Red(Truncate(e.Message, 100)))
 		out.PrintLine("   UUID: %s", e.UUID)
 		out.PrintLine("   Entry Index: %d", e.EntryIndex)
 		out.PrintLine("")
@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/service"
+)
+
+// FileEditsCmd implements the file-edits command.
+type FileEditsCmd struct {
+	Project    string
+	Days       int
+	Limit      int
+	OutputPath string
+}
+
+func (c *FileEditsCmd) Name() string {
+	return "file-edits"
+}
+
+func (c *FileEditsCmd) Description() string {
+	return "Find Edit/Write tool calls on a file across sessions"
+}
+
+func (c *FileEditsCmd) Setup(fs *flag.FlagSet) {
+	fs.StringVar(&c.Project, "project", "", "Limit to a specific project (default: all projects)")
+	fs.IntVar(&c.Days, "days", 0, "Only search sessions from the last N days")
+	fs.IntVar(&c.Limit, "limit", 20, "Maximum edits to return (0 for all)")
+	fs.StringVar(&c.OutputPath, "output", "", "File path to save the edits as JSON")
+}
+
+func (c *FileEditsCmd) Run(ctx *Context, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("file path substring is required\nUsage: cclogviewer file-edits <file> [flags]")
+	}
+
+	file := args[0]
+	hits, err := ctx.Services.Session.FindFileEdits(file, c.Project, c.Days)
+	if err != nil {
+		return err
+	}
+
+	total := len(hits)
+	if c.Limit > 0 && len(hits) > c.Limit {
+		hits = hits[:c.Limit]
+	}
+
+	out := NewOutputWriter(ctx.Output, ctx.Config.JSONOutput)
+
+	result := map[string]interface{}{
+		"file":  file,
+		"edits": hits,
+		"count": len(hits),
+		"total": total,
+	}
+
+	// Save to file if output path specified
+	if c.OutputPath != "" {
+		f, err := os.Create(c.OutputPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+
+		fileOut := NewOutputWriter(f, true)
+		if err := fileOut.WriteJSON(result); err != nil {
+			return fmt.Errorf("failed to write edits: %w", err)
+		}
+
+		return out.WriteSaved("File edits", service.NewOutputManifest(c.OutputPath, models.OutputTypeJSON))
+	}
+
+	// Output to stdout
+	if ctx.Config.JSONOutput {
+		return out.WriteJSON(result)
+	}
+
+	// Human-readable output
+	if len(hits) == 0 {
+		out.PrintLine("No edits found for: %s", file)
+		return nil
+	}
+
+	out.PrintLine("Edits to %s (showing %d of %d)", file, len(hits), total)
+
+	for _, h := range hits {
+		out.PrintSection(fmt.Sprintf("%s  %s  %s", FormatTime(h.Timestamp), h.Tool, h.FilePath))
+		out.PrintKeyValue("Session", h.SessionID)
+		out.PrintKeyValue("Entry", h.UUID)
+		for _, line := range strings.Split(strings.TrimRight(h.Snippet, "\n"), "\n") {
+			out.PrintLine("    %s", line)
+		}
+	}
+
+	return nil
+}
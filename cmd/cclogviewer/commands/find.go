@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+)
+
+// FindCmd implements the find command.
+type FindCmd struct {
+	Project string
+}
+
+func (c *FindCmd) Name() string {
+	return "find"
+}
+
+func (c *FindCmd) Description() string {
+	return "Find which session contains an entry, by full or partial UUID"
+}
+
+func (c *FindCmd) Setup(fs *flag.FlagSet) {
+	fs.StringVar(&c.Project, "project", "", "Project name/path to search (default: all projects)")
+}
+
+func (c *FindCmd) Run(ctx *Context, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("UUID prefix is required\nUsage: cclogviewer find <uuid-prefix> [flags]")
+	}
+
+	prefix := args[0]
+	locations, report, err := ctx.Services.Session.FindEntryByUUID(prefix, c.Project)
+	if err != nil {
+		return err
+	}
+
+	out := NewOutputWriter(ctx.Output, ctx.Config.JSONOutput)
+
+	if ctx.Config.JSONOutput {
+		return out.WriteJSON(map[string]interface{}{
+			"prefix":           prefix,
+			"matches":          locations,
+			"count":            len(locations),
+			"sessions_scanned": report.SessionsScanned,
+			"failures":         report.Failures,
+		})
+	}
+
+	WriteScanWarning(ctx.ErrOutput, report)
+
+	// Human-readable output
+	if len(locations) == 0 {
+		out.PrintLine("No entries found with UUID prefix: %s", prefix)
+		return nil
+	}
+
+	if len(locations) > 1 {
+		out.PrintLine("%d entries match %s; use a longer prefix to narrow it down\n", len(locations), prefix)
+	}
+
+	headers := []string{"Project", "Session ID", "Index", "Timestamp", "Type", "UUID"}
+	var rows [][]string
+	for _, loc := range locations {
+		entryType := loc.Type
+		if loc.IsSidechain {
+			entryType += " (sidechain)"
+		}
+		rows = append(rows, []string{
+			Truncate(loc.Project, 30),
+			loc.SessionID,
+			strconv.Itoa(loc.Index),
+			loc.Timestamp,
+			entryType,
+			loc.UUID,
+		})
+	}
+	out.WriteTable(headers, rows)
+
+	out.PrintLine("\nUse 'cclogviewer context --project <project> <session-id> <uuid>' to see the surrounding entries")
+
+	return nil
+}
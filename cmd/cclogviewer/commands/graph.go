@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/service"
+)
+
+// GraphCmd implements the graph command.
+type GraphCmd struct {
+	Project    string
+	FilePath   string
+	OutputPath string
+	Format     string
+}
+
+func (c *GraphCmd) Name() string {
+	return "graph"
+}
+
+func (c *GraphCmd) Description() string {
+	return "Export a graph of how a session's tool calls feed into each other (JSON or DOT)"
+}
+
+func (c *GraphCmd) Setup(fs *flag.FlagSet) {
+	fs.StringVar(&c.Project, "project", "", "Project name/path (optional)")
+	fs.StringVar(&c.FilePath, "file", "", "Direct path to a JSONL log file (instead of a session ID)")
+	fs.StringVar(&c.OutputPath, "output", "", "File path to save the graph")
+	fs.StringVar(&c.Format, "format", "json", "Output format: json or dot (Graphviz)")
+}
+
+func (c *GraphCmd) Run(ctx *Context, args []string) error {
+	if len(args) < 1 && c.FilePath == "" {
+		return fmt.Errorf("session ID is required\nUsage: cclogviewer graph <session-id> [flags]")
+	}
+
+	if c.Format != "json" && c.Format != "dot" {
+		return fmt.Errorf("unsupported format: %s (expected json or dot)", c.Format)
+	}
+
+	var graph *models.ToolGraph
+	if c.FilePath != "" {
+		var err error
+		graph, err = ctx.Services.Session.ToolCallGraphFromFile(c.FilePath)
+		if err != nil {
+			return err
+		}
+	} else {
+		graph = ctx.Services.Session.ToolCallGraph(args[0], c.Project)
+		if graph == nil {
+			return fmt.Errorf("session not found: %s", args[0])
+		}
+	}
+
+	out := NewOutputWriter(ctx.Output, ctx.Config.JSONOutput)
+
+	if c.Format == "dot" {
+		dot := FormatToolGraphDOT(graph)
+		if c.OutputPath != "" {
+			if err := os.WriteFile(c.OutputPath, []byte(dot), 0644); err != nil {
+				return fmt.Errorf("failed to write graph: %w", err)
+			}
+			return out.WriteSaved("Graph", service.NewOutputManifest(c.OutputPath, models.OutputTypeDOT))
+		}
+		fmt.Fprint(ctx.Output, dot)
+		return nil
+	}
+
+	// Save to file if output path specified
+	if c.OutputPath != "" {
+		file, err := os.Create(c.OutputPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+
+		fileOut := NewOutputWriter(file, true)
+		if err := fileOut.WriteJSON(graph); err != nil {
+			return fmt.Errorf("failed to write graph: %w", err)
+		}
+
+		return out.WriteSaved("Graph", service.NewOutputManifest(c.OutputPath, models.OutputTypeJSON))
+	}
+
+	return out.WriteJSON(graph)
+}
+
+// FormatToolGraphDOT renders a tool graph in Graphviz DOT format. File edges
+// are solid and labeled with the file's base name; sequence edges are dashed.
+func FormatToolGraphDOT(graph *models.ToolGraph) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %q {\n", "tools_"+graph.SessionID)
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, fontname=\"Helvetica\"];\n")
+
+	for _, n := range graph.Nodes {
+		attrs := fmt.Sprintf("label=%q", n.Tool+"\n"+Truncate(n.Summary, 40))
+		if n.Failed {
+			attrs += ", color=red"
+		}
+		if n.IsSidechain {
+			attrs += ", style=dashed"
+		}
+		fmt.Fprintf(&b, "  %q [%s];\n", n.ID, attrs)
+	}
+
+	for _, e := range graph.Edges {
+		if e.Kind == models.ToolGraphEdgeFile {
+			fmt.Fprintf(&b, "  %q -> %q [label=%q];\n", e.From, e.To, filepath.Base(e.File))
+		} else {
+			fmt.Fprintf(&b, "  %q -> %q [style=dashed, color=gray];\n", e.From, e.To)
+		}
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/service"
+)
+
+// HeavyReadsCmd implements the heavy-reads command.
+type HeavyReadsCmd struct {
+	Project    string
+	FilePath   string
+	MinBytes   int
+	OutputPath string
+}
+
+func (c *HeavyReadsCmd) Name() string {
+	return "heavy-reads"
+}
+
+func (c *HeavyReadsCmd) Description() string {
+	return "List Read calls whose large or binary results bloated the context"
+}
+
+func (c *HeavyReadsCmd) Setup(fs *flag.FlagSet) {
+	fs.StringVar(&c.Project, "project", "", "Project name/path (optional)")
+	fs.StringVar(&c.FilePath, "file", "", "Direct path to a JSONL log file (instead of a session ID)")
+	fs.IntVar(&c.MinBytes, "min-bytes", 0, "Report reads at or above this many bytes (default: --heavy-read-bytes)")
+	fs.StringVar(&c.OutputPath, "output", "", "File path to save the results as JSON")
+}
+
+func (c *HeavyReadsCmd) Run(ctx *Context, args []string) error {
+	if len(args) < 1 && c.FilePath == "" {
+		return fmt.Errorf("session ID is required\nUsage: cclogviewer heavy-reads <session-id> [flags]")
+	}
+
+	var reads []models.HeavyRead
+	var err error
+
+	if c.FilePath != "" {
+		reads, err = ctx.Services.Session.HeavyReadsFromFile(c.FilePath, c.MinBytes)
+	} else {
+		reads, err = ctx.Services.Session.HeavyReads(args[0], c.Project, c.MinBytes)
+	}
+	if err != nil {
+		return err
+	}
+
+	if reads == nil {
+		return fmt.Errorf("session not found: %s", args[0])
+	}
+
+	out := NewOutputWriter(ctx.Output, ctx.Config.JSONOutput)
+
+	// Save to file if output path specified
+	if c.OutputPath != "" {
+		file, err := os.Create(c.OutputPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+
+		fileOut := NewOutputWriter(file, true)
+		if err := fileOut.WriteJSON(reads); err != nil {
+			return fmt.Errorf("failed to write results: %w", err)
+		}
+
+		return out.WriteSaved("Results", service.NewOutputManifest(c.OutputPath, models.OutputTypeJSON))
+	}
+
+	// Output to stdout
+	if ctx.Config.JSONOutput {
+		return out.WriteJSON(map[string]interface{}{
+			"reads": reads,
+			"count": len(reads),
+		})
+	}
+
+	// Human-readable output
+	if len(reads) == 0 {
+		out.PrintLine("No heavy reads found")
+		return nil
+	}
+
+	total := 0
+	headers := []string{"Bytes", "Binary", "Time", "File"}
+	var rows [][]string
+	for _, r := range reads {
+		total += r.Bytes
+		binary := ""
+		if r.Binary {
+			binary = "yes"
+		}
+		rows = append(rows, []string{
+			FormatNumber(r.Bytes),
+			binary,
+			r.Timestamp,
+			r.FilePath,
+		})
+	}
+	out.WriteTable(headers, rows)
+	out.PrintLine("\n%d heavy reads, %s bytes total", len(reads), FormatNumber(total))
+
+	return nil
+}
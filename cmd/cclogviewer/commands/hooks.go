@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/service"
+)
+
+// HooksCmd implements the hooks command.
+type HooksCmd struct {
+	Project    string
+	FilePath   string
+	OutputPath string
+}
+
+func (c *HooksCmd) Name() string {
+	return "hooks"
+}
+
+func (c *HooksCmd) Description() string {
+	return "Show which hooks fired in a session, on which tools, and whether they blocked"
+}
+
+func (c *HooksCmd) Setup(fs *flag.FlagSet) {
+	fs.StringVar(&c.Project, "project", "", "Project name/path (optional)")
+	fs.StringVar(&c.FilePath, "file", "", "Direct path to a JSONL log file (instead of a session ID)")
+	fs.StringVar(&c.OutputPath, "output", "", "File path to save the hook events as JSON")
+}
+
+func (c *HooksCmd) Run(ctx *Context, args []string) error {
+	if len(args) < 1 && c.FilePath == "" {
+		return fmt.Errorf("session ID is required\nUsage: cclogviewer hooks <session-id> [flags]")
+	}
+
+	var hooks []models.HookEvent
+	var err error
+
+	if c.FilePath != "" {
+		hooks, err = ctx.Services.Session.ExtractHooksFromFile(c.FilePath)
+	} else {
+		hooks, err = ctx.Services.Session.ExtractHooks(args[0], c.Project)
+	}
+	if err != nil {
+		return err
+	}
+
+	if hooks == nil {
+		return fmt.Errorf("session not found: %s", args[0])
+	}
+
+	out := NewOutputWriter(ctx.Output, ctx.Config.JSONOutput)
+
+	// Save to file if output path specified
+	if c.OutputPath != "" {
+		file, err := os.Create(c.OutputPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+
+		fileOut := NewOutputWriter(file, true)
+		if err := fileOut.WriteJSON(hooks); err != nil {
+			return fmt.Errorf("failed to write hooks: %w", err)
+		}
+
+		return out.WriteSaved("Hooks", service.NewOutputManifest(c.OutputPath, models.OutputTypeJSON))
+	}
+
+	// Output to stdout
+	if ctx.Config.JSONOutput {
+		return out.WriteJSON(map[string]interface{}{
+			"hooks": hooks,
+			"count": len(hooks),
+		})
+	}
+
+	// Human-readable output
+	if len(hooks) == 0 {
+		out.PrintLine("No hook executions found")
+		return nil
+	}
+
+	headers := []string{"Time", "Event", "Tool", "Outcome", "Command", "Message"}
+	var rows [][]string
+	for _, h := range hooks {
+		rows = append(rows, []string{
+			h.Timestamp,
+			h.Event,
+			h.Tool,
+			h.Outcome,
+			Truncate(h.Command, 30),
+			Truncate(h.Message, 50),
+		})
+	}
+	out.WriteTable(headers, rows)
+
+	return nil
+}
@@ -3,6 +3,10 @@ package commands
 import (
 	"flag"
 	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
 
 	"github.com/brads3290/cclogviewer/internal/service"
 )
@@ -10,10 +14,15 @@ import (
 // HTMLCmd implements the html command.
 type HTMLCmd struct {
 	SessionID   string
+	SessionIDs  string
 	FilePath    string
 	Project     string
 	OutputPath  string
 	OpenBrowser bool
+	Watch       bool
+	Format      string
+	// SidechainsOnly renders only the subagent transcripts
+	SidechainsOnly bool
 }
 
 func (c *HTMLCmd) Name() string {
@@ -26,33 +35,61 @@ func (c *HTMLCmd) Description() string {
 
 func (c *HTMLCmd) Setup(fs *flag.FlagSet) {
 	fs.StringVar(&c.SessionID, "session", "", "Session UUID to generate HTML for")
+	fs.StringVar(&c.SessionIDs, "sessions", "", "Comma-separated session UUIDs to combine into one page with a session sidebar")
 	fs.StringVar(&c.FilePath, "file", "", "Direct path to a JSONL log file")
-	fs.StringVar(&c.Project, "project", "", "Project name/path (only used with --session)")
+	fs.StringVar(&c.Project, "project", "", "Project name/path (only used with --session or --sessions)")
 	fs.StringVar(&c.OutputPath, "output", "", "Output HTML file path (creates temp file if not specified)")
 	fs.BoolVar(&c.OpenBrowser, "open", false, "Open the generated HTML file in browser")
+	fs.StringVar(&c.Format, "format", "html", "Output format: html, or ipynb for a Jupyter notebook with Bash calls as code cells")
+	fs.BoolVar(&c.SidechainsOnly, "sidechains-only", false, "Only render sidechain (agent) conversations, each under its agent type header")
+	fs.BoolVar(&c.Watch, "watch", false, "Keep regenerating the HTML as the session grows; the page reloads itself (Ctrl-C to stop)")
 }
 
 func (c *HTMLCmd) Run(ctx *Context, args []string) error {
 	// Check for positional argument as file path
-	if len(args) > 0 && c.FilePath == "" && c.SessionID == "" {
+	if len(args) > 0 && c.FilePath == "" && c.SessionID == "" && c.SessionIDs == "" {
 		c.FilePath = args[0]
 	}
 
-	if c.SessionID == "" && c.FilePath == "" {
-		return fmt.Errorf("either --session or --file (or a file path argument) is required\nUsage: cclogviewer html [--session <id> | --file <path> | <path>] [flags]")
+	if c.SessionID == "" && c.SessionIDs == "" && c.FilePath == "" {
+		return fmt.Errorf("one of --session, --sessions or --file (or a file path argument) is required\nUsage: cclogviewer html [--session <id> | --sessions <id,id,...> | --file <path> | <path>] [flags]")
 	}
 
 	out := NewOutputWriter(ctx.Output, ctx.Config.JSONOutput)
 
+	if c.Format == "ipynb" {
+		return c.runNotebook(ctx, out)
+	}
+	if c.Format != "html" {
+		return fmt.Errorf("unknown format: %s (expected html or ipynb)", c.Format)
+	}
+
+	if c.SidechainsOnly {
+		return c.runSidechains(ctx, out)
+	}
+
+	if c.Watch {
+		return c.runWatch(ctx, out)
+	}
+
 	var result interface{}
 	var err error
 
-	if c.FilePath != "" {
+	if c.SessionIDs != "" {
+		// Combine several sessions into one page
+		var ids []string
+		for _, id := range strings.Split(c.SessionIDs, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				ids = append(ids, id)
+			}
+		}
+		result, err = ctx.Services.Session.GenerateMultiSessionHTML(ids, c.Project, c.OutputPath, c.OpenBrowser)
+	} else if c.FilePath != "" {
 		// Generate from file
-		result, err = ctx.Services.Session.GenerateHTMLFromFile(c.FilePath, c.OutputPath, c.OpenBrowser)
+		result, err = ctx.Services.Session.GenerateHTMLFromFile(c.FilePath, c.OutputPath, c.OpenBrowser, false)
 	} else {
 		// Generate from session
-		result, err = ctx.Services.Session.GenerateSessionHTML(c.SessionID, c.Project, c.OutputPath, c.OpenBrowser)
+		result, err = ctx.Services.Session.GenerateSessionHTML(c.SessionID, c.Project, c.OutputPath, c.OpenBrowser, false)
 	}
 
 	if err != nil {
@@ -73,3 +110,101 @@ func (c *HTMLCmd) Run(ctx *Context, args []string) error {
 
 	return nil
 }
+
+// runNotebook exports a single session as a Jupyter notebook.
+func (c *HTMLCmd) runNotebook(ctx *Context, out *OutputWriter) error {
+	if c.SessionIDs != "" || c.Watch {
+		return fmt.Errorf("--format ipynb exports a single session; use --session or --file without --sessions or --watch")
+	}
+
+	var result *service.HTMLGenerationResult
+	var err error
+	if c.FilePath != "" {
+		result, err = ctx.Services.Session.GenerateNotebookFromFile(c.FilePath, c.OutputPath)
+	} else {
+		result, err = ctx.Services.Session.GenerateSessionNotebook(c.SessionID, c.Project, c.OutputPath)
+	}
+	if err != nil {
+		return err
+	}
+
+	if ctx.Config.JSONOutput {
+		return out.WriteJSON(result)
+	}
+
+	out.PrintLine("Notebook generated: %s", result.OutputPath)
+	return nil
+}
+
+// runSidechains renders only the subagent transcripts of a single session.
+func (c *HTMLCmd) runSidechains(ctx *Context, out *OutputWriter) error {
+	if c.SessionIDs != "" || c.Watch {
+		return fmt.Errorf("--sidechains-only renders a single session; use --session or --file without --sessions or --watch")
+	}
+
+	var result *service.HTMLGenerationResult
+	var err error
+	if c.FilePath != "" {
+		result, err = ctx.Services.Session.GenerateSidechainHTMLFromFile(c.FilePath, c.OutputPath, c.OpenBrowser)
+	} else {
+		result, err = ctx.Services.Session.GenerateSidechainHTML(c.SessionID, c.Project, c.OutputPath, c.OpenBrowser)
+	}
+	if err != nil {
+		return err
+	}
+
+	if ctx.Config.JSONOutput {
+		return out.WriteJSON(result)
+	}
+
+	out.PrintLine("HTML generated: %s", result.OutputPath)
+	if result.OpenedBrowser {
+		out.PrintLine("Opened in browser")
+	}
+	return nil
+}
+
+// runWatch follows a single session, regenerating the page on every change
+// until interrupted.
+func (c *HTMLCmd) runWatch(ctx *Context, out *OutputWriter) error {
+	if c.SessionIDs != "" {
+		return fmt.Errorf("--watch follows a single session; use --session or --file")
+	}
+
+	inputPath := c.FilePath
+	if inputPath == "" {
+		var err error
+		inputPath, err = ctx.Services.Session.SessionFilePath(c.SessionID, c.Project)
+		if err != nil {
+			return err
+		}
+	}
+
+	done := make(chan struct{})
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+	go func() {
+		<-interrupt
+		close(done)
+	}()
+
+	first := true
+	return ctx.Services.Session.FollowHTML(inputPath, c.OutputPath, c.OpenBrowser, service.WatchConfig{}, done,
+		func(result *service.HTMLGenerationResult, err error) {
+			if err != nil {
+				fmt.Fprintf(ctx.ErrOutput, "Warning: %v\n", err)
+				return
+			}
+			if !first {
+				out.PrintLine("Updated: %s", time.Now().Format("15:04:05"))
+				return
+			}
+			first = false
+			out.PrintLine("HTML generated: %s", result.OutputPath)
+			if result.OpenedBrowser {
+				out.PrintLine("Opened in browser")
+			}
+			out.PrintLine("Watching %s for changes (Ctrl-C to stop)", inputPath)
+		})
+}
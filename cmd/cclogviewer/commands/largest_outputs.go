@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/service"
+)
+
+// LargestOutputsCmd implements the largest-outputs command.
+type LargestOutputsCmd struct {
+	Project    string
+	FilePath   string
+	Top        int
+	OutputPath string
+}
+
+func (c *LargestOutputsCmd) Name() string {
+	return "largest-outputs"
+}
+
+func (c *LargestOutputsCmd) Description() string {
+	return "Rank a session's tool results by size to find what bloats the log"
+}
+
+func (c *LargestOutputsCmd) Setup(fs *flag.FlagSet) {
+	fs.StringVar(&c.Project, "project", "", "Project name/path (optional)")
+	fs.StringVar(&c.FilePath, "file", "", "Direct path to a JSONL log file (instead of a session ID)")
+	fs.IntVar(&c.Top, "top", 10, "Number of results to show (0 for all)")
+	fs.StringVar(&c.OutputPath, "output", "", "File path to save the results as JSON")
+}
+
+func (c *LargestOutputsCmd) Run(ctx *Context, args []string) error {
+	if len(args) < 1 && c.FilePath == "" {
+		return fmt.Errorf("session ID is required\nUsage: cclogviewer largest-outputs <session-id> [flags]")
+	}
+
+	var hits []models.OutputSizeHit
+	var err error
+
+	if c.FilePath != "" {
+		hits, err = ctx.Services.Session.LargestOutputsFromFile(c.FilePath, c.Top)
+	} else {
+		hits, err = ctx.Services.Session.LargestOutputs(args[0], c.Project, c.Top)
+	}
+	if err != nil {
+		return err
+	}
+
+	if hits == nil {
+		return fmt.Errorf("session not found: %s", args[0])
+	}
+
+	out := NewOutputWriter(ctx.Output, ctx.Config.JSONOutput)
+
+	// Save to file if output path specified
+	if c.OutputPath != "" {
+		file, err := os.Create(c.OutputPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+
+		fileOut := NewOutputWriter(file, true)
+		if err := fileOut.WriteJSON(hits); err != nil {
+			return fmt.Errorf("failed to write results: %w", err)
+		}
+
+		return out.WriteSaved("Results", service.NewOutputManifest(c.OutputPath, models.OutputTypeJSON))
+	}
+
+	// Output to stdout
+	if ctx.Config.JSONOutput {
+		return out.WriteJSON(map[string]interface{}{
+			"outputs": hits,
+			"count":   len(hits),
+		})
+	}
+
+	// Human-readable output
+	if len(hits) == 0 {
+		out.PrintLine("No tool results found")
+		return nil
+	}
+
+	headers := []string{"Bytes", "Tool", "Time", "Result UUID"}
+	var rows [][]string
+	for _, h := range hits {
+		rows = append(rows, []string{
+			FormatNumber(h.Bytes),
+			h.Tool,
+			h.Timestamp,
+			h.UUID,
+		})
+	}
+	out.WriteTable(headers, rows)
+
+	return nil
+}
@@ -4,13 +4,25 @@ import (
 	"flag"
 	"fmt"
 	"os"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/service"
 )
 
 // LogsCmd implements the logs command.
 type LogsCmd struct {
 	Project           string
 	IncludeSidechains bool
+	SidechainsOnly    bool
 	OutputPath        string
+	TextOnly          bool
+	IncludeToolOutput bool
+	ToolOutputLimit   int
+	IncludeRawUsage   bool
+	IncludeHashes     bool
+	FromTime          string
+	ToTime            string
+	AfterUUID         string
 }
 
 func (c *LogsCmd) Name() string {
@@ -24,7 +36,16 @@ func (c *LogsCmd) Description() string {
 func (c *LogsCmd) Setup(fs *flag.FlagSet) {
 	fs.StringVar(&c.Project, "project", "", "Project name/path (optional if session_id is globally unique)")
 	fs.BoolVar(&c.IncludeSidechains, "include-sidechains", true, "Include sidechain (agent) conversations")
+	fs.BoolVar(&c.SidechainsOnly, "sidechains-only", false, "Only include sidechain (agent) conversations, grouped by agent")
 	fs.StringVar(&c.OutputPath, "output", "", "File path to save the logs as JSON")
+	fs.BoolVar(&c.IncludeToolOutput, "include-tool-output", false, "Include each tool call's output")
+	fs.IntVar(&c.ToolOutputLimit, "tool-output-limit", service.DefaultToolOutputLimit, "Maximum bytes of each tool output (0 for no limit)")
+	fs.BoolVar(&c.IncludeRawUsage, "include-raw-usage", false, "Attach each message's original usage object, including fields not in token stats")
+	fs.BoolVar(&c.IncludeHashes, "include-hashes", false, "Attach a stable content hash to each entry")
+	fs.BoolVar(&c.TextOnly, "text-only", false, "Only include assistant text messages without tool calls")
+	fs.StringVar(&c.FromTime, "from-time", "", "Only include entries at or after this time (HH:MM[:SS], RFC3339, or +duration from session start)")
+	fs.StringVar(&c.ToTime, "to-time", "", "Only include entries at or before this time (HH:MM[:SS], RFC3339, or +duration from session start)")
+	fs.StringVar(&c.AfterUUID, "after-uuid", "", "Only include entries after the entry with this UUID (e.g. the last one already processed)")
 }
 
 func (c *LogsCmd) Run(ctx *Context, args []string) error {
@@ -33,7 +54,20 @@ func (c *LogsCmd) Run(ctx *Context, args []string) error {
 	}
 
 	sessionID := args[0]
-	logs, err := ctx.Services.Session.GetSessionLogs(sessionID, c.Project, c.IncludeSidechains)
+	opts := service.LogsOptions{
+		IncludeSidechains: c.IncludeSidechains,
+		IncludeToolOutput: c.IncludeToolOutput,
+		ToolOutputLimit:   c.ToolOutputLimit,
+		IncludeRawUsage:   c.IncludeRawUsage,
+		IncludeHashes:     c.IncludeHashes,
+	}
+	var logs *models.SessionLogs
+	var err error
+	if c.SidechainsOnly {
+		logs, err = ctx.Services.Session.GetSidechainLogs(sessionID, c.Project, opts)
+	} else {
+		logs, err = ctx.Services.Session.GetSessionLogs(sessionID, c.Project, opts)
+	}
 	if err != nil {
 		return err
 	}
@@ -42,6 +76,23 @@ func (c *LogsCmd) Run(ctx *Context, args []string) error {
 		return fmt.Errorf("session not found: %s", sessionID)
 	}
 
+	if c.AfterUUID != "" {
+		if err := service.FilterLogsAfterUUID(logs, c.AfterUUID); err != nil {
+			return err
+		}
+	}
+
+	window := service.TimeWindow{From: c.FromTime, To: c.ToTime, Location: ctx.Config.Location}
+	if !window.IsZero() {
+		if err := service.FilterLogsByTime(logs, window); err != nil {
+			return err
+		}
+	}
+
+	if c.TextOnly {
+		service.FilterTextOnlyLogs(logs)
+	}
+
 	out := NewOutputWriter(ctx.Output, ctx.Config.JSONOutput)
 
 	// Save to file if output path specified
@@ -57,8 +108,7 @@ func (c *LogsCmd) Run(ctx *Context, args []string) error {
 			return fmt.Errorf("failed to write logs: %w", err)
 		}
 
-		out.PrintLine("Logs saved to: %s", c.OutputPath)
-		return nil
+		return out.WriteSaved("Logs", service.NewOutputManifest(c.OutputPath, models.OutputTypeJSON))
 	}
 
 	// Output to stdout
@@ -69,7 +119,15 @@ func (c *LogsCmd) Run(ctx *Context, args []string) error {
 	// Human-readable output
 	out.PrintLine("Session: %s", logs.SessionID)
 	out.PrintLine("Project: %s", logs.Project)
-	out.PrintLine("Entries: %d", len(logs.Entries))
+	if c.TextOnly {
+		out.PrintLine("Entries: %d (text-only, of %d total)", logs.FilteredEntries, logs.TotalEntries)
+	} else if !window.IsZero() {
+		out.PrintLine("Entries: %d (in time window, of %d total)", logs.FilteredEntries, logs.TotalEntries)
+	} else if c.AfterUUID != "" {
+		out.PrintLine("Entries: %d (after %s, of %d total)", logs.FilteredEntries, c.AfterUUID, logs.TotalEntries)
+	} else {
+		out.PrintLine("Entries: %d", len(logs.Entries))
+	}
 
 	if logs.TokenStats != nil {
 		out.PrintLine("\nToken Stats:")
@@ -6,12 +6,15 @@ import (
 	"io"
 	"strings"
 	"time"
+
+	"github.com/brads3290/cclogviewer/internal/models"
 )
 
 // OutputWriter handles formatted output for commands.
 type OutputWriter struct {
 	w      io.Writer
 	isJSON bool
+	color  bool
 }
 
 // NewOutputWriter creates a new OutputWriter.
@@ -39,8 +42,8 @@ func (o *OutputWriter) WriteTable(headers []string, rows [][]string) {
 	}
 	for _, row := range rows {
 		for i, cell := range row {
-			if i < len(widths) && len(cell) > widths[i] {
-				widths[i] = len(cell)
+			if i < len(widths) && visibleWidth(cell) > widths[i] {
+				widths[i] = visibleWidth(cell)
 			}
 		}
 	}
@@ -55,7 +58,8 @@ func (o *OutputWriter) WriteTable(headers []string, rows [][]string) {
 	for _, row := range rows {
 		for i, cell := range row {
 			if i < len(widths) {
-				fmt.Fprintf(o.w, "%-*s  ", widths[i], cell)
+				// Pad by visible width so colored cells stay aligned
+				fmt.Fprintf(o.w, "%s%s  ", cell, strings.Repeat(" ", widths[i]-visibleWidth(cell)))
 			}
 		}
 		fmt.Fprintln(o.w)
@@ -71,6 +75,45 @@ func (o *OutputWriter) WriteResult(data interface{}) error {
 	return nil
 }
 
+// WriteCount writes a bare count, or {"count": n} in JSON mode.
+func (o *OutputWriter) WriteCount(n int) error {
+	if o.isJSON {
+		return o.WriteJSON(map[string]int{"count": n})
+	}
+	fmt.Fprintln(o.w, n)
+	return nil
+}
+
+// WriteSaved reports the files a command wrote: the manifest in JSON mode,
+// otherwise a "<label> saved to: <path>" line per file.
+func (o *OutputWriter) WriteSaved(label string, manifest *models.OutputManifest) error {
+	if o.isJSON {
+		return o.WriteJSON(manifest)
+	}
+	for _, f := range manifest.Files {
+		o.PrintLine("%s saved to: %s", label, f.Path)
+	}
+	return nil
+}
+
+// stringList is a flag that may be repeated, collecting each value.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// FormatExcludedAgents describes agents left out of stats, e.g.
+// "a1b2, c3d4 (12 entries dropped)".
+func FormatExcludedAgents(excluded *models.AgentExclusion) string {
+	return fmt.Sprintf("%s (%d entries dropped)", strings.Join(excluded.AgentIDs, ", "), excluded.EntriesDropped)
+}
+
 // FormatTime formats a time for display.
 func FormatTime(t time.Time) string {
 	if t.IsZero() {
@@ -79,6 +122,32 @@ func FormatTime(t time.Time) string {
 	return t.Format("2006-01-02 15:04")
 }
 
+// WriteScanWarning reports unreadable sessions from a cross-session operation,
+// e.g. "Searched 100 sessions, 5 unreadable". Nothing is written when every
+// session was read.
+func WriteScanWarning(w io.Writer, report models.ScanReport) {
+	if len(report.Failures) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "Warning: searched %d sessions, %d unreadable\n", report.SessionsScanned, len(report.Failures))
+	for _, f := range report.Failures {
+		fmt.Fprintf(w, "  %s/%s: %s\n", f.Project, f.SessionID, f.Error)
+	}
+}
+
+// FormatClock returns the HH:MM:SS part of localTime when set, otherwise the
+// logged timestamp unchanged.
+func FormatClock(timestamp, localTime string) string {
+	if localTime == "" {
+		return timestamp
+	}
+	t, err := time.Parse(time.RFC3339, localTime)
+	if err != nil {
+		return timestamp
+	}
+	return t.Format("15:04:05")
+}
+
 // FormatDuration formats a duration for display.
 func FormatDuration(minutes int) string {
 	if minutes == 0 {
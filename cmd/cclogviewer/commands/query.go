@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+)
+
+// queryUsage documents the selector grammar accepted by the query command.
+const queryUsage = `Usage: cclogviewer query [flags] <session-id> <selector>
+       cclogviewer query --file <path> <selector>
+
+Selector: <tool>[.<field>...]
+  tool   tool name (e.g. Edit) or * for every tool
+  field  object key, array index, or * for every element
+Each tool call has the keys id, name, uuid, timestamp, input, result, is_error, is_sidechain.
+
+Examples:
+  Edit.input.file_path
+  Bash.input.command
+  MultiEdit.input.edits.*.old_string
+  *.name`
+
+// QueryCmd implements the query command.
+type QueryCmd struct {
+	Project  string
+	FilePath string
+}
+
+func (c *QueryCmd) Name() string {
+	return "query"
+}
+
+func (c *QueryCmd) Description() string {
+	return "Extract tool call fields with a selector (e.g. Edit.input.file_path) as JSON lines"
+}
+
+func (c *QueryCmd) Setup(fs *flag.FlagSet) {
+	fs.StringVar(&c.Project, "project", "", "Project name/path (optional)")
+	fs.StringVar(&c.FilePath, "file", "", "Direct path to a JSONL log file (instead of a session ID)")
+}
+
+func (c *QueryCmd) Run(ctx *Context, args []string) error {
+	var values []interface{}
+	var err error
+
+	if c.FilePath != "" {
+		if len(args) < 1 {
+			return fmt.Errorf("selector is required\n%s", queryUsage)
+		}
+		values, err = ctx.Services.Session.QueryFromFile(c.FilePath, args[0])
+	} else {
+		if len(args) < 2 {
+			return fmt.Errorf("session ID and selector are required\n%s", queryUsage)
+		}
+		values, err = ctx.Services.Session.Query(args[0], c.Project, args[1])
+		if err == nil && values == nil {
+			return fmt.Errorf("session not found: %s", args[0])
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	// One JSON value per line so results can be piped to other tools
+	for _, v := range values {
+		line, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("failed to encode value: %w", err)
+		}
+		fmt.Fprintln(ctx.Output, string(line))
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"flag"
+)
+
+// RecentCmd implements the recent command.
+type RecentCmd struct {
+	Limit int
+}
+
+func (c *RecentCmd) Name() string {
+	return "recent"
+}
+
+func (c *RecentCmd) Description() string {
+	return "List the most recently modified sessions across all projects"
+}
+
+func (c *RecentCmd) Setup(fs *flag.FlagSet) {
+	fs.IntVar(&c.Limit, "limit", 0, "Maximum sessions to return (default: recent_limit from the config file, or 10)")
+}
+
+func (c *RecentCmd) Run(ctx *Context, args []string) error {
+	limit := c.Limit
+	if limit <= 0 {
+		limit = ctx.Config.RecentLimit
+	}
+	if limit <= 0 {
+		limit = DefaultRecentLimit
+	}
+
+	sessions := &SessionsCmd{All: true, Limit: limit}
+	return sessions.runAll(ctx, args)
+}
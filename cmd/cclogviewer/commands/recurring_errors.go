@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/service"
+)
+
+// RecurringErrorsCmd implements the recurring-errors command.
+type RecurringErrorsCmd struct {
+	Project    string
+	Days       int
+	Limit      int
+	OutputPath string
+}
+
+func (c *RecurringErrorsCmd) Name() string {
+	return "recurring-errors"
+}
+
+func (c *RecurringErrorsCmd) Description() string {
+	return "Find the same error recurring across sessions"
+}
+
+func (c *RecurringErrorsCmd) Setup(fs *flag.FlagSet) {
+	fs.StringVar(&c.Project, "project", "", "Limit to a specific project (default: all projects)")
+	fs.IntVar(&c.Days, "days", 7, "Only include sessions from the last N days (0 for all)")
+	fs.IntVar(&c.Limit, "limit", 20, "Maximum number of error clusters to show (0 for all)")
+	fs.StringVar(&c.OutputPath, "output", "", "File path to save the clusters as JSON")
+}
+
+func (c *RecurringErrorsCmd) Run(ctx *Context, args []string) error {
+	clusters, report, err := ctx.Services.Search.RecurringErrors(c.Project, c.Days)
+	if err != nil {
+		return err
+	}
+	if c.Limit > 0 && len(clusters) > c.Limit {
+		clusters = clusters[:c.Limit]
+	}
+
+	out := NewOutputWriter(ctx.Output, ctx.Config.JSONOutput)
+
+	// Save to file if output path specified
+	if c.OutputPath != "" {
+		file, err := os.Create(c.OutputPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+
+		fileOut := NewOutputWriter(file, true)
+		if err := fileOut.WriteJSON(clusters); err != nil {
+			return fmt.Errorf("failed to write clusters: %w", err)
+		}
+
+		WriteScanWarning(ctx.ErrOutput, report)
+		return out.WriteSaved("Recurring errors", service.NewOutputManifest(c.OutputPath, models.OutputTypeJSON))
+	}
+
+	// Output to stdout
+	if ctx.Config.JSONOutput {
+		return out.WriteJSON(map[string]interface{}{
+			"clusters":         clusters,
+			"count":            len(clusters),
+			"sessions_scanned": report.SessionsScanned,
+			"failures":         report.Failures,
+		})
+	}
+
+	WriteScanWarning(ctx.ErrOutput, report)
+
+	// Human-readable output
+	if len(clusters) == 0 {
+		out.PrintLine("No errors found")
+		return nil
+	}
+
+	for _, cluster := range clusters {
+		label := cluster.Type
+		if cluster.ToolName != "" {
+			label = cluster.ToolName
+		}
+		out.PrintSection(fmt.Sprintf("%dx in %d sessions [%s] %s", cluster.Occurrences, cluster.Sessions, label, Truncate(cluster.Signature, 60)))
+		out.PrintKeyValue("Example", Truncate(cluster.Example, 100))
+
+		headers := []string{"Session ID", "Project", "Time", "UUID"}
+		var rows [][]string
+		for _, r := range cluster.References {
+			rows = append(rows, []string{
+				r.SessionID,
+				Truncate(r.Project, 20),
+				r.Timestamp,
+				r.UUID,
+			})
+		}
+		out.WriteTable(headers, rows)
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/service"
+)
+
+// ReplayCmd implements the replay command.
+type ReplayCmd struct {
+	Project       string
+	FilePath      string
+	OutputPath    string
+	IncludeFailed bool
+}
+
+func (c *ReplayCmd) Name() string {
+	return "replay"
+}
+
+func (c *ReplayCmd) Description() string {
+	return "Export a session's Bash commands as a commented shell script for review"
+}
+
+func (c *ReplayCmd) Setup(fs *flag.FlagSet) {
+	fs.StringVar(&c.Project, "project", "", "Project name/path (optional)")
+	fs.StringVar(&c.FilePath, "file", "", "Direct path to a JSONL log file (instead of a session ID)")
+	fs.StringVar(&c.OutputPath, "output", "", "File path to save the script (prints to stdout if omitted)")
+	fs.BoolVar(&c.IncludeFailed, "include-failed", false, "Include commands that failed or were interrupted")
+}
+
+func (c *ReplayCmd) Run(ctx *Context, args []string) error {
+	if len(args) < 1 && c.FilePath == "" {
+		return fmt.Errorf("session ID is required\nUsage: cclogviewer replay <session-id> [flags]")
+	}
+
+	w := ctx.Output
+	if c.OutputPath != "" {
+		file, err := os.Create(c.OutputPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	var err error
+	if c.FilePath != "" {
+		err = ctx.Services.Session.GenerateReplayScriptFromFile(c.FilePath, c.IncludeFailed, w)
+	} else {
+		err = ctx.Services.Session.GenerateReplayScript(args[0], c.Project, c.IncludeFailed, w)
+	}
+	if err != nil {
+		return err
+	}
+
+	if c.OutputPath != "" {
+		return NewOutputWriter(ctx.Output, ctx.Config.JSONOutput).WriteSaved("Replay script", service.NewOutputManifest(c.OutputPath, models.OutputTypeScript))
+	}
+
+	return nil
+}
@@ -1,7 +1,11 @@
 package commands
 
 import (
+	"bytes"
+	"encoding/json"
 	"flag"
+	"fmt"
+	"strings"
 
 	"github.com/brads3290/cclogviewer/internal/service"
 )
@@ -15,6 +19,15 @@ type SearchCmd struct {
 	Days              int
 	IncludeSidechains bool
 	Limit             int
+	SessionID         string
+	FilePath          string
+	Resume            bool
+	Bookmark          bool
+	CountOnly         bool
+	Sort              string
+	MinContentLen     int
+	WholeWord         bool
+	FullContent       bool
 }
 
 func (c *SearchCmd) Name() string {
@@ -33,6 +46,15 @@ func (c *SearchCmd) Setup(fs *flag.FlagSet) {
 	fs.IntVar(&c.Days, "days", 0, "Only search sessions from the last N days")
 	fs.BoolVar(&c.IncludeSidechains, "include-sidechains", true, "Search in sidechain conversations too")
 	fs.IntVar(&c.Limit, "limit", 50, "Maximum results to return")
+	fs.StringVar(&c.SessionID, "session", "", "Search only this session ID (ignores --project and --days)")
+	fs.StringVar(&c.FilePath, "file", "", "Search only this JSONL log file (ignores --project and --days)")
+	fs.BoolVar(&c.Bookmark, "bookmark", false, "Record which sessions were searched so an interrupted search can be continued with --resume")
+	fs.BoolVar(&c.Resume, "resume", false, "Continue an interrupted --bookmark search with the same criteria, skipping sessions it already searched")
+	fs.StringVar(&c.Sort, "sort", service.SortByProject, "Session order: project (by name, newest session first) or time (newest first across projects)")
+	fs.IntVar(&c.MinContentLen, "min-content-len", 0, "Skip entries whose text is shorter than N characters")
+	fs.BoolVar(&c.WholeWord, "whole-word", false, "Match --query only as a whole word")
+	fs.BoolVar(&c.FullContent, "full-content", false, "Include each match's full text and tool input/output instead of a snippet")
+	fs.BoolVar(&c.CountOnly, "count-only", false, "Print only the number of matches (--limit is ignored)")
 }
 
 func (c *SearchCmd) Run(ctx *Context, args []string) error {
@@ -44,6 +66,15 @@ func (c *SearchCmd) Run(ctx *Context, args []string) error {
 		Days:              c.Days,
 		IncludeSidechains: c.IncludeSidechains,
 		Limit:             c.Limit,
+		SessionID:         c.SessionID,
+		FilePath:          c.FilePath,
+		Resume:            c.Resume,
+		Bookmark:          c.Bookmark,
+		CountOnly:         c.CountOnly,
+		Sort:              c.Sort,
+		MinContentLen:     c.MinContentLen,
+		WholeWord:         c.WholeWord,
+		FullContent:       c.FullContent,
 	}
 
 	results, err := ctx.Services.Search.Search(criteria)
@@ -53,10 +84,24 @@ func (c *SearchCmd) Run(ctx *Context, args []string) error {
 
 	out := NewOutputWriter(ctx.Output, ctx.Config.JSONOutput)
 
+	if results.BookmarkWarning != "" {
+		fmt.Fprintf(ctx.ErrOutput, "Warning: %s\n", results.BookmarkWarning)
+	}
+
+	if c.CountOnly {
+		WriteScanWarning(ctx.ErrOutput, results.ScanReport)
+		return out.WriteCount(results.TotalMatches)
+	}
+
 	if ctx.Config.JSONOutput {
 		return out.WriteJSON(results)
 	}
 
+	WriteScanWarning(ctx.ErrOutput, results.ScanReport)
+	if results.ResumedSessions > 0 {
+		fmt.Fprintf(ctx.ErrOutput, "Resumed: skipped %d sessions searched earlier\n", results.ResumedSessions)
+	}
+
 	// Human-readable output
 	if len(results.Results) == 0 {
 		out.PrintLine("No results found")
@@ -65,6 +110,26 @@ func (c *SearchCmd) Run(ctx *Context, args []string) error {
 
 	out.PrintLine("Found %d results:\n", results.TotalMatches)
 
+	if c.FullContent {
+		for i, r := range results.Results {
+			out.PrintSection(fmt.Sprintf("%d. %s %s (%s)", i+1, r.Role, r.EntryUUID, r.SessionID))
+			if r.Content != "" {
+				out.PrintLine("%s", r.Content)
+			}
+			if r.ToolInput != nil {
+				var input bytes.Buffer
+				enc := json.NewEncoder(&input)
+				enc.SetEscapeHTML(false)
+				_ = enc.Encode(r.ToolInput)
+				out.PrintLine("%s input: %s", r.ToolName, strings.TrimSpace(input.String()))
+			}
+			if r.ToolOutput != "" {
+				out.PrintLine("Tool output:\n%s", r.ToolOutput)
+			}
+		}
+		return nil
+	}
+
 	headers := []string{"Session ID", "Project", "Role", "Tool", "Content"}
 	var rows [][]string
 	for _, r := range results.Results {
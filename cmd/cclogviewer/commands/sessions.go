@@ -3,6 +3,9 @@ package commands
 import (
 	"flag"
 	"fmt"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/service"
 )
 
 // SessionsCmd implements the sessions command.
@@ -10,6 +13,10 @@ type SessionsCmd struct {
 	Days              int
 	Limit             int
 	IncludeAgentTypes bool
+	WithErrors        bool
+	IncludeEmpty      bool
+	Chains            bool
+	All               bool
 }
 
 func (c *SessionsCmd) Name() string {
@@ -17,36 +24,71 @@ func (c *SessionsCmd) Name() string {
 }
 
 func (c *SessionsCmd) Description() string {
-	return "List sessions for a project with optional filtering"
+	return "List sessions for a project, or the most recent across all projects with --all"
 }
 
 func (c *SessionsCmd) Setup(fs *flag.FlagSet) {
 	fs.IntVar(&c.Days, "days", 0, "Only include sessions from the last N days")
 	fs.IntVar(&c.Limit, "limit", 50, "Maximum sessions to return")
 	fs.BoolVar(&c.IncludeAgentTypes, "include-agent-types", false, "Include subagent types used in each session")
+	fs.BoolVar(&c.WithErrors, "with-errors", false, "Only include sessions containing errors (processes each session; slower)")
+	fs.BoolVar(&c.IncludeEmpty, "include-empty", false, "Include sessions with no usable entries and show a Status column")
+	fs.BoolVar(&c.Chains, "chains", false, "Group sessions that continue one another, oldest first within each chain")
+	fs.BoolVar(&c.All, "all", false, "List the most recently modified sessions across all projects (no project argument)")
 }
 
 func (c *SessionsCmd) Run(ctx *Context, args []string) error {
+	if c.All {
+		return c.runAll(ctx, args)
+	}
+
 	if len(args) < 1 {
-		return fmt.Errorf("project name is required\nUsage: cclogviewer sessions <project> [flags]")
+		return fmt.Errorf("project name is required\nUsage: cclogviewer sessions <project> [flags] | cclogviewer sessions --all [--limit N]")
 	}
 
 	project := args[0]
-	sessions, err := ctx.Services.Session.ListSessions(project, c.Days, c.IncludeAgentTypes, c.Limit)
+
+	var sessions []models.SessionInfo
+	var report models.ScanReport
+	var err error
+	if c.WithErrors {
+		sessions, report, err = ctx.Services.Session.ListSessionsWithErrors(project, c.Days, c.IncludeAgentTypes, c.Limit)
+	} else {
+		sessions, err = ctx.Services.Session.ListSessions(project, c.Days, c.IncludeAgentTypes, c.IncludeEmpty, c.Limit)
+	}
 	if err != nil {
 		return err
 	}
 
 	out := NewOutputWriter(ctx.Output, ctx.Config.JSONOutput)
 
+	var chains [][]models.SessionInfo
+	if c.Chains {
+		chains = service.GroupSessionChains(sessions)
+	}
+
 	if ctx.Config.JSONOutput {
-		return out.WriteJSON(map[string]interface{}{
+		result := map[string]interface{}{
 			"project":  project,
 			"sessions": sessions,
 			"count":    len(sessions),
-		})
+		}
+		if c.Chains {
+			result = map[string]interface{}{
+				"project": project,
+				"chains":  chains,
+				"count":   len(chains),
+			}
+		}
+		if c.WithErrors {
+			result["sessions_scanned"] = report.SessionsScanned
+			result["failures"] = report.Failures
+		}
+		return out.WriteJSON(result)
 	}
 
+	WriteScanWarning(ctx.ErrOutput, report)
+
 	// Human-readable output
 	if len(sessions) == 0 {
 		out.PrintLine("No sessions found for project: %s", project)
@@ -56,18 +98,51 @@ func (c *SessionsCmd) Run(ctx *Context, args []string) error {
 	out.PrintLine("Sessions for project: %s\n", project)
 
 	headers := []string{"Session ID", "Start Time", "Messages", "First Message"}
+	if c.IncludeEmpty {
+		headers = append(headers, "Status")
+	}
+	if c.WithErrors {
+		headers = append(headers, "Errors")
+	}
 	if c.IncludeAgentTypes {
 		headers = append(headers, "Agent Types")
 	}
 
+	// With --chains, continuations are listed under the session they continue
+	listed := sessions
+	continued := make(map[int]bool)
+	if c.Chains {
+		listed = nil
+		for _, chain := range chains {
+			for i, s := range chain {
+				continued[len(listed)] = i > 0
+				listed = append(listed, s)
+			}
+		}
+	}
+
 	var rows [][]string
-	for _, s := range sessions {
+	for i, s := range listed {
+		startTime := s.StartTime
+		if ctx.Config.Location != nil {
+			startTime = startTime.In(ctx.Config.Location)
+		}
+		id := Truncate(s.SessionID, 36)
+		if continued[i] {
+			id = "└ " + id
+		}
 		row := []string{
-			Truncate(s.SessionID, 36),
-			FormatTime(s.StartTime),
+			id,
+			FormatTime(startTime),
 			FormatNumber(s.MessageCount),
 			Truncate(s.FirstUserMessage, 40),
 		}
+		if c.IncludeEmpty {
+			row = append(row, s.Status)
+		}
+		if c.WithErrors {
+			row = append(row, FormatNumber(s.ErrorCount))
+		}
 		if c.IncludeAgentTypes {
 			agents := ""
 			if len(s.AgentTypesUsed) > 0 {
@@ -81,3 +156,57 @@ func (c *SessionsCmd) Run(ctx *Context, args []string) error {
 
 	return nil
 }
+
+// runAll lists the most recently modified sessions across all projects.
+func (c *SessionsCmd) runAll(ctx *Context, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("--all lists sessions across all projects; drop the project argument")
+	}
+	if c.Days > 0 || c.WithErrors || c.IncludeEmpty || c.IncludeAgentTypes || c.Chains {
+		return fmt.Errorf("--all only supports --limit")
+	}
+
+	sessions, err := ctx.Services.Session.RecentSessions(c.Limit)
+	if err != nil {
+		return err
+	}
+
+	out := NewOutputWriter(ctx.Output, ctx.Config.JSONOutput)
+
+	if ctx.Config.JSONOutput {
+		return out.WriteJSON(map[string]interface{}{
+			"sessions": sessions,
+			"count":    len(sessions),
+		})
+	}
+
+	// Human-readable output
+	if len(sessions) == 0 {
+		out.PrintLine("No sessions found")
+		return nil
+	}
+
+	out.PrintLine("Recent sessions across all projects\n")
+
+	headers := []string{"Project", "Session ID", "Modified", "Messages", "First Message"}
+	var rows [][]string
+	for _, s := range sessions {
+		modified := s.EndTime
+		if s.ModifiedTime != nil {
+			modified = *s.ModifiedTime
+		}
+		if ctx.Config.Location != nil {
+			modified = modified.In(ctx.Config.Location)
+		}
+		rows = append(rows, []string{
+			Truncate(s.Project, 30),
+			Truncate(s.SessionID, 36),
+			FormatTime(modified),
+			FormatNumber(s.MessageCount),
+			Truncate(s.FirstUserMessage, 40),
+		})
+	}
+	out.WriteTable(headers, rows)
+
+	return nil
+}
@@ -4,6 +4,11 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/service"
 )
 
 // StatsCmd implements the stats command.
@@ -15,6 +20,12 @@ type StatsCmd struct {
 	GenerateHTML      bool
 	OpenBrowser       bool
 	OutputPath        string
+	Baseline          string
+	Tolerances        string
+	Git               bool
+	Files             stringList
+	PerFile           bool
+	ExcludeAgents     stringList
 }
 
 func (c *StatsCmd) Name() string {
@@ -33,24 +44,52 @@ func (c *StatsCmd) Setup(fs *flag.FlagSet) {
 	fs.BoolVar(&c.GenerateHTML, "html", false, "Generate HTML visualization alongside JSON")
 	fs.BoolVar(&c.OpenBrowser, "open", false, "Open HTML in browser (requires --html)")
 	fs.StringVar(&c.OutputPath, "output", "", "Base path for output files (without extension)")
+	fs.StringVar(&c.Baseline, "baseline", "", "Compare against a baseline session ID and fail on regressions")
+	fs.StringVar(&c.Tolerances, "tolerance", "", "Per-metric tolerances in percent, e.g. total_tokens=10,errors=0")
+	fs.BoolVar(&c.Git, "git", false, "Include git commits made in the session's working directory and branch while it ran")
+	fs.Var(&c.Files, "file", "Direct path to a JSONL log file instead of a session ID; repeat to merge several files")
+	fs.Var(&c.ExcludeAgents, "exclude-agent", "Leave out entries from this subagent ID; repeat to exclude several")
+	fs.BoolVar(&c.PerFile, "per-file", false, "With several --file paths, also report each file's summary")
 }
 
 func (c *StatsCmd) Run(ctx *Context, args []string) error {
-	if len(args) < 1 {
+	if len(args) < 1 && len(c.Files) == 0 {
 		return fmt.Errorf("session ID is required\nUsage: cclogviewer stats <session-id> [flags]")
 	}
 
-	sessionID := args[0]
-	stats, err := ctx.Services.Session.GetSessionStats(sessionID, c.AgentID, c.Project, c.IncludeSidechains, c.ErrorsLimit)
-	if err != nil {
-		return err
-	}
+	var stats *models.SessionStats
+	var err error
+
+	if len(c.Files) > 0 {
+		if c.Baseline != "" || c.Git {
+			return fmt.Errorf("--baseline and --git need a session ID and cannot be used with --file")
+		}
+		stats, err = ctx.Services.Session.GetStatsFromFiles(c.Files, c.IncludeSidechains, c.ErrorsLimit, c.PerFile, c.ExcludeAgents)
+		if err != nil {
+			return err
+		}
+	} else {
+		sessionID := args[0]
+		if c.Baseline != "" {
+			return c.runBaseline(ctx, sessionID)
+		}
+
+		stats, err = ctx.Services.Session.GetSessionStats(sessionID, c.AgentID, c.Project, c.IncludeSidechains, c.ErrorsLimit, c.ExcludeAgents)
+		if err != nil {
+			return err
+		}
 
-	if stats == nil {
-		return fmt.Errorf("session not found: %s", sessionID)
+		if stats == nil {
+			return fmt.Errorf("session not found: %s", sessionID)
+		}
+
+		if c.Git && stats.Summary != nil {
+			attachCommits(ctx, stats.Summary, sessionID, c.Project)
+		}
 	}
 
 	out := NewOutputWriter(ctx.Output, ctx.Config.JSONOutput)
+	out.SetColor(ctx.Config.ColorOutput)
 
 	// Save to file if output path specified
 	if c.OutputPath != "" {
@@ -66,7 +105,11 @@ func (c *StatsCmd) Run(ctx *Context, args []string) error {
 			return fmt.Errorf("failed to write stats: %w", err)
 		}
 
-		out.PrintLine("Stats saved to: %s", jsonPath)
+		// With --json the manifest goes out with the stats below
+		stats.Files = service.NewOutputManifest(jsonPath, models.OutputTypeJSON)
+		if !ctx.Config.JSONOutput {
+			out.PrintLine("Stats saved to: %s", jsonPath)
+		}
 	}
 
 	// Output to stdout
@@ -84,6 +127,7 @@ func (c *StatsCmd) Run(ctx *Context, args []string) error {
 		out.PrintSection("Summary")
 		out.PrintKeyValue("Date", stats.Summary.Date)
 		out.PrintKeyValue("Duration", FormatDuration(stats.Summary.DurationMinutes))
+		out.PrintKeyValue("Health", out.HealthBadge(stats.Summary.HealthScore))
 		out.PrintKeyValue("Messages", fmt.Sprintf("%d total (%d user, %d assistant)",
 			stats.Summary.MessageCount, stats.Summary.UserMessages, stats.Summary.AssistantMsgs))
 
@@ -94,24 +138,56 @@ func (c *StatsCmd) Run(ctx *Context, args []string) error {
 		}
 
 		if stats.Summary.ToolCalls != nil {
-			out.PrintKeyValue("Tool Calls", fmt.Sprintf("%d total (%d success, %d failed)",
-				stats.Summary.ToolCalls.Total, stats.Summary.ToolCalls.Success, stats.Summary.ToolCalls.Failed))
+			out.PrintKeyValue("Tool Calls", fmt.Sprintf("%d total (%s success, %s failed)",
+				stats.Summary.ToolCalls.Total,
+				out.Count(stats.Summary.ToolCalls.Success, out.Green),
+				out.Count(stats.Summary.ToolCalls.Failed, out.Red)))
 		}
 
-		out.PrintKeyValue("Errors", FormatNumber(stats.Summary.ErrorCount))
+		out.PrintKeyValue("Errors", out.Count(stats.Summary.ErrorCount, out.Red))
+		out.PrintKeyValue("Tool Output", FormatNumber(stats.TotalToolOutputBytes)+" bytes")
+		if stats.Summary.ExcludedAgents != nil {
+			out.PrintKeyValue("Excluded Agents", FormatExcludedAgents(stats.Summary.ExcludedAgents))
+		}
+	}
+
+	// Per-file section
+	if len(stats.Sources) > 0 {
+		out.PrintSection("Files")
+		headers := []string{"File", "Messages", "Tokens In", "Tokens Out", "Tool Calls", "Errors"}
+		var rows [][]string
+		for _, src := range stats.Sources {
+			row := []string{src.Path, FormatNumber(src.Summary.MessageCount), "-", "-", "-", out.Count(src.Summary.ErrorCount, out.Red)}
+			if src.Summary.Tokens != nil {
+				row[2] = FormatNumber(src.Summary.Tokens.TotalInput)
+				row[3] = FormatNumber(src.Summary.Tokens.TotalOutput)
+			}
+			if src.Summary.ToolCalls != nil {
+				row[4] = FormatNumber(src.Summary.ToolCalls.Total)
+			}
+			rows = append(rows, row)
+		}
+		out.WriteTable(headers, rows)
+	}
+
+	// Commits section
+	if stats.Summary != nil && len(stats.Summary.Commits) > 0 {
+		out.PrintSection("Commits")
+		printCommits(out, stats.Summary.Commits)
 	}
 
 	// Tool stats section
 	if stats.ToolStats != nil && len(stats.ToolStats.Tools) > 0 {
 		out.PrintSection("Tool Usage")
-		headers := []string{"Tool", "Count", "Success", "Failed"}
+		headers := []string{"Tool", "Count", "%", "Success", "Failed"}
 		var rows [][]string
 		for _, t := range stats.ToolStats.Tools {
 			rows = append(rows, []string{
 				t.Name,
 				FormatNumber(t.Count),
-				FormatNumber(t.Success),
-				FormatNumber(t.Failed),
+				fmt.Sprintf("%.1f%%", t.Percentage),
+				out.Count(t.Success, out.Green),
+				out.Count(t.Failed, out.Red),
 			})
 		}
 		out.WriteTable(headers, rows)
@@ -120,16 +196,93 @@ func (c *StatsCmd) Run(ctx *Context, args []string) error {
 	// Errors section
 	if stats.Errors != nil && stats.Errors.TotalErrors > 0 {
 		out.PrintSection("Errors")
-		out.PrintLine("Total: %d errors\n", stats.Errors.TotalErrors)
+		out.PrintLine("Total: %s errors\n", out.Red(FormatNumber(stats.Errors.TotalErrors)))
+
+		if rc := stats.Errors.RootCause; rc != nil {
+			out.PrintLine("Likely root cause: [%s] %s: %s", out.Red(rc.Type), rc.ToolName, Truncate(rc.Message, 60))
+			out.PrintLine("  %s\n", stats.Errors.RootCauseReason)
+		}
 
 		for i, e := range stats.Errors.Errors {
 			if i >= 5 {
 				out.PrintLine("... and %d more errors (use --json for full list)", stats.Errors.TotalErrors-5)
 				break
 			}
-			out.PrintLine("%d. [%s] %s: %s", i+1, e.Type, e.ToolName, Truncate(e.Message, 60))
+			out.PrintLine("%d. [%s] %s: %s", i+1, out.Red(e.Type), e.ToolName, Truncate(e.Message, 60))
 		}
 	}
 
 	return nil
 }
+
+// runBaseline compares the session against c.Baseline and returns an error if
+// any metric regressed beyond its tolerance, so CI runs fail.
+func (c *StatsCmd) runBaseline(ctx *Context, sessionID string) error {
+	tolerances, err := parseTolerances(c.Tolerances)
+	if err != nil {
+		return err
+	}
+
+	comparison, err := ctx.Services.Session.CompareToBaseline(sessionID, c.Baseline, c.Project, tolerances)
+	if err != nil {
+		return err
+	}
+
+	out := NewOutputWriter(ctx.Output, ctx.Config.JSONOutput)
+	out.SetColor(ctx.Config.ColorOutput)
+
+	if ctx.Config.JSONOutput {
+		if err := out.WriteJSON(comparison); err != nil {
+			return err
+		}
+	} else {
+		out.PrintLine("Session: %s", comparison.SessionID)
+		out.PrintLine("Baseline: %s\n", comparison.BaselineID)
+
+		headers := []string{"Metric", "Current", "Baseline", "Delta", "Delta %", "Tolerance", "Result"}
+		var rows [][]string
+		for _, m := range comparison.Metrics {
+			result := "pass"
+			if !m.Passed {
+				result = "FAIL"
+			}
+			rows = append(rows, []string{
+				m.Metric,
+				FormatNumber(int(m.Current)),
+				FormatNumber(int(m.Baseline)),
+				fmt.Sprintf("%+d", int(m.Delta)),
+				fmt.Sprintf("%+.1f%%", m.DeltaPercent),
+				fmt.Sprintf("%.0f%%", m.Tolerance),
+				result,
+			})
+		}
+		out.WriteTable(headers, rows)
+	}
+
+	if !comparison.Passed {
+		return fmt.Errorf("session %s regressed against baseline %s", sessionID, c.Baseline)
+	}
+	return nil
+}
+
+// parseTolerances parses "metric=percent" pairs separated by commas.
+func parseTolerances(spec string) (map[string]float64, error) {
+	tolerances := make(map[string]float64)
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		metric, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid tolerance %q: expected metric=percent", pair)
+		}
+		percent, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(value), "%"), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tolerance %q: %w", pair, err)
+		}
+		tolerances[strings.TrimSpace(metric)] = percent
+	}
+	return tolerances, nil
+}
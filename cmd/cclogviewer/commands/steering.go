@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+)
+
+// SteeringCmd implements the steering command.
+type SteeringCmd struct {
+	Days int
+}
+
+func (c *SteeringCmd) Name() string {
+	return "steering"
+}
+
+func (c *SteeringCmd) Description() string {
+	return "Count interruptions, rejected tool calls, and follow-ups per session in a project"
+}
+
+func (c *SteeringCmd) Setup(fs *flag.FlagSet) {
+	fs.IntVar(&c.Days, "days", 0, "Only include sessions from the last N days")
+}
+
+func (c *SteeringCmd) Run(ctx *Context, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("project name is required\nUsage: cclogviewer steering <project> [flags]")
+	}
+
+	project := args[0]
+	stats, err := ctx.Services.Session.SteeringStats(project, c.Days)
+	if err != nil {
+		return err
+	}
+
+	if stats == nil {
+		return fmt.Errorf("project not found: %s", project)
+	}
+
+	out := NewOutputWriter(ctx.Output, ctx.Config.JSONOutput)
+
+	if ctx.Config.JSONOutput {
+		return out.WriteJSON(stats)
+	}
+
+	WriteScanWarning(ctx.ErrOutput, stats.ScanReport)
+
+	// Human-readable output
+	if len(stats.Sessions) == 0 {
+		out.PrintLine("No sessions found for project: %s", stats.Project)
+		return nil
+	}
+
+	out.PrintLine("Steering for project: %s\n", stats.Project)
+
+	headers := []string{"Session ID", "Start Time", "User Msgs", "Interrupts", "Rejected", "Follow-ups", "Total"}
+	var rows [][]string
+	for _, s := range stats.Sessions {
+		startTime := s.StartTime
+		if ctx.Config.Location != nil {
+			startTime = startTime.In(ctx.Config.Location)
+		}
+		rows = append(rows, []string{
+			s.SessionID,
+			FormatTime(startTime),
+			FormatNumber(s.UserMessages),
+			FormatNumber(s.Interruptions),
+			FormatNumber(s.RejectedToolCalls),
+			FormatNumber(s.Followups),
+			FormatNumber(s.Total()),
+		})
+	}
+	rows = append(rows, []string{
+		"TOTAL",
+		"",
+		FormatNumber(stats.Total.UserMessages),
+		FormatNumber(stats.Total.Interruptions),
+		FormatNumber(stats.Total.RejectedToolCalls),
+		FormatNumber(stats.Total.Followups),
+		FormatNumber(stats.Total.Total()),
+	})
+	out.WriteTable(headers, rows)
+
+	return nil
+}
@@ -4,6 +4,9 @@ import (
 	"flag"
 	"fmt"
 	"os"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/service"
 )
 
 // SummaryCmd implements the summary command.
@@ -11,7 +14,14 @@ type SummaryCmd struct {
 	AgentID           string
 	Project           string
 	IncludeSidechains bool
+	ExcludeEmpty      bool
 	OutputPath        string
+	All               bool
+	Days              int
+	Limit             int
+	Git               bool
+	Oneline           bool
+	ExcludeAgents     stringList
 }
 
 func (c *SummaryCmd) Name() string {
@@ -26,16 +36,27 @@ func (c *SummaryCmd) Setup(fs *flag.FlagSet) {
 	fs.StringVar(&c.AgentID, "agent-id", "", "Specific subagent ID to analyze")
 	fs.StringVar(&c.Project, "project", "", "Project name/path (optional)")
 	fs.BoolVar(&c.IncludeSidechains, "include-sidechains", true, "Include sidechain (agent) conversations in analysis")
+	fs.BoolVar(&c.ExcludeEmpty, "exclude-empty", false, "Leave empty assistant responses out of message counts")
 	fs.StringVar(&c.OutputPath, "output", "", "File path to save the summary as JSON")
+	fs.BoolVar(&c.All, "all", false, "Summarize every session in --project as one JSON array")
+	fs.IntVar(&c.Days, "days", 0, "With --all, only include sessions from the last N days (0 for all)")
+	fs.IntVar(&c.Limit, "limit", 0, "With --all, maximum number of sessions to summarize (0 for no limit)")
+	fs.BoolVar(&c.Oneline, "oneline", false, "Print one aligned line per session: id, date, messages, tokens, errors")
+	fs.Var(&c.ExcludeAgents, "exclude-agent", "Leave out entries from this subagent ID; repeat to exclude several")
+	fs.BoolVar(&c.Git, "git", false, "Include git commits made in the session's working directory and branch while it ran")
 }
 
 func (c *SummaryCmd) Run(ctx *Context, args []string) error {
+	if c.All {
+		return c.runAll(ctx)
+	}
+
 	if len(args) < 1 {
 		return fmt.Errorf("session ID is required\nUsage: cclogviewer summary <session-id> [flags]")
 	}
 
 	sessionID := args[0]
-	summary, err := ctx.Services.Session.GetSessionSummary(sessionID, c.AgentID, c.Project, c.IncludeSidechains)
+	summary, err := ctx.Services.Session.GetSessionSummary(sessionID, c.AgentID, c.Project, c.IncludeSidechains, c.ExcludeAgents)
 	if err != nil {
 		return err
 	}
@@ -44,7 +65,16 @@ func (c *SummaryCmd) Run(ctx *Context, args []string) error {
 		return fmt.Errorf("session not found: %s", sessionID)
 	}
 
+	if c.ExcludeEmpty {
+		ctx.Services.Session.ExcludeEmptyAssistant(summary)
+	}
+
+	if c.Git {
+		attachCommits(ctx, summary, sessionID, c.Project)
+	}
+
 	out := NewOutputWriter(ctx.Output, ctx.Config.JSONOutput)
+	out.SetColor(ctx.Config.ColorOutput)
 
 	// Save to file if output path specified
 	if c.OutputPath != "" {
@@ -59,7 +89,11 @@ func (c *SummaryCmd) Run(ctx *Context, args []string) error {
 			return fmt.Errorf("failed to write summary: %w", err)
 		}
 
-		out.PrintLine("Summary saved to: %s", c.OutputPath)
+		return out.WriteSaved("Summary", service.NewOutputManifest(c.OutputPath, models.OutputTypeJSON))
+	}
+
+	if c.Oneline {
+		out.PrintLine("%s", FormatOneline(summary))
 		return nil
 	}
 
@@ -73,32 +107,223 @@ func (c *SummaryCmd) Run(ctx *Context, args []string) error {
 	out.PrintLine("Project: %s", summary.Project)
 	out.PrintLine("Date: %s", summary.Date)
 	out.PrintLine("Duration: %s", FormatDuration(summary.DurationMinutes))
+	out.PrintLine("Health: %s", out.HealthBadge(summary.HealthScore))
 	out.PrintLine("")
 
 	out.PrintLine("Messages: %d total (%d user, %d assistant)",
 		summary.MessageCount, summary.UserMessages, summary.AssistantMsgs)
+	if summary.ExcludedAgents != nil {
+		out.PrintLine("Excluded Agents: %s", FormatExcludedAgents(summary.ExcludedAgents))
+	}
+	if summary.EmptyAssistantMessages > 0 {
+		out.PrintLine("Empty Responses: %d", summary.EmptyAssistantMessages)
+	}
+	if summary.AssistantMsgs > 0 {
+		out.PrintLine("Turn Shape: %.2f prompts per assistant message, avg %.0f chars per prompt / %.0f chars per reply",
+			summary.UserAssistantRatio, summary.AvgUserMessageLength, summary.AvgAssistantMessageLength)
+	}
 
 	if summary.Tokens != nil {
 		out.PrintLine("Tokens: %s input / %s output",
 			FormatNumber(summary.Tokens.TotalInput),
 			FormatNumber(summary.Tokens.TotalOutput))
+		if summary.Tokens.TotalOutput > 0 {
+			out.PrintLine("Output Split: %s text / %s tool-driven",
+				FormatNumber(summary.Tokens.TextOutputTokens),
+				FormatNumber(summary.Tokens.ToolOutputTokens))
+		}
+		if summary.Tokens.ThinkingTokens > 0 {
+			estimated := ""
+			if summary.Tokens.ThinkingTokensEstimated {
+				estimated = " (estimated)"
+			}
+			out.PrintLine("Thinking: %s tokens%s", FormatNumber(summary.Tokens.ThinkingTokens), estimated)
+		}
 		if summary.Tokens.CacheRead > 0 || summary.Tokens.CacheCreation > 0 {
 			out.PrintLine("Cache: %s read / %s creation",
 				FormatNumber(summary.Tokens.CacheRead),
 				FormatNumber(summary.Tokens.CacheCreation))
 		}
+		if summary.TokensPerMinute > 0 {
+			out.PrintLine("Burn Rate: %.1f tokens/min", summary.TokensPerMinute)
+		}
 	}
 
 	if summary.ToolCalls != nil {
 		out.PrintLine("Tool Calls: %d total (%d success, %d failed)",
 			summary.ToolCalls.Total, summary.ToolCalls.Success, summary.ToolCalls.Failed)
+		if summary.ToolCalls.NoiseCalls > 0 {
+			out.PrintLine("Noise Tool Calls: %d (not counted above)", summary.ToolCalls.NoiseCalls)
+		}
+		if summary.ToolCalls.Total > 0 {
+			out.PrintLine("Tool Access: %d mutating / %d read-only / %d unknown",
+				summary.ToolCalls.Mutating, summary.ToolCalls.ReadOnly, summary.ToolCalls.UnknownAccess)
+		}
 	}
 
 	out.PrintLine("Errors: %d found", summary.ErrorCount)
+	if summary.HeavyReads > 0 {
+		out.PrintLine("Heavy Reads: %d (see heavy-reads)", summary.HeavyReads)
+	}
+
+	if stretch := summary.LongestToolFreeStretch; stretch != nil {
+		out.PrintLine("Longest Tool-Free Stretch: %d turns (steps %d-%d, %s output tokens)",
+			stretch.Turns, stretch.StartStep, stretch.EndStep, FormatNumber(stretch.OutputTokens))
+	}
+
+	if len(summary.BranchSwitches) > 0 {
+		out.PrintLine("Branch Switches: %d", len(summary.BranchSwitches))
+		for _, b := range summary.BranchSwitches {
+			out.PrintLine("  %s: %s -> %s", b.Timestamp, b.From, b.To)
+		}
+	}
+
+	if summary.CompactionCount > 0 {
+		out.PrintLine("Compactions: %d", summary.CompactionCount)
+		for _, c := range summary.Compactions {
+			out.PrintLine("  %s: %s -> %s tokens", c.Timestamp, FormatNumber(c.PreTokens), FormatNumber(c.PostTokens))
+		}
+	}
 
 	if summary.Sidechains != nil && summary.Sidechains.Count > 0 {
 		out.PrintLine("Sidechains: %d (%v)", summary.Sidechains.Count, summary.Sidechains.AgentTypes)
 	}
 
+	if len(summary.Commits) > 0 {
+		out.PrintLine("Commits: %d", len(summary.Commits))
+		printCommits(out, summary.Commits)
+	}
+
+	return nil
+}
+
+// onelineIDWidth fits a full session UUID.
+const onelineIDWidth = 36
+
+// FormatOneline renders a summary as a single line with fixed-width columns,
+// e.g. "<id> 2024-01-15   42msg   123456tok    3err". Numbers are not
+// thousands-separated so the output sorts and greps cleanly.
+func FormatOneline(s *models.SessionSummary) string {
+	tokens := 0
+	if s.Tokens != nil {
+		tokens = s.Tokens.TotalInput + s.Tokens.TotalOutput
+	}
+	date := s.Date
+	if date == "" {
+		date = "-"
+	}
+	return fmt.Sprintf("%-*s %-10s %5dmsg %9dtok %4derr",
+		onelineIDWidth, Truncate(s.SessionID, onelineIDWidth), date, s.MessageCount, tokens, s.ErrorCount)
+}
+
+// attachCommits adds the session's git commits to summary. Git problems, such
+// as a working directory that is not a repository, are reported as a warning.
+func attachCommits(ctx *Context, summary *models.SessionSummary, sessionID, project string) {
+	commits, err := ctx.Services.Session.SessionCommits(sessionID, project)
+	if err != nil {
+		fmt.Fprintf(ctx.ErrOutput, "Warning: git commits unavailable: %v\n", err)
+		return
+	}
+	summary.Commits = commits
+}
+
+// printCommits prints one line per commit: short hash, time, and subject.
+func printCommits(out *OutputWriter, commits []models.GitCommit) {
+	for _, commit := range commits {
+		hash := commit.Hash
+		if len(hash) > 8 {
+			hash = hash[:8]
+		}
+		out.PrintLine("  %s %s %s", hash, commit.Timestamp.Format("15:04:05"), Truncate(commit.Subject, 70))
+	}
+}
+
+// runAll summarizes every session in the project.
+func (c *SummaryCmd) runAll(ctx *Context) error {
+	if c.Project == "" {
+		return fmt.Errorf("--project is required with --all\nUsage: cclogviewer summary --all --project <name> [flags]")
+	}
+
+	// These flags shape a single session's summary and are not applied per session
+	switch {
+	case c.AgentID != "":
+		return fmt.Errorf("--agent-id cannot be used with --all")
+	case len(c.ExcludeAgents) > 0:
+		return fmt.Errorf("--exclude-agent cannot be used with --all")
+	case !c.IncludeSidechains:
+		return fmt.Errorf("--include-sidechains=false cannot be used with --all")
+	case c.Git:
+		return fmt.Errorf("--git cannot be used with --all")
+	}
+
+	summaries, report, err := ctx.Services.Session.ProjectSummaries(c.Project, c.Days, c.Limit)
+	if err != nil {
+		return err
+	}
+	WriteScanWarning(ctx.ErrOutput, report)
+	if c.ExcludeEmpty {
+		for i := range summaries {
+			ctx.Services.Session.ExcludeEmptyAssistant(&summaries[i])
+		}
+	}
+
+	out := NewOutputWriter(ctx.Output, ctx.Config.JSONOutput)
+
+	// Save to file if output path specified
+	if c.OutputPath != "" {
+		file, err := os.Create(c.OutputPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+
+		fileOut := NewOutputWriter(file, true)
+		if err := fileOut.WriteJSON(summaries); err != nil {
+			return fmt.Errorf("failed to write summaries: %w", err)
+		}
+
+		return out.WriteSaved(fmt.Sprintf("%d summaries", len(summaries)), service.NewOutputManifest(c.OutputPath, models.OutputTypeJSON))
+	}
+
+	if c.Oneline {
+		for i := range summaries {
+			out.PrintLine("%s", FormatOneline(&summaries[i]))
+		}
+		return nil
+	}
+
+	// Output to stdout
+	if ctx.Config.JSONOutput {
+		return out.WriteJSON(summaries)
+	}
+
+	// Human-readable output
+	if len(summaries) == 0 {
+		out.PrintLine("No sessions found")
+		return nil
+	}
+
+	headers := []string{"Session", "Date", "Duration", "Messages", "Output Tokens", "Tool Calls", "Errors"}
+	var rows [][]string
+	for _, s := range summaries {
+		outputTokens, toolCalls := 0, 0
+		if s.Tokens != nil {
+			outputTokens = s.Tokens.TotalOutput
+		}
+		if s.ToolCalls != nil {
+			toolCalls = s.ToolCalls.Total
+		}
+		rows = append(rows, []string{
+			s.SessionID,
+			s.Date,
+			FormatDuration(s.DurationMinutes),
+			fmt.Sprintf("%d", s.MessageCount),
+			FormatNumber(outputTokens),
+			fmt.Sprintf("%d", toolCalls),
+			fmt.Sprintf("%d", s.ErrorCount),
+		})
+	}
+	out.WriteTable(headers, rows)
+
 	return nil
 }
@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/brads3290/cclogviewer/internal/processor/tools/diff"
+)
+
+// SystemContextCmd implements the system-context command.
+type SystemContextCmd struct {
+	Project  string
+	FilePath string
+	Diff     bool
+}
+
+func (c *SystemContextCmd) Name() string {
+	return "system-context"
+}
+
+func (c *SystemContextCmd) Description() string {
+	return "Show the system context injected into a session, or diff it between two sessions"
+}
+
+func (c *SystemContextCmd) Setup(fs *flag.FlagSet) {
+	fs.StringVar(&c.Project, "project", "", "Project name/path (optional)")
+	fs.StringVar(&c.FilePath, "file", "", "Direct path to a JSONL log file (instead of a session ID)")
+	fs.BoolVar(&c.Diff, "diff", false, "Compare the system context of two sessions (<old-session-id> <new-session-id>)")
+}
+
+func (c *SystemContextCmd) Run(ctx *Context, args []string) error {
+	if c.Diff {
+		return c.runDiff(ctx, args)
+	}
+
+	if len(args) < 1 && c.FilePath == "" {
+		return fmt.Errorf("session ID is required\nUsage: cclogviewer system-context <session-id> [flags]")
+	}
+
+	var text, label string
+	var err error
+	if c.FilePath != "" {
+		text, err = ctx.Services.Session.ExtractSystemContextFromFile(c.FilePath)
+		label = c.FilePath
+	} else {
+		text, err = ctx.Services.Session.ExtractSystemContext(args[0], c.Project)
+		label = args[0]
+	}
+	if err != nil {
+		return err
+	}
+
+	out := NewOutputWriter(ctx.Output, ctx.Config.JSONOutput)
+
+	if ctx.Config.JSONOutput {
+		return out.WriteJSON(map[string]interface{}{
+			"session":        label,
+			"system_context": text,
+		})
+	}
+
+	if text == "" {
+		out.PrintLine("No system context found for %s", label)
+		return nil
+	}
+	out.PrintLine("%s", text)
+
+	return nil
+}
+
+func (c *SystemContextCmd) runDiff(ctx *Context, args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("two session IDs are required\nUsage: cclogviewer system-context --diff <old-session-id> <new-session-id> [flags]")
+	}
+
+	lines, err := ctx.Services.Session.DiffSystemContext(args[0], args[1], c.Project)
+	if err != nil {
+		return err
+	}
+
+	changed := 0
+	for _, l := range lines {
+		if l.Type != diff.LineUnchanged {
+			changed++
+		}
+	}
+
+	out := NewOutputWriter(ctx.Output, ctx.Config.JSONOutput)
+
+	if ctx.Config.JSONOutput {
+		type diffLine struct {
+			Type    string `json:"type"`
+			Content string `json:"content"`
+		}
+		result := make([]diffLine, 0, len(lines))
+		for _, l := range lines {
+			result = append(result, diffLine{Type: l.Type.String(), Content: l.Content})
+		}
+		return out.WriteJSON(map[string]interface{}{
+			"old_session":   args[0],
+			"new_session":   args[1],
+			"changed_lines": changed,
+			"lines":         result,
+		})
+	}
+
+	if changed == 0 {
+		out.PrintLine("System context is identical in %s and %s", args[0], args[1])
+		return nil
+	}
+
+	out.PrintLine("--- %s", args[0])
+	out.PrintLine("+++ %s", args[1])
+	for _, l := range lines {
+		out.PrintLine("%s%s", l.Type.Prefix(), l.Content)
+	}
+
+	return nil
+}
@@ -4,6 +4,11 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
+	"time"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/service"
 )
 
 // TimelineCmd implements the timeline command.
@@ -11,8 +16,17 @@ type TimelineCmd struct {
 	AgentID           string
 	Project           string
 	IncludeSidechains bool
+	SidechainsOnly    bool
 	Limit             int
 	OutputPath        string
+	Format            string
+	TextOnly          bool
+	Cost              bool
+	Model             string
+	FromTime          string
+	ToTime            string
+	IncludeTokens     bool
+	MutatingOnly      bool
 }
 
 func (c *TimelineCmd) Name() string {
@@ -27,8 +41,17 @@ func (c *TimelineCmd) Setup(fs *flag.FlagSet) {
 	fs.StringVar(&c.AgentID, "agent-id", "", "Specific subagent ID to analyze")
 	fs.StringVar(&c.Project, "project", "", "Project name/path (optional)")
 	fs.BoolVar(&c.IncludeSidechains, "include-sidechains", true, "Include sidechain (agent) conversations in analysis")
+	fs.BoolVar(&c.SidechainsOnly, "sidechains-only", false, "Only include sidechain (agent) conversations, grouped by agent")
 	fs.IntVar(&c.Limit, "limit", 100, "Maximum number of timeline entries to return")
-	fs.StringVar(&c.OutputPath, "output", "", "File path to save the timeline as JSON")
+	fs.StringVar(&c.OutputPath, "output", "", "File path to save the timeline as JSON (or Mermaid with --format mermaid)")
+	fs.StringVar(&c.Format, "format", "table", "Output format: table, mermaid (Gantt diagram), or otlp (OpenTelemetry JSON spans)")
+	fs.BoolVar(&c.TextOnly, "text-only", false, "Only include assistant text messages without tool calls")
+	fs.BoolVar(&c.MutatingOnly, "mutating-only", false, "Only include calls to tools that modify state (Edit, Write, Bash, ...)")
+	fs.BoolVar(&c.Cost, "cost", false, "Estimate the cost of each step (requires --model)")
+	fs.StringVar(&c.Model, "model", "", "Model whose rates are used for --cost (e.g. claude-sonnet-4-5, opus)")
+	fs.StringVar(&c.FromTime, "from-time", "", "Only include entries at or after this time (HH:MM[:SS], RFC3339, or +duration from session start)")
+	fs.StringVar(&c.ToTime, "to-time", "", "Only include entries at or before this time (HH:MM[:SS], RFC3339, or +duration from session start)")
+	fs.BoolVar(&c.IncludeTokens, "include-tokens", false, "Show each step's input, output, and cache token breakdown")
 }
 
 func (c *TimelineCmd) Run(ctx *Context, args []string) error {
@@ -36,8 +59,50 @@ func (c *TimelineCmd) Run(ctx *Context, args []string) error {
 		return fmt.Errorf("session ID is required\nUsage: cclogviewer timeline <session-id> [flags]")
 	}
 
+	if c.Format != "table" && c.Format != "mermaid" && c.Format != "otlp" {
+		return fmt.Errorf("unsupported format: %s (expected table, mermaid, or otlp)", c.Format)
+	}
+
+	if c.TextOnly && c.MutatingOnly {
+		return fmt.Errorf("--text-only and --mutating-only cannot be used together")
+	}
+
+	if c.SidechainsOnly && (c.AgentID != "" || c.Format == "otlp") {
+		return fmt.Errorf("--sidechains-only cannot be used with --agent-id or --format otlp")
+	}
+
 	sessionID := args[0]
-	timeline, err := ctx.Services.Session.GetSessionTimeline(sessionID, c.AgentID, c.Project, c.IncludeSidechains, c.Limit)
+
+	if c.Format == "otlp" {
+		return c.runOTLP(ctx, sessionID)
+	}
+
+	window := service.TimeWindow{From: c.FromTime, To: c.ToTime, Location: ctx.Config.Location}
+
+	// Filtering happens after the timeline is built, so fetch it unlimited
+	limit := c.Limit
+	if c.TextOnly || c.MutatingOnly || !window.IsZero() {
+		limit = 0
+	}
+
+	costModel := ""
+	if c.Cost {
+		if _, ok := service.LookupModelRates(c.Model); ok {
+			costModel = c.Model
+		} else if c.Model == "" {
+			fmt.Fprintln(ctx.ErrOutput, "Warning: --cost requires --model; cost omitted")
+		} else {
+			fmt.Fprintf(ctx.ErrOutput, "Warning: no rates known for model %q; cost omitted\n", c.Model)
+		}
+	}
+
+	var timeline *models.SessionTimeline
+	var err error
+	if c.SidechainsOnly {
+		timeline, err = ctx.Services.Session.GetSidechainTimeline(sessionID, c.Project, limit, costModel, c.IncludeTokens)
+	} else {
+		timeline, err = ctx.Services.Session.GetSessionTimeline(sessionID, c.AgentID, c.Project, c.IncludeSidechains, limit, costModel, c.IncludeTokens)
+	}
 	if err != nil {
 		return err
 	}
@@ -46,7 +111,38 @@ func (c *TimelineCmd) Run(ctx *Context, args []string) error {
 		return fmt.Errorf("session not found: %s", sessionID)
 	}
 
+	if !window.IsZero() {
+		windowLimit := c.Limit
+		if c.TextOnly || c.MutatingOnly {
+			windowLimit = 0
+		}
+		if err := service.FilterTimelineByTime(timeline, window, windowLimit); err != nil {
+			return err
+		}
+	}
+
+	if c.TextOnly {
+		service.FilterTextOnlyTimeline(timeline, c.Limit)
+	}
+
+	if c.MutatingOnly {
+		service.FilterMutatingTimeline(timeline, c.Limit)
+	}
+
 	out := NewOutputWriter(ctx.Output, ctx.Config.JSONOutput)
+	out.SetColor(ctx.Config.ColorOutput)
+
+	if c.Format == "mermaid" {
+		diagram := FormatTimelineMermaid(timeline)
+		if c.OutputPath != "" {
+			if err := os.WriteFile(c.OutputPath, []byte(diagram), 0644); err != nil {
+				return fmt.Errorf("failed to write timeline: %w", err)
+			}
+			return out.WriteSaved("Timeline", service.NewOutputManifest(c.OutputPath, models.OutputTypeMermaid))
+		}
+		fmt.Fprint(ctx.Output, diagram)
+		return nil
+	}
 
 	// Save to file if output path specified
 	if c.OutputPath != "" {
@@ -61,8 +157,7 @@ func (c *TimelineCmd) Run(ctx *Context, args []string) error {
 			return fmt.Errorf("failed to write timeline: %w", err)
 		}
 
-		out.PrintLine("Timeline saved to: %s", c.OutputPath)
-		return nil
+		return out.WriteSaved("Timeline", service.NewOutputManifest(c.OutputPath, models.OutputTypeJSON))
 	}
 
 	// Output to stdout
@@ -72,25 +167,180 @@ func (c *TimelineCmd) Run(ctx *Context, args []string) error {
 
 	// Human-readable output
 	out.PrintLine("Session Timeline: %s", timeline.SessionID)
-	out.PrintLine("Total Entries: %d (showing %d)\n", timeline.TotalEntries, timeline.ReturnedEntries)
+	if c.TextOnly {
+		out.PrintLine("Total Entries: %d, text-only: %d (showing %d)\n", timeline.TotalEntries, timeline.FilteredEntries, timeline.ReturnedEntries)
+	} else if c.MutatingOnly {
+		out.PrintLine("Total Entries: %d, mutating tool calls: %d (showing %d)\n", timeline.TotalEntries, timeline.FilteredEntries, timeline.ReturnedEntries)
+	} else if !window.IsZero() {
+		out.PrintLine("Total Entries: %d, in time window: %d (showing %d)\n", timeline.TotalEntries, timeline.FilteredEntries, timeline.ReturnedEntries)
+	} else {
+		out.PrintLine("Total Entries: %d (showing %d)\n", timeline.TotalEntries, timeline.ReturnedEntries)
+	}
 
 	headers := []string{"Step", "Time", "Role", "Type", "Tool/Summary", "Status"}
+	if c.IncludeTokens {
+		headers = append(headers, "Input", "Output", "Cache Read", "Cache Write")
+	}
+	if timeline.CostModel != "" {
+		headers = append(headers, "Cost")
+	}
 	var rows [][]string
 	for _, e := range timeline.Timeline {
 		summary := e.Summary
 		if e.Tool != "" {
 			summary = e.Tool + ": " + summary
 		}
-		rows = append(rows, []string{
+		entryType := e.Type
+		if e.Followup {
+			entryType = "followup"
+		}
+		row := []string{
 			fmt.Sprintf("%d", e.Step),
-			e.Timestamp,
+			out.Dim(FormatClock(e.Timestamp, e.LocalTime)),
 			e.Role,
-			e.Type,
+			entryType,
 			Truncate(summary, 40),
-			e.Status,
-		})
+			out.Status(e.Status),
+		}
+		if c.IncludeTokens {
+			row = append(row, formatTokenBreakdown(e.TokenBreakdown)...)
+		}
+		if timeline.CostModel != "" {
+			row = append(row, formatCost(e.Cost))
+		}
+		rows = append(rows, row)
 	}
 	out.WriteTable(headers, rows)
 
+	if timeline.CostModel != "" {
+		out.PrintLine("\nEstimated cost (%s): $%.4f", timeline.CostModel, timeline.TotalCost)
+	}
+
 	return nil
 }
+
+// runOTLP exports the session's tool calls as OTLP/JSON spans. Spans cover
+// every tool call, so the step filters (--limit, --text-only, --mutating-only,
+// --from-time, --to-time) do not apply.
+func (c *TimelineCmd) runOTLP(ctx *Context, sessionID string) error {
+	if c.TextOnly || c.MutatingOnly || c.FromTime != "" || c.ToTime != "" {
+		return fmt.Errorf("--text-only, --mutating-only, --from-time and --to-time cannot be used with --format otlp")
+	}
+
+	trace, err := ctx.Services.Session.GetSessionTrace(sessionID, c.AgentID, c.Project, c.IncludeSidechains)
+	if err != nil {
+		return err
+	}
+	if trace == nil {
+		return fmt.Errorf("session not found: %s", sessionID)
+	}
+
+	if c.OutputPath != "" {
+		file, err := os.Create(c.OutputPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+
+		if err := NewOutputWriter(file, true).WriteJSON(trace); err != nil {
+			return fmt.Errorf("failed to write trace: %w", err)
+		}
+		return NewOutputWriter(ctx.Output, ctx.Config.JSONOutput).WriteSaved("Trace", service.NewOutputManifest(c.OutputPath, models.OutputTypeJSON))
+	}
+
+	return NewOutputWriter(ctx.Output, true).WriteJSON(trace)
+}
+
+// formatCost formats a step cost in USD, or "-" when the step had none.
+func formatCost(cost float64) string {
+	if cost == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("$%.4f", cost)
+}
+
+// formatTokenBreakdown formats a step's token counts as table cells, or "-"
+// cells when the step had none.
+func formatTokenBreakdown(b *models.TokenBreakdown) []string {
+	if b == nil {
+		return []string{"-", "-", "-", "-"}
+	}
+	return []string{
+		FormatNumber(b.Input),
+		FormatNumber(b.Output),
+		FormatNumber(b.CacheRead),
+		FormatNumber(b.CacheCreation),
+	}
+}
+
+// FormatTimelineMermaid renders a timeline as a Mermaid Gantt diagram.
+// Each step spans from its timestamp to the next step's; steps without a usable
+// timestamp are chained after the previous step so ordering is preserved.
+func FormatTimelineMermaid(timeline *models.SessionTimeline) string {
+	var b strings.Builder
+
+	b.WriteString("gantt\n")
+	fmt.Fprintf(&b, "    title Session %s\n", mermaidLabel(timeline.SessionID))
+	b.WriteString("    dateFormat HH:mm:ss\n")
+	b.WriteString("    axisFormat %H:%M:%S\n")
+	b.WriteString("    section Timeline\n")
+
+	for i, e := range timeline.Timeline {
+		label := e.Type
+		if e.Tool != "" {
+			label = e.Tool
+		}
+		if e.Summary != "" {
+			label += " - " + Truncate(e.Summary, 40)
+		}
+		label = fmt.Sprintf("%d %s %s", e.Step, e.Role, label)
+
+		tag := ""
+		if e.Status == "failed" {
+			tag = "crit, "
+		}
+
+		id := fmt.Sprintf("s%d", i+1)
+		start, ok := parseTimelineClock(e.Timestamp)
+		if !ok {
+			if i == 0 {
+				fmt.Fprintf(&b, "    %s :%s%s, 00:00:00, 1s\n", mermaidLabel(label), tag, id)
+			} else {
+				fmt.Fprintf(&b, "    %s :%s%s, after s%d, 1s\n", mermaidLabel(label), tag, id, i)
+			}
+			continue
+		}
+
+		duration := time.Second
+		if i+1 < len(timeline.Timeline) {
+			if next, ok := parseTimelineClock(timeline.Timeline[i+1].Timestamp); ok && next.Sub(start) > duration {
+				duration = next.Sub(start)
+			}
+		}
+
+		fmt.Fprintf(&b, "    %s :%s%s, %s, %ds\n", mermaidLabel(label), tag, id, start.Format("15:04:05"), int(duration.Seconds()))
+	}
+
+	return b.String()
+}
+
+// parseTimelineClock parses a timeline timestamp in HH:MM:SS form.
+func parseTimelineClock(ts string) (time.Time, bool) {
+	t, err := time.Parse("15:04:05", ts)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// mermaidLabel strips characters that Mermaid treats as task syntax.
+func mermaidLabel(s string) string {
+	s = strings.Map(func(r rune) rune {
+		switch r {
+		case ':', ';', '#', '\n', '\r', '\t':
+			return ' '
+		}
+		return r
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/service"
+)
+
+// TodosCmd implements the todos command.
+type TodosCmd struct {
+	Project    string
+	FilePath   string
+	OutputPath string
+}
+
+func (c *TodosCmd) Name() string {
+	return "todos"
+}
+
+func (c *TodosCmd) Description() string {
+	return "Show how the TodoWrite task list changed over a session"
+}
+
+func (c *TodosCmd) Setup(fs *flag.FlagSet) {
+	fs.StringVar(&c.Project, "project", "", "Project name/path (optional)")
+	fs.StringVar(&c.FilePath, "file", "", "Direct path to a JSONL log file (instead of a session ID)")
+	fs.StringVar(&c.OutputPath, "output", "", "File path to save the snapshots as JSON")
+}
+
+func (c *TodosCmd) Run(ctx *Context, args []string) error {
+	if len(args) < 1 && c.FilePath == "" {
+		return fmt.Errorf("session ID is required\nUsage: cclogviewer todos <session-id> [flags]")
+	}
+
+	var snapshots []models.TodoSnapshot
+	var err error
+
+	if c.FilePath != "" {
+		snapshots, err = ctx.Services.Session.ExtractTodosFromFile(c.FilePath)
+	} else {
+		snapshots, err = ctx.Services.Session.ExtractTodos(args[0], c.Project)
+	}
+	if err != nil {
+		return err
+	}
+
+	if snapshots == nil {
+		return fmt.Errorf("session not found: %s", args[0])
+	}
+
+	out := NewOutputWriter(ctx.Output, ctx.Config.JSONOutput)
+
+	// Save to file if output path specified
+	if c.OutputPath != "" {
+		file, err := os.Create(c.OutputPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+
+		fileOut := NewOutputWriter(file, true)
+		if err := fileOut.WriteJSON(snapshots); err != nil {
+			return fmt.Errorf("failed to write todos: %w", err)
+		}
+
+		return out.WriteSaved("Todos", service.NewOutputManifest(c.OutputPath, models.OutputTypeJSON))
+	}
+
+	// Output to stdout
+	if ctx.Config.JSONOutput {
+		return out.WriteJSON(snapshots)
+	}
+
+	// Human-readable output
+	if len(snapshots) == 0 {
+		out.PrintLine("No TodoWrite calls found")
+		return nil
+	}
+
+	for _, snap := range snapshots {
+		out.PrintSection(fmt.Sprintf("Snapshot %d (%s) - %d added, %d completed",
+			snap.Step, snap.Timestamp, snap.AddedCount, snap.CompletedCount))
+		for _, item := range snap.Items {
+			marker := ""
+			if item.NewlyCompleted {
+				marker = " (newly completed)"
+			} else if item.IsNew {
+				marker = " (new)"
+			}
+			out.PrintLine("  [%s] %s%s", todoStatusMark(item.Status), item.Content, marker)
+		}
+	}
+
+	return nil
+}
+
+// todoStatusMark returns a short checkbox marker for a todo status.
+func todoStatusMark(status string) string {
+	switch status {
+	case "completed":
+		return "x"
+	case "in_progress":
+		return "~"
+	default:
+		return " "
+	}
+}
@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/brads3290/cclogviewer/internal/browser"
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/renderer"
+	"github.com/brads3290/cclogviewer/internal/service"
+)
+
+// TokenTreeCmd implements the token-tree command.
+type TokenTreeCmd struct {
+	Project     string
+	FilePath    string
+	HTMLPath    string
+	OpenBrowser bool
+}
+
+func (c *TokenTreeCmd) Name() string {
+	return "token-tree"
+}
+
+func (c *TokenTreeCmd) Description() string {
+	return "Attribute session tokens to the main conversation, subagents, and tools"
+}
+
+func (c *TokenTreeCmd) Setup(fs *flag.FlagSet) {
+	fs.StringVar(&c.Project, "project", "", "Project name/path (optional)")
+	fs.StringVar(&c.FilePath, "file", "", "Direct path to a JSONL log file (instead of a session ID)")
+	fs.StringVar(&c.HTMLPath, "html", "", "Write an HTML treemap to this file instead of printing")
+	fs.BoolVar(&c.OpenBrowser, "open", false, "Open the HTML treemap in browser (requires --html)")
+}
+
+func (c *TokenTreeCmd) Run(ctx *Context, args []string) error {
+	if len(args) < 1 && c.FilePath == "" {
+		return fmt.Errorf("session ID is required\nUsage: cclogviewer token-tree <session-id> [flags]")
+	}
+
+	var tree *models.TokenTree
+	var err error
+
+	if c.FilePath != "" {
+		tree, err = ctx.Services.Session.TokenAttributionFromFile(c.FilePath)
+	} else {
+		tree, err = ctx.Services.Session.TokenAttribution(args[0], c.Project)
+	}
+	if err != nil {
+		return err
+	}
+
+	if tree == nil {
+		return fmt.Errorf("session not found: %s", args[0])
+	}
+
+	out := NewOutputWriter(ctx.Output, ctx.Config.JSONOutput)
+
+	if c.HTMLPath != "" {
+		if err := renderer.GenerateTokenTreeHTML(tree, c.HTMLPath); err != nil {
+			return fmt.Errorf("failed to generate treemap: %w", err)
+		}
+		manifest := service.NewOutputManifest(c.HTMLPath, models.OutputTypeHTML)
+
+		if c.OpenBrowser {
+			if err := browser.OpenInBrowser(c.HTMLPath); err != nil {
+				fmt.Fprintf(ctx.ErrOutput, "Warning: Could not open browser: %v\n", err)
+			} else {
+				manifest.OpenedBrowser = true
+			}
+		}
+		return out.WriteSaved("Treemap", manifest)
+	}
+
+	if ctx.Config.JSONOutput {
+		return out.WriteJSON(tree)
+	}
+
+	// Human-readable output: an indented tree with each node's share of the total
+	printTokenTree(out, tree, tree.Tokens, 0)
+
+	return nil
+}
+
+// printTokenTree prints a node and its children, indented by depth.
+func printTokenTree(out *OutputWriter, node *models.TokenTree, total, depth int) {
+	share := 0.0
+	if total > 0 {
+		share = float64(node.Tokens) * 100 / float64(total)
+	}
+
+	name := node.Name
+	if node.Kind == models.TokenNodeAgent {
+		name = "agent: " + name
+	}
+
+	out.PrintLine("%s%-*s %12s %6.1f%%", strings.Repeat("  ", depth), 40-2*depth, Truncate(name, 40-2*depth), FormatNumber(node.Tokens), share)
+	for _, c := range node.Children {
+		printTokenTree(out, c, total, depth+1)
+	}
+}
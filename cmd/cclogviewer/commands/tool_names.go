@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+)
+
+// ToolNamesCmd implements the tool-names command.
+type ToolNamesCmd struct {
+	Days int
+}
+
+func (c *ToolNamesCmd) Name() string {
+	return "tool-names"
+}
+
+func (c *ToolNamesCmd) Description() string {
+	return "List every tool name called in a project, with call counts"
+}
+
+func (c *ToolNamesCmd) Setup(fs *flag.FlagSet) {
+	fs.IntVar(&c.Days, "days", 0, "Only include sessions from the last N days")
+}
+
+func (c *ToolNamesCmd) Run(ctx *Context, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("project name is required\nUsage: cclogviewer tool-names <project> [flags]")
+	}
+
+	project := args[0]
+	names, report, err := ctx.Services.Session.ListToolNames(project, c.Days)
+	if err != nil {
+		return err
+	}
+
+	out := NewOutputWriter(ctx.Output, ctx.Config.JSONOutput)
+
+	if ctx.Config.JSONOutput {
+		return out.WriteJSON(map[string]interface{}{
+			"tools":            names,
+			"count":            len(names),
+			"sessions_scanned": report.SessionsScanned,
+			"failures":         report.Failures,
+		})
+	}
+
+	WriteScanWarning(ctx.ErrOutput, report)
+
+	// Human-readable output
+	if len(names) == 0 {
+		out.PrintLine("No tool calls found for project: %s", project)
+		return nil
+	}
+
+	headers := []string{"Tool", "Calls", "Sessions"}
+	var rows [][]string
+	for _, n := range names {
+		rows = append(rows, []string{
+			n.Name,
+			FormatNumber(n.Count),
+			FormatNumber(n.Sessions),
+		})
+	}
+	out.WriteTable(headers, rows)
+
+	return nil
+}
@@ -4,6 +4,9 @@ import (
 	"flag"
 	"fmt"
 	"os"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/service"
 )
 
 // ToolsCmd implements the tools command.
@@ -12,6 +15,10 @@ type ToolsCmd struct {
 	Project           string
 	IncludeSidechains bool
 	OutputPath        string
+	CountOnly         bool
+	Signature         bool
+	ExcludeAgents     stringList
+	MutatingOnly      bool
 }
 
 func (c *ToolsCmd) Name() string {
@@ -27,6 +34,10 @@ func (c *ToolsCmd) Setup(fs *flag.FlagSet) {
 	fs.StringVar(&c.Project, "project", "", "Project name/path (optional)")
 	fs.BoolVar(&c.IncludeSidechains, "include-sidechains", true, "Include sidechain (agent) conversations in analysis")
 	fs.StringVar(&c.OutputPath, "output", "", "File path to save the stats as JSON")
+	fs.Var(&c.ExcludeAgents, "exclude-agent", "Leave out entries from this subagent ID; repeat to exclude several")
+	fs.BoolVar(&c.MutatingOnly, "mutating-only", false, "Only show tools that modify state (Edit, Write, Bash, ...)")
+	fs.BoolVar(&c.CountOnly, "count-only", false, "Print only the total number of tool calls")
+	fs.BoolVar(&c.Signature, "signature", false, "Print only the tool sequence signature, raw and with repeats collapsed")
 }
 
 func (c *ToolsCmd) Run(ctx *Context, args []string) error {
@@ -35,7 +46,7 @@ func (c *ToolsCmd) Run(ctx *Context, args []string) error {
 	}
 
 	sessionID := args[0]
-	stats, err := ctx.Services.Session.GetToolUsageStats(sessionID, c.AgentID, c.Project, c.IncludeSidechains)
+	stats, err := ctx.Services.Session.GetToolUsageStats(sessionID, c.AgentID, c.Project, c.IncludeSidechains, c.ExcludeAgents)
 	if err != nil {
 		return err
 	}
@@ -44,7 +55,28 @@ func (c *ToolsCmd) Run(ctx *Context, args []string) error {
 		return fmt.Errorf("session not found: %s", sessionID)
 	}
 
+	if c.MutatingOnly {
+		service.FilterMutatingTools(stats)
+	}
+
 	out := NewOutputWriter(ctx.Output, ctx.Config.JSONOutput)
+	out.SetColor(ctx.Config.ColorOutput)
+
+	if c.CountOnly {
+		return out.WriteCount(stats.TotalCalls())
+	}
+
+	if c.Signature {
+		if ctx.Config.JSONOutput {
+			return out.WriteJSON(map[string]string{
+				"sequence_signature":  stats.SequenceSignature,
+				"collapsed_signature": stats.CollapsedSignature,
+			})
+		}
+		out.PrintKeyValue("Signature", stats.SequenceSignature)
+		out.PrintKeyValue("Collapsed", stats.CollapsedSignature)
+		return nil
+	}
 
 	// Save to file if output path specified
 	if c.OutputPath != "" {
@@ -59,8 +91,7 @@ func (c *ToolsCmd) Run(ctx *Context, args []string) error {
 			return fmt.Errorf("failed to write stats: %w", err)
 		}
 
-		out.PrintLine("Tool stats saved to: %s", c.OutputPath)
-		return nil
+		return out.WriteSaved("Tool stats", service.NewOutputManifest(c.OutputPath, models.OutputTypeJSON))
 	}
 
 	// Output to stdout
@@ -69,20 +100,42 @@ func (c *ToolsCmd) Run(ctx *Context, args []string) error {
 	}
 
 	// Human-readable output
-	out.PrintLine("Tool Usage Statistics: %s\n", stats.SessionID)
+	out.PrintLine("Tool Usage Statistics: %s", stats.SessionID)
+	if stats.ExcludedAgents != nil {
+		out.PrintLine("Excluded Agents: %s", FormatExcludedAgents(stats.ExcludedAgents))
+	}
+	out.PrintLine("")
 
-	headers := []string{"Tool", "Count", "Success", "Failed"}
+	headers := []string{"Tool", "Count", "%", "Success", "Failed", "Access"}
 	var rows [][]string
 	for _, t := range stats.Tools {
 		rows = append(rows, []string{
 			t.Name,
 			FormatNumber(t.Count),
-			FormatNumber(t.Success),
-			FormatNumber(t.Failed),
+			fmt.Sprintf("%.1f%%", t.Percentage),
+			out.Count(t.Success, out.Green),
+			out.Count(t.Failed, out.Red),
+			t.Access,
 		})
 	}
 	out.WriteTable(headers, rows)
 
+	if len(stats.NoiseTools) > 0 {
+		out.PrintLine("\nNoise Tools (not counted above):")
+		rows = nil
+		for _, t := range stats.NoiseTools {
+			rows = append(rows, []string{
+				t.Name,
+				FormatNumber(t.Count),
+				fmt.Sprintf("%.1f%%", t.Percentage),
+				out.Count(t.Success, out.Green),
+				out.Count(t.Failed, out.Red),
+				t.Access,
+			})
+		}
+		out.WriteTable(headers, rows)
+	}
+
 	if stats.Patterns != nil {
 		out.PrintLine("\nPatterns:")
 		out.PrintKeyValue("Most Used", stats.Patterns.MostUsed)
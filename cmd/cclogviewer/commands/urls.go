@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/service"
+)
+
+// URLsCmd implements the urls command.
+type URLsCmd struct {
+	Project    string
+	FilePath   string
+	OutputPath string
+}
+
+func (c *URLsCmd) Name() string {
+	return "urls"
+}
+
+func (c *URLsCmd) Description() string {
+	return "List URLs fetched or found by WebFetch and WebSearch in a session"
+}
+
+func (c *URLsCmd) Setup(fs *flag.FlagSet) {
+	fs.StringVar(&c.Project, "project", "", "Project name/path (optional)")
+	fs.StringVar(&c.FilePath, "file", "", "Direct path to a JSONL log file (instead of a session ID)")
+	fs.StringVar(&c.OutputPath, "output", "", "File path to save the URLs as JSON")
+}
+
+func (c *URLsCmd) Run(ctx *Context, args []string) error {
+	if len(args) < 1 && c.FilePath == "" {
+		return fmt.Errorf("session ID is required\nUsage: cclogviewer urls <session-id> [flags]")
+	}
+
+	var urls []models.URLHit
+	var err error
+
+	if c.FilePath != "" {
+		urls, err = ctx.Services.Session.ExtractURLsFromFile(c.FilePath)
+	} else {
+		urls, err = ctx.Services.Session.ExtractURLs(args[0], c.Project)
+	}
+	if err != nil {
+		return err
+	}
+
+	if urls == nil {
+		return fmt.Errorf("session not found: %s", args[0])
+	}
+
+	out := NewOutputWriter(ctx.Output, ctx.Config.JSONOutput)
+
+	// Save to file if output path specified
+	if c.OutputPath != "" {
+		file, err := os.Create(c.OutputPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+
+		fileOut := NewOutputWriter(file, true)
+		if err := fileOut.WriteJSON(urls); err != nil {
+			return fmt.Errorf("failed to write URLs: %w", err)
+		}
+
+		return out.WriteSaved("URLs", service.NewOutputManifest(c.OutputPath, models.OutputTypeJSON))
+	}
+
+	// Output to stdout
+	if ctx.Config.JSONOutput {
+		return out.WriteJSON(map[string]interface{}{
+			"urls":  urls,
+			"count": len(urls),
+		})
+	}
+
+	// Human-readable output
+	if len(urls) == 0 {
+		out.PrintLine("No web URLs found")
+		return nil
+	}
+
+	headers := []string{"Count", "Tools", "First Seen", "URL"}
+	var rows [][]string
+	for _, u := range urls {
+		rows = append(rows, []string{
+			FormatNumber(u.Count),
+			strings.Join(u.Tools, ","),
+			u.FirstSeen,
+			Truncate(u.URL, 80),
+		})
+	}
+	out.WriteTable(headers, rows)
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
@@ -17,6 +18,7 @@ import (
 	"github.com/brads3290/cclogviewer/internal/parser"
 	"github.com/brads3290/cclogviewer/internal/processor"
 	"github.com/brads3290/cclogviewer/internal/renderer"
+	"github.com/brads3290/cclogviewer/internal/service"
 )
 
 var (
@@ -39,10 +41,21 @@ func main() {
 		}
 	}
 
-	// If no arguments, print help
+	// Read the config file once; whether an error is fatal depends on the command
+	fileConfig, fileConfigErr := commands.LoadFileConfig(commands.DefaultConfigPath())
+
+	// If no arguments, run the configured default command, or print help
 	if len(os.Args) < 2 {
-		commands.DefaultRegistry.PrintHelp(os.Stdout)
-		os.Exit(0)
+		if fileConfigErr != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", fileConfigErr)
+			os.Exit(1)
+		}
+		defaultArgs := strings.Fields(fileConfig.DefaultCommand)
+		if len(defaultArgs) == 0 || defaultArgs[0] == "help" {
+			commands.DefaultRegistry.PrintHelp(os.Stdout)
+			os.Exit(0)
+		}
+		os.Args = append(os.Args, defaultArgs...)
 	}
 
 	// Check if running in legacy mode (first arg starts with - and is a legacy flag)
@@ -52,7 +65,7 @@ func main() {
 	}
 
 	// Run subcommand mode
-	if err := runSubcommandMode(); err != nil {
+	if err := runSubcommandMode(fileConfig, fileConfigErr); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
@@ -60,7 +73,7 @@ func main() {
 
 // isLegacyFlag checks if the argument is a legacy mode flag.
 func isLegacyFlag(arg string) bool {
-	legacyFlags := []string{"-input", "-output", "-open", "-debug", "-contextsize"}
+	legacyFlags := []string{"-input", "-output", "-open", "-debug", "-contextsize", "-summary"}
 	for _, f := range legacyFlags {
 		if arg == f || strings.HasPrefix(arg, f+"=") {
 			return true
@@ -70,7 +83,9 @@ func isLegacyFlag(arg string) bool {
 }
 
 // runSubcommandMode handles the new subcommand-based CLI.
-func runSubcommandMode() error {
+// A config file error fails only the recent command, which reads its
+// settings; other commands warn and continue without the file.
+func runSubcommandMode(fileConfig *commands.FileConfig, fileConfigErr error) error {
 	cmdName := os.Args[1]
 
 	// Look up command
@@ -92,6 +107,17 @@ func runSubcommandMode() error {
 	fs.StringVar(&config.ClaudeDir, "claude-dir", homeDir, "Path to Claude directory")
 	fs.BoolVar(&config.JSONOutput, "json", false, "Output in JSON format")
 	fs.BoolVar(&config.Debug, "debug", false, "Enable debug logging")
+	fs.BoolVar(&config.EncodedProject, "encoded", false, "Treat project names as encoded directory names (e.g. -Users-me-app)")
+	fs.StringVar(&config.Timezone, "timezone", "", "Time zone for displayed timestamps, e.g. Europe/Berlin (default: $TZ)")
+	fs.BoolVar(&config.StripANSI, "strip-ansi", true, "Remove terminal escape codes from tool output in text and JSON results (HTML renders them as colors)")
+	fs.StringVar(&config.Color, "color", commands.ColorAuto, "Color human output: auto (when stdout is a terminal and NO_COLOR is unset), always, or never")
+	healthWeights := fs.String("health-weights", "", "Health score weight overrides, e.g. errors=40,interruptions=10 (keys: errors, tool_failures, retries, interruptions)")
+	fs.BoolVar(&config.Recursive, "recursive", false, "Also find session files in project subdirectories (subagents/ excluded)")
+	fs.IntVar(&config.DiscoveryDepth, "discovery-depth", service.DefaultDiscoveryDepth, "Subdirectory levels searched with --recursive")
+	fs.IntVar(&config.MaxSubagentFiles, "max-subagent-files", 0, "Load at most this many subagent files per session, most recently modified first (0 for unlimited)")
+	fs.Int64Var(&config.MaxSubagentBytes, "max-subagent-bytes", 0, "Load at most this many bytes of subagent files per session, most recently modified first (0 for unlimited)")
+	fs.IntVar(&config.HeavyReadBytes, "heavy-read-bytes", service.DefaultHeavyReadBytes, "Read results at or above this many bytes count as heavy reads")
+	fs.StringVar(&config.NoiseTools, "noise-tools", "", "Comma-separated tools (e.g. TodoWrite) left out of summary and tool-stats counts and reported separately")
 
 	// Command-specific flags
 	cmd.Setup(fs)
@@ -117,6 +143,32 @@ func runSubcommandMode() error {
 		debugpkg.Enabled = true
 	}
 
+	loc, err := commands.LoadLocation(config.Timezone)
+	if err != nil {
+		return err
+	}
+	config.Location = loc
+
+	color, err := commands.ResolveColor(config.Color, os.Stdout)
+	if err != nil {
+		return err
+	}
+	config.ColorOutput = color
+
+	config.HealthWeights, err = service.ParseHealthWeights(*healthWeights)
+	if err != nil {
+		return err
+	}
+
+	if fileConfigErr != nil {
+		if cmd.Name() == "recent" {
+			return fileConfigErr
+		}
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", fileConfigErr)
+	} else {
+		config.RecentLimit = fileConfig.RecentLimit
+	}
+
 	// Create context
 	ctx := commands.NewContext(&config)
 
@@ -127,13 +179,14 @@ func runSubcommandMode() error {
 // runLegacyMode handles the original -input/-output flag-based CLI.
 func runLegacyMode() {
 	var inputFile, outputFile string
-	var openBrowser, showVersion, showContextSize bool
+	var openBrowser, showVersion, showContextSize, showSummary bool
 	flag.StringVar(&inputFile, "input", "", "Input JSONL file path")
 	flag.StringVar(&outputFile, "output", "", "Output HTML file path (optional)")
 	flag.BoolVar(&openBrowser, "open", false, "Open the generated HTML file in browser")
 	flag.BoolVar(&debugpkg.Enabled, "debug", false, "Enable debug logging")
 	flag.BoolVar(&showVersion, "version", false, "Show version information")
 	flag.BoolVar(&showContextSize, "contextsize", false, "Print the conversation size from the last assistant message")
+	flag.BoolVar(&showSummary, "summary", false, "Print the session summary as JSON")
 	flag.Parse()
 
 	if showVersion {
@@ -205,6 +258,20 @@ func runLegacyMode() {
 		os.Exit(0)
 	}
 
+	// If -summary flag is set, print the session summary as JSON and exit
+	if showSummary {
+		baseName := strings.TrimSuffix(filepath.Base(inputFile), filepath.Ext(inputFile))
+		summary := service.NewSessionService(nil).SummarizeEntries(baseName, processed)
+
+		data, err := json.MarshalIndent(summary, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding summary: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(data))
+		os.Exit(0)
+	}
+
 	err = renderer.GenerateHTML(processed, outputFile, debugpkg.Enabled)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error generating HTML: %v\n", err)
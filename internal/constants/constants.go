@@ -42,6 +42,9 @@ const (
 	EntryTypeToolCall   = "tool_call"
 	EntryTypeToolResult = "tool_result"
 	EntryTypeSummary    = "summary"
+
+	// Timeline entry type for tool calls issued together in one turn
+	EntryTypeParallelTools = "parallel_tools"
 	
 	// Message roles
 	RoleUser      = "user"
@@ -52,6 +55,10 @@ const (
 	ContentTypeText       = "text"
 	ContentTypeToolUse    = "tool_use"
 	ContentTypeToolResult = "tool_result"
+
+	// Thinking content types
+	ContentTypeThinking         = "thinking"
+	ContentTypeRedactedThinking = "redacted_thinking"
 )
 
 // Tool names
@@ -60,13 +67,17 @@ const (
 	TaskToolName = "Task"
 	
 	// Common tool names
-	ToolNameBash      = "Bash"
-	ToolNameWebSearch = "WebSearch"
-	ToolNameRead      = "Read"
-	ToolNameEdit      = "Edit"
-	ToolNameMultiEdit = "MultiEdit"
-	ToolNameWrite     = "Write"
-	ToolNameTodoWrite = "TodoWrite"
+	ToolNameBash         = "Bash"
+	ToolNameWebSearch    = "WebSearch"
+	ToolNameRead         = "Read"
+	ToolNameEdit         = "Edit"
+	ToolNameMultiEdit    = "MultiEdit"
+	ToolNameWrite        = "Write"
+	ToolNameTodoWrite    = "TodoWrite"
+	ToolNameGrep         = "Grep"
+	ToolNameGlob         = "Glob"
+	ToolNameWebFetch     = "WebFetch"
+	ToolNameNotebookEdit = "NotebookEdit"
 )
 
 // Version information
@@ -92,6 +103,9 @@ const (
 	// TempFileNameFormat is the format string for temporary HTML files
 	TempFileNameFormat = "cclog-%s-%s.html"
 	
+	// TempNotebookNameFormat is the format string for temporary notebook files
+	TempNotebookNameFormat = "cclog-%s-%s.ipynb"
+	
 	// HTMLFileExtension is the file extension for HTML files
 	HTMLFileExtension = ".html"
 	
@@ -124,6 +138,9 @@ const (
 	
 	// UserInterruptionPattern identifies interrupted requests
 	UserInterruptionPattern = "request interrupted by user"
+
+	// UserRejectionPattern identifies tool calls the user declined to run
+	UserRejectionPattern = "the user doesn't want to proceed with this tool use"
 	
 	// Command XML tags
 	CommandNameOpenTag    = "<command-name>"
@@ -167,4 +184,76 @@ const (
 	TypeMessage    = "message"
 	TypeToolUse    = "tool_use"
 	TypeToolResult = "tool_result"
-)
\ No newline at end of file
+	TypeSystem     = "system"
+	TypeAttachment = "attachment"
+)
+
+// System entry subtypes
+const (
+	// SubtypeCompactBoundary marks where the conversation was compacted
+	SubtypeCompactBoundary = "compact_boundary"
+)
+
+// Hook outcomes reported in HookEvent.Outcome
+const (
+	HookOutcomeSuccess = "success"
+	HookOutcomeError   = "error"
+	HookOutcomeBlocked = "blocked"
+)
+
+// Context windows
+const (
+	// DefaultContextWindow is the context window of Claude models in tokens
+	DefaultContextWindow = 200000
+
+	// ContextBudgetWarnPercent is the share of the context window, in percent,
+	// at which a context budget is flagged as running low
+	ContextBudgetWarnPercent = 80.0
+)
+
+// ModelContextWindows lists context window sizes by model ID fragment. More
+// specific fragments come first so "sonnet-4-5[1m]" is not sized as "sonnet".
+var ModelContextWindows = []struct {
+	Match  string
+	Tokens int
+}{
+	{"[1m]", 1000000},
+	{"opus", DefaultContextWindow},
+	{"sonnet", DefaultContextWindow},
+	{"haiku", DefaultContextWindow},
+}
+
+// Tool access classes
+const (
+	// ToolAccessRead marks tools that only inspect state
+	ToolAccessRead = "read"
+
+	// ToolAccessWrite marks tools that can modify files or run commands
+	ToolAccessWrite = "write"
+
+	// ToolAccessUnknown marks tools not listed in ToolAccess, including MCP tools
+	ToolAccessUnknown = "unknown"
+)
+
+// ToolAccess classifies built-in tools as read-only or state-modifying. Bash
+// counts as a write since a command can change anything. Tools not listed,
+// such as MCP tools or Task, are ToolAccessUnknown rather than guessed.
+var ToolAccess = map[string]string{
+	ToolNameRead:           ToolAccessRead,
+	ToolNameGrep:           ToolAccessRead,
+	ToolNameGlob:           ToolAccessRead,
+	"LS":                   ToolAccessRead,
+	"NotebookRead":         ToolAccessRead,
+	ToolNameWebFetch:       ToolAccessRead,
+	ToolNameWebSearch:      ToolAccessRead,
+	"BashOutput":           ToolAccessRead,
+	"ListMcpResourcesTool": ToolAccessRead,
+	"ReadMcpResourceTool":  ToolAccessRead,
+	ToolNameBash:           ToolAccessWrite,
+	ToolNameEdit:           ToolAccessWrite,
+	ToolNameMultiEdit:      ToolAccessWrite,
+	ToolNameWrite:          ToolAccessWrite,
+	ToolNameNotebookEdit:   ToolAccessWrite,
+	"KillShell":            ToolAccessWrite,
+	"KillBash":             ToolAccessWrite,
+}
@@ -1,4 +1,43 @@
 package debug
 
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
 // Enabled indicates whether debug logging is enabled
 var Enabled bool
+
+// Event logs a structured line of key=value pairs when debug logging is
+// enabled, e.g. "event=parse file=abc.jsonl entries=120 ms=3.214". fields
+// alternate keys and values; durations are written as milliseconds and
+// values containing spaces are quoted.
+func Event(name string, fields ...interface{}) {
+	if !Enabled {
+		return
+	}
+
+	var b strings.Builder
+	b.WriteString("event=")
+	b.WriteString(name)
+	for i := 0; i+1 < len(fields); i += 2 {
+		fmt.Fprintf(&b, " %v=%s", fields[i], formatValue(fields[i+1]))
+	}
+	log.Print(b.String())
+}
+
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case time.Duration:
+		return strconv.FormatFloat(float64(v)/float64(time.Millisecond), 'f', 3, 64)
+	case string:
+		if v == "" || strings.ContainsAny(v, " \t\"=") {
+			return strconv.Quote(v)
+		}
+		return v
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,73 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// ErrNotInstalled is returned when no git executable is on the PATH.
+var ErrNotInstalled = errors.New("git is not installed")
+
+// logFormat separates commit fields with the ASCII unit separator.
+const logFormat = "%H%x1f%an%x1f%aI%x1f%s"
+
+// Log returns the commits on branch in the repository containing dir whose
+// author date falls within [since, until], newest first. An empty branch
+// means HEAD. Branch names come from session logs, so one that git would read
+// as an option is rejected.
+func Log(dir, branch string, since, until time.Time) ([]models.GitCommit, error) {
+	if strings.HasPrefix(branch, "-") {
+		return nil, fmt.Errorf("invalid branch name: %q", branch)
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, ErrNotInstalled
+	}
+
+	ref := branch
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	cmd := exec.Command("git", "-C", dir, "log", ref,
+		"--since="+since.Format(time.RFC3339),
+		"--until="+until.Format(time.RFC3339),
+		"--format="+logFormat, "--")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("git log in %s: %s", dir, msg)
+		}
+		return nil, fmt.Errorf("git log in %s: %w", dir, err)
+	}
+
+	return parseLog(string(output)), nil
+}
+
+// parseLog parses git log output written with logFormat.
+func parseLog(output string) []models.GitCommit {
+	var commits []models.GitCommit
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, "\x1f")
+		if len(fields) != 4 {
+			continue
+		}
+		commit := models.GitCommit{
+			Hash:    fields[0],
+			Author:  fields[1],
+			Subject: fields[3],
+		}
+		if t, err := time.Parse(time.RFC3339, fields[2]); err == nil {
+			commit.Timestamp = t
+		}
+		commits = append(commits, commit)
+	}
+	return commits
+}
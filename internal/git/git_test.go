@@ -0,0 +1,40 @@
+package git
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLog(t *testing.T) {
+	output := "abc123\x1fAda\x1f2024-01-01T10:00:30Z\x1fFix parser\n" +
+		"def456\x1fGrace\x1fnot-a-date\x1fSubject with \x1e odd bytes\n" +
+		"malformed line\n"
+
+	commits := parseLog(output)
+	require.Len(t, commits, 2)
+
+	assert.Equal(t, "abc123", commits[0].Hash)
+	assert.Equal(t, "Ada", commits[0].Author)
+	assert.Equal(t, "Fix parser", commits[0].Subject)
+	assert.True(t, commits[0].Timestamp.Equal(time.Date(2024, 1, 1, 10, 0, 30, 0, time.UTC)))
+
+	assert.Equal(t, "def456", commits[1].Hash)
+	assert.True(t, commits[1].Timestamp.IsZero())
+}
+
+func TestParseLog_Empty(t *testing.T) {
+	assert.Empty(t, parseLog(""))
+}
+
+func TestLog_RejectsOptionBranch(t *testing.T) {
+	dir := t.TempDir()
+	target := dir + "/written"
+
+	_, err := Log(dir, "--output="+target, time.Now().Add(-time.Hour), time.Now())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid branch name")
+	assert.NoFileExists(t, target)
+}
@@ -0,0 +1,68 @@
+package mcp
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// DryRunResult describes the files a tool would write, without creating anything.
+type DryRunResult struct {
+	DryRun bool           `json:"dry_run"`
+	Files  []PlannedWrite `json:"files"`
+	Data   interface{}    `json:"data,omitempty"`
+}
+
+// PlannedWrite describes a single file a tool would write.
+type PlannedWrite struct {
+	Path               string `json:"path"`
+	Bytes              int    `json:"bytes,omitempty"`
+	CreatesDirectories bool   `json:"creates_directories,omitempty"`
+	Overwrites         bool   `json:"overwrites,omitempty"`
+}
+
+// planWrite validates that path could be written and reports what writing it
+// would do. It checks no more than the real write does, so a dry run fails only
+// where the write would. Nothing is created on disk.
+func planWrite(path string, size int) (PlannedWrite, error) {
+	plan := PlannedWrite{Path: path, Bytes: size}
+
+	if path == "" {
+		return plan, fmt.Errorf("output_path is empty")
+	}
+
+	if info, err := os.Stat(path); err == nil {
+		if info.IsDir() {
+			return plan, fmt.Errorf("output_path %s is a directory", path)
+		}
+		plan.Overwrites = true
+	}
+
+	// Walk up to the nearest existing ancestor; it must be a writable directory
+	dir := filepath.Dir(path)
+	for {
+		info, err := os.Stat(dir)
+		if err == nil {
+			if !info.IsDir() {
+				return plan, fmt.Errorf("%s is not a directory", dir)
+			}
+			// Owner write bit only; ownership and ACLs are not checked
+			if info.Mode().Perm()&0200 == 0 {
+				return plan, fmt.Errorf("directory %s is not writable", dir)
+			}
+			break
+		}
+		if !os.IsNotExist(err) {
+			return plan, fmt.Errorf("failed to check %s: %w", dir, err)
+		}
+
+		plan.CreatesDirectories = true
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	return plan, nil
+}
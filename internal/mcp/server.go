@@ -8,6 +8,8 @@ import (
 	"log"
 	"os"
 	"sync"
+
+	"github.com/brads3290/cclogviewer/internal/utils"
 )
 
 const (
@@ -15,6 +17,9 @@ const (
 	ProtocolVersion = "2024-11-05"
 	ServerName      = "cclogviewer-mcp"
 	ServerVersion   = "1.0.0"
+
+	// DefaultMaxResponseBytes caps the text of a single tool response.
+	DefaultMaxResponseBytes = 4 * 1024 * 1024
 )
 
 // JSONRPCRequest represents a JSON-RPC 2.0 request.
@@ -71,6 +76,8 @@ type Server struct {
 	input    io.Reader
 	output   io.Writer
 	debug    bool
+	// maxResponseBytes truncates tool responses above this size; <= 0 disables the cap
+	maxResponseBytes int
 }
 
 // NewServer creates a new MCP server.
@@ -80,9 +87,17 @@ func NewServer() *Server {
 		input:  os.Stdin,
 		output: os.Stdout,
 		debug:  os.Getenv("DEBUG") != "",
+
+		maxResponseBytes: DefaultMaxResponseBytes,
 	}
 }
 
+// SetMaxResponseBytes sets the maximum size of a tool response. Values <= 0
+// disable truncation.
+func (s *Server) SetMaxResponseBytes(n int) {
+	s.maxResponseBytes = n
+}
+
 // RegisterTool registers a tool with the server.
 func (s *Server) RegisterTool(tool Tool) {
 	s.mu.Lock()
@@ -211,7 +226,7 @@ func (s *Server) handleToolsCall(req *JSONRPCRequest) *JSONRPCResponse {
 	content := []map[string]interface{}{
 		{
 			"type": "text",
-			"text": formatResult(result),
+			"text": s.limitResponse(formatResult(result)),
 		},
 	}
 
@@ -228,6 +243,20 @@ func formatResult(result interface{}) string {
 	return string(bytes)
 }
 
+// limitResponse truncates text that exceeds maxResponseBytes and appends a note
+// explaining how to retrieve the full result.
+func (s *Server) limitResponse(text string) string {
+	if s.maxResponseBytes <= 0 || len(text) <= s.maxResponseBytes {
+		return text
+	}
+
+	shown := utils.CutAtRune(text, s.maxResponseBytes)
+
+	return shown + fmt.Sprintf("\n\n[Response truncated: %d of %d bytes shown. "+
+		"Pass output_path to save the full result to a file, or narrow the request with limit or filter arguments.]",
+		len(shown), len(text))
+}
+
 func (s *Server) successResponse(id interface{}, result interface{}) *JSONRPCResponse {
 	return &JSONRPCResponse{
 		JSONRPC: "2.0",
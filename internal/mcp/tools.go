@@ -19,19 +19,28 @@ type SaveResult struct {
 }
 
 // saveToFile saves data as JSON to the specified path, creating directories if needed.
-func saveToFile(data interface{}, outputPath string) (*SaveResult, error) {
-	// Create parent directories if they don't exist
-	dir := filepath.Dir(outputPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return nil, fmt.Errorf("failed to create directory %s: %w", dir, err)
-	}
-
+// With dryRun set it validates the path and returns a DryRunResult instead of writing.
+func saveToFile(data interface{}, outputPath string, dryRun bool) (interface{}, error) {
 	// Marshal data to JSON
 	jsonData, err := json.MarshalIndent(data, "", "  ")
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal data: %w", err)
 	}
 
+	if dryRun {
+		plan, err := planWrite(outputPath, len(jsonData))
+		if err != nil {
+			return nil, err
+		}
+		return &DryRunResult{DryRun: true, Files: []PlannedWrite{plan}, Data: data}, nil
+	}
+
+	// Create parent directories if they don't exist
+	dir := filepath.Dir(outputPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
 	// Write to file
 	if err := os.WriteFile(outputPath, jsonData, 0644); err != nil {
 		return nil, fmt.Errorf("failed to write file: %w", err)
@@ -43,6 +52,11 @@ func saveToFile(data interface{}, outputPath string) (*SaveResult, error) {
 	}, nil
 }
 
+// countResult is the minimal response returned by tools called with count_only.
+func countResult(n int) map[string]int {
+	return map[string]int{"count": n}
+}
+
 // Services is an alias for service.Services for backward compatibility.
 type Services = service.Services
 
@@ -135,6 +149,11 @@ func (t *ListSessionsTool) InputSchema() json.RawMessage {
 				"description": "Extract and include subagent_types used in each session",
 				"default": false
 			},
+			"include_empty": {
+				"type": "boolean",
+				"description": "Include sessions with no usable entries (status \"empty\")",
+				"default": false
+			},
 			"limit": {
 				"type": "integer",
 				"description": "Maximum number of sessions to return",
@@ -161,12 +180,17 @@ func (t *ListSessionsTool) Execute(args map[string]interface{}) (interface{}, er
 		includeAgentTypes = b
 	}
 
+	includeEmpty := false
+	if b, ok := args["include_empty"].(bool); ok {
+		includeEmpty = b
+	}
+
 	limit := 50
 	if l, ok := args["limit"].(float64); ok {
 		limit = int(l)
 	}
 
-	sessions, err := t.services.Session.ListSessions(project, days, includeAgentTypes, limit)
+	sessions, err := t.services.Session.ListSessions(project, days, includeAgentTypes, includeEmpty, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list sessions: %w", err)
 	}
@@ -216,9 +240,38 @@ func (t *GetSessionLogsTool) InputSchema() json.RawMessage {
 				"description": "Include sidechain (agent) conversations",
 				"default": true
 			},
+			"include_tool_output": {
+				"type": "boolean",
+				"description": "Include each tool call's output (increases payload size)",
+				"default": false
+			},
+			"tool_output_limit": {
+				"type": "integer",
+				"description": "Maximum bytes of each tool output to include (0 for no limit)",
+				"default": 2000
+			},
+			"include_raw_usage": {
+				"type": "boolean",
+				"description": "Attach each message's original usage object as raw_usage, including fields not covered by token stats",
+				"default": false
+			},
+			"include_hashes": {
+				"type": "boolean",
+				"description": "Attach a stable content_hash to each entry for spotting repeated content",
+				"default": false
+			},
+			"after_uuid": {
+				"type": "string",
+				"description": "Only return entries after the entry with this UUID, e.g. the last one already processed. Errors if no entry has it."
+			},
 			"output_path": {
 				"type": "string",
 				"description": "File path to save the logs as JSON. If provided, creates parent directories automatically."
+			},
+			"dry_run": {
+				"type": "boolean",
+				"description": "Validate output_path and report what would be written without creating any files",
+				"default": false
 			}
 		}
 	}`)
@@ -232,16 +285,25 @@ func (t *GetSessionLogsTool) Execute(args map[string]interface{}) (interface{},
 		return nil, fmt.Errorf("either session_id or file_path is required")
 	}
 
-	includeSidechains := getBool(args, "include_sidechains", true)
+	opts := service.LogsOptions{
+		IncludeSidechains: getBool(args, "include_sidechains", true),
+		IncludeToolOutput: getBool(args, "include_tool_output", false),
+		ToolOutputLimit:   service.DefaultToolOutputLimit,
+		IncludeRawUsage:   getBool(args, "include_raw_usage", false),
+		IncludeHashes:     getBool(args, "include_hashes", false),
+	}
+	if l, ok := args["tool_output_limit"].(float64); ok {
+		opts.ToolOutputLimit = int(l)
+	}
 
 	var logs *models.SessionLogs
 	var err error
 
 	if filePath != "" {
-		logs, err = t.services.Session.GetSessionLogsFromFile(filePath, includeSidechains)
+		logs, err = t.services.Session.GetSessionLogsFromFile(filePath, opts)
 	} else {
 		project := getString(args, "project")
-		logs, err = t.services.Session.GetSessionLogs(sessionID, project, includeSidechains)
+		logs, err = t.services.Session.GetSessionLogs(sessionID, project, opts)
 	}
 
 	if err != nil {
@@ -252,10 +314,16 @@ func (t *GetSessionLogsTool) Execute(args map[string]interface{}) (interface{},
 		return nil, fmt.Errorf("session not found: %s", sessionID)
 	}
 
+	if afterUUID := getString(args, "after_uuid"); afterUUID != "" {
+		if err := service.FilterLogsAfterUUID(logs, afterUUID); err != nil {
+			return nil, err
+		}
+	}
+
 	// Save to file if output_path is provided
 	outputPath := getString(args, "output_path")
 	if outputPath != "" {
-		return saveToFile(logs, outputPath)
+		return saveToFile(logs, outputPath, getBool(args, "dry_run", false))
 	}
 
 	return logs, nil
@@ -359,6 +427,17 @@ func (t *GetAgentSessionsTool) InputSchema() json.RawMessage {
 				"type": "integer",
 				"description": "Maximum sessions to return",
 				"default": 20
+			},
+			"max_concurrency": {
+				"type": "integer",
+				"description": "Maximum projects to scan in parallel",
+				"default": 4
+			},
+			"sort": {
+				"type": "string",
+				"enum": ["project", "time"],
+				"description": "Result order: project (by project name, newest session first; default) or time (newest session first across projects)",
+				"default": "project"
 			}
 		},
 		"required": ["agent_type"]
@@ -383,15 +462,76 @@ func (t *GetAgentSessionsTool) Execute(args map[string]interface{}) (interface{}
 		limit = int(l)
 	}
 
-	sessions, err := t.services.Session.FindSessionsByAgentType(agentType, project, days, limit)
+	maxConcurrency := getInt(args, "max_concurrency")
+
+	sessions, report, err := t.services.Session.FindSessionsByAgentType(agentType, project, days, limit, maxConcurrency, getString(args, "sort"), nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find agent sessions: %w", err)
 	}
 
 	return map[string]interface{}{
-		"agent_type": agentType,
-		"sessions":   sessions,
-		"count":      len(sessions),
+		"agent_type":       agentType,
+		"sessions":         sessions,
+		"count":            len(sessions),
+		"sessions_scanned": report.SessionsScanned,
+		"failures":         report.Failures,
+	}, nil
+}
+
+// GetProjectAgentTypesTool implements the get_project_agent_types tool.
+type GetProjectAgentTypesTool struct {
+	services *Services
+}
+
+func NewGetProjectAgentTypesTool(services *Services) *GetProjectAgentTypesTool {
+	return &GetProjectAgentTypesTool{services: services}
+}
+
+func (t *GetProjectAgentTypesTool) Name() string {
+	return "get_project_agent_types"
+}
+
+func (t *GetProjectAgentTypesTool) Description() string {
+	return "List the subagent types used in each project, with the number of sessions that used them"
+}
+
+func (t *GetProjectAgentTypesTool) InputSchema() json.RawMessage {
+	return json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"project": {
+				"type": "string",
+				"description": "Limit to a specific project (all projects if omitted)"
+			},
+			"days": {
+				"type": "integer",
+				"description": "Only include sessions from the last N days"
+			},
+			"sessions_per_project": {
+				"type": "integer",
+				"description": "Maximum recent sessions to scan per project",
+				"default": 50
+			}
+		}
+	}`)
+}
+
+func (t *GetProjectAgentTypesTool) Execute(args map[string]interface{}) (interface{}, error) {
+	project := getString(args, "project")
+	days := getInt(args, "days")
+	sessionLimit := getInt(args, "sessions_per_project")
+	if sessionLimit == 0 {
+		sessionLimit = 50
+	}
+
+	agentTypes, err := t.services.Session.ProjectAgentTypes(project, days, sessionLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project agent types: %w", err)
+	}
+
+	return map[string]interface{}{
+		"projects": agentTypes,
+		"count":    len(agentTypes),
 	}, nil
 }
 
@@ -437,6 +577,14 @@ func (t *SearchLogsTool) InputSchema() json.RawMessage {
 				"type": "integer",
 				"description": "Only search sessions from the last N days"
 			},
+			"session_id": {
+				"type": "string",
+				"description": "Search only this session (project and days are ignored)"
+			},
+			"file_path": {
+				"type": "string",
+				"description": "Search only this JSONL log file (project and days are ignored)"
+			},
 			"include_sidechains": {
 				"type": "boolean",
 				"description": "Search in sidechain conversations too",
@@ -446,6 +594,31 @@ func (t *SearchLogsTool) InputSchema() json.RawMessage {
 				"type": "integer",
 				"description": "Maximum results to return",
 				"default": 50
+			},
+			"count_only": {
+				"type": "boolean",
+				"description": "Return only {\"count\": N}, the number of matches (limit is ignored)",
+				"default": false
+			},
+			"sort": {
+				"type": "string",
+				"enum": ["project", "time"],
+				"description": "Result order: project (by project name, newest session first; default) or time (newest session first across projects)",
+				"default": "project"
+			},
+			"min_content_len": {
+				"type": "integer",
+				"description": "Skip entries whose text content is shorter than this many characters"
+			},
+			"whole_word": {
+				"type": "boolean",
+				"description": "Match the query only as a whole word (Unicode-aware boundaries)",
+				"default": false
+			},
+			"full_content": {
+				"type": "boolean",
+				"description": "Include each match's full text and tool input/output, not just a snippet. Results get much larger; keep limit low",
+				"default": false
 			}
 		}
 	}`)
@@ -460,6 +633,13 @@ func (t *SearchLogsTool) Execute(args map[string]interface{}) (interface{}, erro
 		Days:              getInt(args, "days"),
 		IncludeSidechains: getBool(args, "include_sidechains", true),
 		Limit:             getInt(args, "limit"),
+		SessionID:         getString(args, "session_id"),
+		FilePath:          getString(args, "file_path"),
+		CountOnly:         getBool(args, "count_only", false),
+		Sort:              getString(args, "sort"),
+		MinContentLen:     getInt(args, "min_content_len"),
+		WholeWord:         getBool(args, "whole_word", false),
+		FullContent:       getBool(args, "full_content", false),
 	}
 
 	if criteria.Limit == 0 {
@@ -471,6 +651,10 @@ func (t *SearchLogsTool) Execute(args map[string]interface{}) (interface{}, erro
 		return nil, fmt.Errorf("search failed: %w", err)
 	}
 
+	if criteria.CountOnly {
+		return countResult(results.TotalMatches), nil
+	}
+
 	return results, nil
 }
 
@@ -515,6 +699,16 @@ func (t *GenerateHTMLTool) InputSchema() json.RawMessage {
 				"type": "boolean",
 				"description": "Open the generated HTML file in browser (default: true when output_path not specified)",
 				"default": false
+			},
+			"include_preview": {
+				"type": "boolean",
+				"description": "Include a short text preview (first user message, token totals, entry count) in the result",
+				"default": false
+			},
+			"dry_run": {
+				"type": "boolean",
+				"description": "Validate output_path and report the file that would be written without generating it",
+				"default": false
 			}
 		}
 	}`)
@@ -535,9 +729,15 @@ func (t *GenerateHTMLTool) Execute(args map[string]interface{}) (interface{}, er
 		openBrowser = b
 	}
 
+	includePreview := getBool(args, "include_preview", false)
+
+	if getBool(args, "dry_run", false) {
+		return planHTMLOutput(filePath, outputPath)
+	}
+
 	// If file_path is provided, use it directly
 	if filePath != "" {
-		result, err := t.services.Session.GenerateHTMLFromFile(filePath, outputPath, openBrowser)
+		result, err := t.services.Session.GenerateHTMLFromFile(filePath, outputPath, openBrowser, includePreview)
 		if err != nil {
 			return nil, fmt.Errorf("failed to generate HTML: %w", err)
 		}
@@ -546,7 +746,7 @@ func (t *GenerateHTMLTool) Execute(args map[string]interface{}) (interface{}, er
 
 	// Otherwise use session_id lookup
 	project, _ := args["project"].(string)
-	result, err := t.services.Session.GenerateSessionHTML(sessionID, project, outputPath, openBrowser)
+	result, err := t.services.Session.GenerateSessionHTML(sessionID, project, outputPath, openBrowser, includePreview)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate HTML: %w", err)
 	}
@@ -554,6 +754,27 @@ func (t *GenerateHTMLTool) Execute(args map[string]interface{}) (interface{}, er
 	return result, nil
 }
 
+// planHTMLOutput validates the input file and output path for generate_html without rendering.
+// When no output_path is given the HTML goes to a temp file, so there is nothing to validate.
+func planHTMLOutput(filePath, outputPath string) (*DryRunResult, error) {
+	if filePath != "" {
+		if _, err := os.Stat(filePath); err != nil {
+			return nil, fmt.Errorf("file not found: %s", filePath)
+		}
+	}
+
+	result := &DryRunResult{DryRun: true, Files: []PlannedWrite{}}
+	if outputPath != "" {
+		plan, err := planWrite(outputPath, 0)
+		if err != nil {
+			return nil, err
+		}
+		result.Files = append(result.Files, plan)
+	}
+
+	return result, nil
+}
+
 // GetSessionSummaryTool implements the get_session_summary tool.
 type GetSessionSummaryTool struct {
 	services *Services
@@ -596,9 +817,19 @@ func (t *GetSessionSummaryTool) InputSchema() json.RawMessage {
 				"description": "Include sidechain (agent) conversations in analysis",
 				"default": true
 			},
+			"exclude_empty": {
+				"type": "boolean",
+				"description": "Leave empty assistant responses out of assistant_messages and message_count",
+				"default": false
+			},
 			"output_path": {
 				"type": "string",
 				"description": "File path to save the summary as JSON. If provided, creates parent directories automatically."
+			},
+			"dry_run": {
+				"type": "boolean",
+				"description": "Validate output_path and report what would be written without creating any files",
+				"default": false
 			}
 		}
 	}`)
@@ -622,7 +853,7 @@ func (t *GetSessionSummaryTool) Execute(args map[string]interface{}) (interface{
 	} else {
 		agentID := getString(args, "agent_id")
 		project := getString(args, "project")
-		summary, err = t.services.Session.GetSessionSummary(sessionID, agentID, project, includeSidechains)
+		summary, err = t.services.Session.GetSessionSummary(sessionID, agentID, project, includeSidechains, nil)
 	}
 
 	if err != nil {
@@ -633,10 +864,14 @@ func (t *GetSessionSummaryTool) Execute(args map[string]interface{}) (interface{
 		return nil, fmt.Errorf("session not found: %s", sessionID)
 	}
 
+	if getBool(args, "exclude_empty", false) {
+		t.services.Session.ExcludeEmptyAssistant(summary)
+	}
+
 	// Save to file if output_path is provided
 	outputPath := getString(args, "output_path")
 	if outputPath != "" {
-		return saveToFile(summary, outputPath)
+		return saveToFile(summary, outputPath, getBool(args, "dry_run", false))
 	}
 
 	return summary, nil
@@ -684,9 +919,19 @@ func (t *GetToolUsageStatsTool) InputSchema() json.RawMessage {
 				"description": "Include sidechain (agent) conversations in analysis",
 				"default": true
 			},
+			"count_only": {
+				"type": "boolean",
+				"description": "Return only {\"count\": N}, the total number of tool calls",
+				"default": false
+			},
 			"output_path": {
 				"type": "string",
 				"description": "File path to save the stats as JSON. If provided, creates parent directories automatically."
+			},
+			"dry_run": {
+				"type": "boolean",
+				"description": "Validate output_path and report what would be written without creating any files",
+				"default": false
 			}
 		}
 	}`)
@@ -710,7 +955,7 @@ func (t *GetToolUsageStatsTool) Execute(args map[string]interface{}) (interface{
 	} else {
 		agentID := getString(args, "agent_id")
 		project := getString(args, "project")
-		stats, err = t.services.Session.GetToolUsageStats(sessionID, agentID, project, includeSidechains)
+		stats, err = t.services.Session.GetToolUsageStats(sessionID, agentID, project, includeSidechains, nil)
 	}
 
 	if err != nil {
@@ -721,10 +966,14 @@ func (t *GetToolUsageStatsTool) Execute(args map[string]interface{}) (interface{
 		return nil, fmt.Errorf("session not found: %s", sessionID)
 	}
 
+	if getBool(args, "count_only", false) {
+		return countResult(stats.TotalCalls()), nil
+	}
+
 	// Save to file if output_path is provided
 	outputPath := getString(args, "output_path")
 	if outputPath != "" {
-		return saveToFile(stats, outputPath)
+		return saveToFile(stats, outputPath, getBool(args, "dry_run", false))
 	}
 
 	return stats, nil
@@ -777,9 +1026,19 @@ func (t *GetSessionErrorsTool) InputSchema() json.RawMessage {
 				"description": "Maximum number of errors to return",
 				"default": 20
 			},
+			"count_only": {
+				"type": "boolean",
+				"description": "Return only {\"count\": N}, the total number of errors (limit is ignored)",
+				"default": false
+			},
 			"output_path": {
 				"type": "string",
 				"description": "File path to save the errors as JSON. If provided, creates parent directories automatically."
+			},
+			"dry_run": {
+				"type": "boolean",
+				"description": "Validate output_path and report what would be written without creating any files",
+				"default": false
 			}
 		}
 	}`)
@@ -818,10 +1077,14 @@ func (t *GetSessionErrorsTool) Execute(args map[string]interface{}) (interface{}
 		return nil, fmt.Errorf("session not found: %s", sessionID)
 	}
 
+	if getBool(args, "count_only", false) {
+		return countResult(errors.TotalErrors), nil
+	}
+
 	// Save to file if output_path is provided
 	outputPath := getString(args, "output_path")
 	if outputPath != "" {
-		return saveToFile(errors, outputPath)
+		return saveToFile(errors, outputPath, getBool(args, "dry_run", false))
 	}
 
 	return errors, nil
@@ -874,9 +1137,28 @@ func (t *GetSessionTimelineTool) InputSchema() json.RawMessage {
 				"description": "Maximum number of timeline entries to return",
 				"default": 100
 			},
+			"include_cost": {
+				"type": "boolean",
+				"description": "Attach each step's estimated USD cost (requires model; omitted if the model's rates are unknown)",
+				"default": false
+			},
+			"model": {
+				"type": "string",
+				"description": "Model whose rates are used for include_cost (e.g. claude-sonnet-4-5, opus)"
+			},
+			"include_tokens": {
+				"type": "boolean",
+				"description": "Attach each step's token_breakdown (input, output, cache read, cache creation)",
+				"default": false
+			},
 			"output_path": {
 				"type": "string",
 				"description": "File path to save the timeline as JSON. If provided, creates parent directories automatically."
+			},
+			"dry_run": {
+				"type": "boolean",
+				"description": "Validate output_path and report what would be written without creating any files",
+				"default": false
 			}
 		}
 	}`)
@@ -896,15 +1178,22 @@ func (t *GetSessionTimelineTool) Execute(args map[string]interface{}) (interface
 		limit = 100
 	}
 
+	costModel := ""
+	if getBool(args, "include_cost", false) {
+		costModel = getString(args, "model")
+	}
+
+	includeTokens := getBool(args, "include_tokens", false)
+
 	var timeline *models.SessionTimeline
 	var err error
 
 	if filePath != "" {
-		timeline, err = t.services.Session.GetSessionTimelineFromFile(filePath, includeSidechains, limit)
+		timeline, err = t.services.Session.GetSessionTimelineFromFile(filePath, includeSidechains, limit, costModel, includeTokens)
 	} else {
 		agentID := getString(args, "agent_id")
 		project := getString(args, "project")
-		timeline, err = t.services.Session.GetSessionTimeline(sessionID, agentID, project, includeSidechains, limit)
+		timeline, err = t.services.Session.GetSessionTimeline(sessionID, agentID, project, includeSidechains, limit, costModel, includeTokens)
 	}
 
 	if err != nil {
@@ -918,7 +1207,7 @@ func (t *GetSessionTimelineTool) Execute(args map[string]interface{}) (interface
 	// Save to file if output_path is provided
 	outputPath := getString(args, "output_path")
 	if outputPath != "" {
-		return saveToFile(timeline, outputPath)
+		return saveToFile(timeline, outputPath, getBool(args, "dry_run", false))
 	}
 
 	return timeline, nil
@@ -984,6 +1273,16 @@ func (t *GetSessionStatsTool) InputSchema() json.RawMessage {
 				"type": "boolean",
 				"description": "Open HTML in browser (requires generate_html=true)",
 				"default": false
+			},
+			"link_conversation": {
+				"type": "boolean",
+				"description": "Also generate the full conversation HTML next to the stats HTML and link each tool-sequence tag to its entry (requires generate_html=true)",
+				"default": false
+			},
+			"dry_run": {
+				"type": "boolean",
+				"description": "Validate output_path and report the files that would be written without creating them",
+				"default": false
 			}
 		}
 	}`)
@@ -1011,7 +1310,7 @@ func (t *GetSessionStatsTool) Execute(args map[string]interface{}) (interface{},
 	} else {
 		agentID := getString(args, "agent_id")
 		project := getString(args, "project")
-		stats, err = t.services.Session.GetSessionStats(sessionID, agentID, project, includeSidechains, errorsLimit)
+		stats, err = t.services.Session.GetSessionStats(sessionID, agentID, project, includeSidechains, errorsLimit, nil)
 	}
 
 	if err != nil {
@@ -1027,8 +1326,25 @@ func (t *GetSessionStatsTool) Execute(args map[string]interface{}) (interface{},
 	generateHTML := getBool(args, "generate_html", false)
 	openBrowser := getBool(args, "open_browser", false)
 
+	// The conversation page is rendered from the same source as the stats
+	var conversation func(path string) error
+	if generateHTML && getBool(args, "link_conversation", false) {
+		conversation = func(path string) error {
+			if filePath != "" {
+				_, err := t.services.Session.GenerateHTMLFromFile(filePath, path, false, false)
+				return err
+			}
+			_, err := t.services.Session.GenerateSessionHTML(sessionID, getString(args, "project"), path, false, false)
+			return err
+		}
+	}
+
+	if getBool(args, "dry_run", false) && (outputPath != "" || generateHTML) {
+		return planStatsFiles(stats, outputPath, generateHTML, conversation != nil)
+	}
+
 	if outputPath != "" || generateHTML {
-		files, err := t.saveStatsFiles(stats, outputPath, generateHTML, openBrowser)
+		files, err := t.saveStatsFiles(stats, outputPath, generateHTML, openBrowser, conversation)
 		if err != nil {
 			return nil, fmt.Errorf("failed to save stats files: %w", err)
 		}
@@ -1038,14 +1354,13 @@ func (t *GetSessionStatsTool) Execute(args map[string]interface{}) (interface{},
 	return stats, nil
 }
 
-// saveStatsFiles saves stats to JSON and optionally HTML files.
-func (t *GetSessionStatsTool) saveStatsFiles(stats *models.SessionStats, outputPath string, generateHTML, openBrowser bool) (*models.OutputFiles, error) {
-	// Determine output path
-	if outputPath == "" {
-		outputPath = fmt.Sprintf("/tmp/session-stats-%s", stats.SessionID[:8])
-	}
+// saveStatsFiles saves stats to JSON and optionally HTML files. When
+// conversation is set it renders the full conversation HTML next to the stats
+// HTML, which then links each tool-sequence tag to its entry.
+func (t *GetSessionStatsTool) saveStatsFiles(stats *models.SessionStats, outputPath string, generateHTML, openBrowser bool, conversation func(path string) error) (*models.OutputManifest, error) {
+	outputPath = statsOutputBase(stats, outputPath)
 
-	files := &models.OutputFiles{}
+	files := &models.OutputManifest{}
 
 	// Save JSON
 	jsonPath := outputPath + ".json"
@@ -1057,17 +1372,30 @@ func (t *GetSessionStatsTool) saveStatsFiles(stats *models.SessionStats, outputP
 	if err := writeFile(jsonPath, jsonData); err != nil {
 		return nil, fmt.Errorf("failed to write JSON: %w", err)
 	}
-	files.JSONPath = jsonPath
+	files.AddFile(jsonPath, models.OutputTypeJSON, int64(len(jsonData)))
 
 	// Generate HTML if requested
 	if generateHTML {
 		htmlPath := outputPath + ".html"
-		htmlContent := generateStatsHTML(stats)
+
+		conversationHref := ""
+		if conversation != nil {
+			conversationPath := conversationHTMLPath(outputPath)
+			if err := conversation(conversationPath); err != nil {
+				return nil, fmt.Errorf("failed to write conversation HTML: %w", err)
+			}
+			conversationHref = filepath.Base(conversationPath)
+		}
+
+		htmlContent := generateStatsHTML(stats, conversationHref)
 
 		if err := writeFile(htmlPath, []byte(htmlContent)); err != nil {
 			return nil, fmt.Errorf("failed to write HTML: %w", err)
 		}
-		files.HTMLPath = htmlPath
+		files.AddFile(htmlPath, models.OutputTypeHTML, int64(len(htmlContent)))
+		if conversationHref != "" {
+			service.RecordOutputFile(files, conversationHTMLPath(outputPath), models.OutputTypeHTML)
+		}
 
 		// Open browser if requested
 		if openBrowser {
@@ -1080,8 +1408,65 @@ func (t *GetSessionStatsTool) saveStatsFiles(stats *models.SessionStats, outputP
 	return files, nil
 }
 
-// generateStatsHTML generates HTML visualization of stats.
-func generateStatsHTML(stats *models.SessionStats) string {
+// statsOutputBase returns the base path for stats files, defaulting to a temp location.
+func statsOutputBase(stats *models.SessionStats, outputPath string) string {
+	if outputPath == "" {
+		return fmt.Sprintf("/tmp/session-stats-%s", stats.SessionID[:8])
+	}
+	return outputPath
+}
+
+// conversationHTMLPath returns where the conversation HTML linked from the
+// stats HTML is written, next to the stats files.
+func conversationHTMLPath(outputBase string) string {
+	return outputBase + "-conversation.html"
+}
+
+// planStatsFiles validates the stats output paths and reports what saveStatsFiles would write.
+func planStatsFiles(stats *models.SessionStats, outputPath string, generateHTML, linkConversation bool) (*DryRunResult, error) {
+	outputPath = statsOutputBase(stats, outputPath)
+
+	jsonData, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal stats: %w", err)
+	}
+
+	result := &DryRunResult{DryRun: true}
+	plan, err := planWrite(outputPath+".json", len(jsonData))
+	if err != nil {
+		return nil, err
+	}
+	result.Files = append(result.Files, plan)
+
+	if generateHTML {
+		htmlPath := outputPath + ".html"
+		conversationHref := ""
+		if linkConversation {
+			conversationHref = filepath.Base(conversationHTMLPath(outputPath))
+		}
+		plan, err := planWrite(htmlPath, len(generateStatsHTML(stats, conversationHref)))
+		if err != nil {
+			return nil, err
+		}
+		result.Files = append(result.Files, plan)
+
+		if linkConversation {
+			// The conversation page's size is only known once it is rendered
+			plan, err := planWrite(conversationHTMLPath(outputPath), 0)
+			if err != nil {
+				return nil, err
+			}
+			result.Files = append(result.Files, plan)
+		}
+	}
+
+	return result, nil
+}
+
+// generateStatsHTML generates HTML visualization of stats. When
+// conversationHref names the conversation HTML, each tool-sequence tag links
+// to its entry there; otherwise the tool_use_id is only a tooltip.
+func generateStatsHTML(stats *models.SessionStats, conversationHref string) string {
 	// Simple HTML template for stats visualization
 	html := `<!DOCTYPE html>
 <html lang="en">
@@ -1138,11 +1523,12 @@ func generateStatsHTML(stats *models.SessionStats) string {
             </div>
         </div>
 
+` + agentTokensHTML(stats.Summary.AgentTokens) + `
         <div class="box">
             <h3 class="title is-5">Tool Usage</h3>
             <table class="table is-fullwidth">
                 <thead>
-                    <tr><th>Tool</th><th>Count</th><th>Success</th><th>Failed</th></tr>
+                    <tr><th>Tool</th><th>Count</th><th>%</th><th>Success</th><th>Failed</th></tr>
                 </thead>
                 <tbody>`
 
@@ -1151,9 +1537,10 @@ func generateStatsHTML(stats *models.SessionStats) string {
                     <tr>
                         <td>%s</td>
                         <td>%d</td>
+                        <td>%.1f%%</td>
                         <td class="has-text-success">%d</td>
                         <td class="has-text-danger">%d</td>
-                    </tr>`, tool.Name, tool.Count, tool.Success, tool.Failed)
+                    </tr>`, tool.Name, tool.Count, tool.Percentage, tool.Success, tool.Failed)
 	}
 
 	html += `
@@ -1166,7 +1553,18 @@ func generateStatsHTML(stats *models.SessionStats) string {
             <div class="tool-sequence" style="display: flex; flex-wrap: wrap; gap: 4px;">`
 
 	for _, entry := range stats.ToolStats.ToolSequence {
-		html += fmt.Sprintf(`<span class="tag is-info" title="%s">%s</span>`, entry.ToolUseID, entry.Name)
+		class, title := "is-info", entry.ToolUseID
+		// Calls issued in the same turn are highlighted as a parallel group
+		if entry.GroupSize > 1 {
+			class = "is-warning"
+			title = fmt.Sprintf("%s (parallel group %d, %d calls)", entry.ToolUseID, entry.ParallelGroup, entry.GroupSize)
+		}
+
+		if conversationHref != "" && entry.UUID != "" {
+			html += fmt.Sprintf(`<a class="tag %s" href="%s#entry-%s" title="%s">%s</a>`, class, escapeHTML(conversationHref), entry.UUID, title, entry.Name)
+			continue
+		}
+		html += fmt.Sprintf(`<span class="tag %s" title="%s">%s</span>`, class, title, entry.Name)
 	}
 
 	html += `
@@ -1176,6 +1574,25 @@ func generateStatsHTML(stats *models.SessionStats) string {
         <div class="box">
             <h3 class="title is-5">Errors (` + fmt.Sprintf("%d", stats.Errors.TotalErrors) + ` total)</h3>`
 
+	if rc := stats.Errors.RootCause; rc != nil {
+		toolInfo := ""
+		if rc.ToolName != "" {
+			toolInfo = " - " + rc.ToolName
+		}
+		html += fmt.Sprintf(`
+            <article class="message is-warning">
+                <div class="message-header">
+                    <p>Likely root cause: %s%s <span class="error-entry-index">(entry #%d)</span></p>
+                    <span>%s</span>
+                </div>
+                <div class="message-body">
+                    <div class="error-message">%s</div>
+                    <p class="mt-2 is-size-7">%s</p>
+                    <p class="mt-2"><code class="has-text-grey">UUID: %s</code></p>
+                </div>
+            </article>`, rc.Type, toolInfo, rc.EntryIndex, rc.Timestamp, escapeHTML(rc.Message), escapeHTML(stats.Errors.RootCauseReason), rc.UUID)
+	}
+
 	if len(stats.Errors.Errors) == 0 {
 		html += `<p class="has-text-success">No errors found</p>`
 	} else {
@@ -1214,6 +1631,52 @@ func generateStatsHTML(stats *models.SessionStats) string {
 	return html
 }
 
+// agentTokensHTML renders the per-thread token table for the stats page, or
+// nothing when the session has no subagents.
+func agentTokensHTML(usage []models.AgentTokenUsage) string {
+	if len(usage) < 2 {
+		return ""
+	}
+
+	html := `
+        <div class="box">
+            <h3 class="title is-5">Tokens by Agent</h3>
+            <table class="table is-fullwidth">
+                <thead>
+                    <tr><th>Agent</th><th>Messages</th><th>Input</th><th>Output</th><th>Cache Read</th><th>Cache Creation</th><th>Total</th><th>Share</th></tr>
+                </thead>
+                <tbody>`
+
+	for _, u := range usage {
+		name := "main"
+		if u.AgentID != "" {
+			name = escapeHTML(u.AgentType)
+			if name == "" {
+				name = "subagent"
+			}
+			name += ` <code class="has-text-grey">` + escapeHTML(u.AgentID) + `</code>`
+		}
+		html += fmt.Sprintf(`
+                    <tr>
+                        <td>%s</td>
+                        <td>%d</td>
+                        <td>%d</td>
+                        <td>%d</td>
+                        <td>%d</td>
+                        <td>%d</td>
+                        <td><strong>%d</strong></td>
+                        <td>%.1f%%</td>
+                    </tr>`, name, u.Messages, u.InputTokens, u.OutputTokens, u.CacheRead, u.CacheCreation, u.TotalTokens, u.Percent)
+	}
+
+	html += `
+                </tbody>
+            </table>
+        </div>`
+
+	return html
+}
+
 // escapeHTML escapes HTML special characters.
 func escapeHTML(s string) string {
 	s = strings.ReplaceAll(s, "&", "&amp;")
@@ -1289,9 +1752,19 @@ func (t *GetLogsAroundEntryTool) InputSchema() json.RawMessage {
 				"description": "Include sidechain (agent) conversations",
 				"default": true
 			},
+			"include_hashes": {
+				"type": "boolean",
+				"description": "Attach a stable content_hash to each entry for spotting repeated content",
+				"default": false
+			},
 			"output_path": {
 				"type": "string",
 				"description": "File path to save the logs as JSON. If provided, creates parent directories automatically."
+			},
+			"dry_run": {
+				"type": "boolean",
+				"description": "Validate output_path and report what would be written without creating any files",
+				"default": false
 			}
 		},
 		"required": ["uuid"]
@@ -1316,15 +1789,16 @@ func (t *GetLogsAroundEntryTool) Execute(args map[string]interface{}) (interface
 		offset = 3
 	}
 	includeSidechains := getBool(args, "include_sidechains", true)
+	includeHashes := getBool(args, "include_hashes", false)
 
 	var logs *models.LogsAroundEntry
 	var err error
 
 	if filePath != "" {
-		logs, err = t.services.Session.GetLogsAroundEntryFromFile(filePath, targetUUID, offset, includeSidechains)
+		logs, err = t.services.Session.GetLogsAroundEntryFromFile(filePath, targetUUID, offset, includeSidechains, includeHashes)
 	} else {
 		project := getString(args, "project")
-		logs, err = t.services.Session.GetLogsAroundEntry(sessionID, targetUUID, project, offset, includeSidechains)
+		logs, err = t.services.Session.GetLogsAroundEntry(sessionID, targetUUID, project, offset, includeSidechains, includeHashes)
 	}
 
 	if err != nil {
@@ -1338,12 +1812,231 @@ func (t *GetLogsAroundEntryTool) Execute(args map[string]interface{}) (interface
 	// Save to file if output_path is provided
 	outputPath := getString(args, "output_path")
 	if outputPath != "" {
-		return saveToFile(logs, outputPath)
+		return saveToFile(logs, outputPath, getBool(args, "dry_run", false))
 	}
 
 	return logs, nil
 }
 
+// FindFileEditsTool implements the find_file_edits tool.
+type FindFileEditsTool struct {
+	services *Services
+}
+
+func NewFindFileEditsTool(services *Services) *FindFileEditsTool {
+	return &FindFileEditsTool{services: services}
+}
+
+func (t *FindFileEditsTool) Name() string {
+	return "find_file_edits"
+}
+
+func (t *FindFileEditsTool) Description() string {
+	return "Find Edit, MultiEdit, and Write tool calls on files whose path contains the given text, newest first. Returns the session, entry UUID, timestamp, and a diff snippet for each edit."
+}
+
+func (t *FindFileEditsTool) InputSchema() json.RawMessage {
+	return json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"file": {
+				"type": "string",
+				"description": "Substring of the file path to match (e.g., 'main.go' or 'internal/service/')"
+			},
+			"project": {
+				"type": "string",
+				"description": "Limit search to a specific project"
+			},
+			"days": {
+				"type": "integer",
+				"description": "Only search sessions from the last N days"
+			},
+			"limit": {
+				"type": "integer",
+				"description": "Maximum edits to return",
+				"default": 20
+			}
+		},
+		"required": ["file"]
+	}`)
+}
+
+func (t *FindFileEditsTool) Execute(args map[string]interface{}) (interface{}, error) {
+	file := getString(args, "file")
+	if file == "" {
+		return nil, fmt.Errorf("file is required")
+	}
+
+	limit := getInt(args, "limit")
+	if limit == 0 {
+		limit = 20
+	}
+
+	hits, err := t.services.Session.FindFileEdits(file, getString(args, "project"), getInt(args, "days"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find file edits: %w", err)
+	}
+
+	total := len(hits)
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	return map[string]interface{}{
+		"file":  file,
+		"edits": hits,
+		"count": len(hits),
+		"total": total,
+	}, nil
+}
+
+// ExtractURLsTool implements the extract_urls tool.
+type ExtractURLsTool struct {
+	services *Services
+}
+
+func NewExtractURLsTool(services *Services) *ExtractURLsTool {
+	return &ExtractURLsTool{services: services}
+}
+
+func (t *ExtractURLsTool) Name() string {
+	return "extract_urls"
+}
+
+func (t *ExtractURLsTool) Description() string {
+	return "List URLs fetched or returned by WebFetch and WebSearch in a session, de-duplicated with access counts, the tools that accessed them, and first/last timestamps. Accepts either a session_id or a direct file_path to a JSONL file."
+}
+
+func (t *ExtractURLsTool) InputSchema() json.RawMessage {
+	return json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"session_id": {
+				"type": "string",
+				"description": "Session UUID (use this OR file_path)"
+			},
+			"file_path": {
+				"type": "string",
+				"description": "Direct path to a JSONL log file (use this OR session_id)"
+			},
+			"project": {
+				"type": "string",
+				"description": "Project name/path (optional, only used with session_id)"
+			}
+		}
+	}`)
+}
+
+func (t *ExtractURLsTool) Execute(args map[string]interface{}) (interface{}, error) {
+	sessionID := getString(args, "session_id")
+	filePath := getString(args, "file_path")
+
+	if sessionID == "" && filePath == "" {
+		return nil, fmt.Errorf("either session_id or file_path is required")
+	}
+
+	var urls []models.URLHit
+	var err error
+
+	if filePath != "" {
+		urls, err = t.services.Session.ExtractURLsFromFile(filePath)
+	} else {
+		urls, err = t.services.Session.ExtractURLs(sessionID, getString(args, "project"))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract URLs: %w", err)
+	}
+
+	if urls == nil {
+		return nil, fmt.Errorf("session not found: %s", sessionID)
+	}
+
+	return map[string]interface{}{
+		"urls":  urls,
+		"count": len(urls),
+	}, nil
+}
+
+// ExportSessionTextTool implements the export_session_text tool.
+type ExportSessionTextTool struct {
+	services *Services
+}
+
+func NewExportSessionTextTool(services *Services) *ExportSessionTextTool {
+	return &ExportSessionTextTool{services: services}
+}
+
+func (t *ExportSessionTextTool) Name() string {
+	return "export_session_text"
+}
+
+func (t *ExportSessionTextTool) Description() string {
+	return "Export a compact plain-text transcript of a session (user prompts, tool names, and the assistant's final reply to each prompt, without tool inputs or outputs), trimmed to fit a token budget. Returns the text and its estimated token count. Accepts either a session_id or a direct file_path to a JSONL file."
+}
+
+func (t *ExportSessionTextTool) InputSchema() json.RawMessage {
+	return json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"session_id": {
+				"type": "string",
+				"description": "Session UUID (use this OR file_path)"
+			},
+			"file_path": {
+				"type": "string",
+				"description": "Direct path to a JSONL log file (use this OR session_id)"
+			},
+			"project": {
+				"type": "string",
+				"description": "Project name/path (optional, only used with session_id)"
+			},
+			"max_tokens": {
+				"type": "integer",
+				"description": "Estimated token budget for the export; whole turns are dropped to fit (0 for no limit)",
+				"default": 0
+			},
+			"strategy": {
+				"type": "string",
+				"enum": ["oldest-first", "middle-out"],
+				"description": "Which turns to drop when over max_tokens: oldest-first keeps the latest turns, middle-out keeps the opening and closing turns",
+				"default": "oldest-first"
+			}
+		}
+	}`)
+}
+
+func (t *ExportSessionTextTool) Execute(args map[string]interface{}) (interface{}, error) {
+	sessionID := getString(args, "session_id")
+	filePath := getString(args, "file_path")
+
+	if sessionID == "" && filePath == "" {
+		return nil, fmt.Errorf("either session_id or file_path is required")
+	}
+
+	maxTokens := getInt(args, "max_tokens")
+	strategy := getString(args, "strategy")
+
+	var export *models.SessionTextExport
+	var err error
+
+	if filePath != "" {
+		export, err = t.services.Session.ExportSessionTextFromFile(filePath, maxTokens, strategy)
+	} else {
+		export, err = t.services.Session.ExportSessionText(sessionID, getString(args, "project"), maxTokens, strategy)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to export session text: %w", err)
+	}
+
+	if export == nil {
+		return nil, fmt.Errorf("session not found: %s", sessionID)
+	}
+
+	return export, nil
+}
+
 // Helper functions for argument extraction
 func getString(args map[string]interface{}, key string) string {
 	if v, ok := args[key].(string); ok {
@@ -1373,6 +2066,7 @@ func RegisterAllTools(server *Server, services *Services) {
 	server.RegisterTool(NewGetSessionLogsTool(services))
 	server.RegisterTool(NewListAgentsTool(services))
 	server.RegisterTool(NewGetAgentSessionsTool(services))
+	server.RegisterTool(NewGetProjectAgentTypesTool(services))
 	server.RegisterTool(NewSearchLogsTool(services))
 	server.RegisterTool(NewGenerateHTMLTool(services))
 
@@ -1385,6 +2079,9 @@ func RegisterAllTools(server *Server, services *Services) {
 
 	// Log exploration tools
 	server.RegisterTool(NewGetLogsAroundEntryTool(services))
+	server.RegisterTool(NewFindFileEditsTool(services))
+	server.RegisterTool(NewExtractURLsTool(services))
+	server.RegisterTool(NewExportSessionTextTool(services))
 }
 
 // Ensure all tools implement the Tool interface
@@ -1393,6 +2090,7 @@ var _ Tool = (*ListSessionsTool)(nil)
 var _ Tool = (*GetSessionLogsTool)(nil)
 var _ Tool = (*ListAgentsTool)(nil)
 var _ Tool = (*GetAgentSessionsTool)(nil)
+var _ Tool = (*GetProjectAgentTypesTool)(nil)
 var _ Tool = (*SearchLogsTool)(nil)
 var _ Tool = (*GenerateHTMLTool)(nil)
 var _ Tool = (*GetSessionSummaryTool)(nil)
@@ -1401,6 +2099,9 @@ var _ Tool = (*GetSessionErrorsTool)(nil)
 var _ Tool = (*GetSessionTimelineTool)(nil)
 var _ Tool = (*GetSessionStatsTool)(nil)
 var _ Tool = (*GetLogsAroundEntryTool)(nil)
+var _ Tool = (*FindFileEditsTool)(nil)
+var _ Tool = (*ExtractURLsTool)(nil)
+var _ Tool = (*ExportSessionTextTool)(nil)
 
 // Suppress unused variable warning
 var _ = []models.Project{}
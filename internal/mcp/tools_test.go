@@ -1,8 +1,11 @@
 package mcp
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/brads3290/cclogviewer/internal/models"
@@ -178,6 +181,39 @@ func TestGenerateHTMLTool_Execute_Success(t *testing.T) {
 	assert.Contains(t, string(content), "Hi there!")
 }
 
+func TestGenerateHTMLTool_Execute_IncludePreview(t *testing.T) {
+	claudeDir := setupTestClaudeDir(t)
+	services := NewServices(claudeDir)
+	tool := NewGenerateHTMLTool(services)
+
+	outputPath := filepath.Join(t.TempDir(), "output.html")
+
+	result, err := tool.Execute(map[string]interface{}{
+		"session_id":      "12345678-1234-1234-1234-123456789abc",
+		"project":         "myproject",
+		"output_path":     outputPath,
+		"include_preview": true,
+	})
+	require.NoError(t, err)
+
+	resultMap, ok := result.(*service.HTMLGenerationResult)
+	require.True(t, ok)
+	require.NotNil(t, resultMap.Preview)
+
+	assert.Equal(t, "Hello", resultMap.Preview.FirstUserMessage)
+	assert.Greater(t, resultMap.Preview.EntryCount, 0)
+	assert.NotNil(t, resultMap.Preview.Tokens)
+
+	// Preview is omitted unless requested
+	result, err = tool.Execute(map[string]interface{}{
+		"session_id":  "12345678-1234-1234-1234-123456789abc",
+		"project":     "myproject",
+		"output_path": outputPath,
+	})
+	require.NoError(t, err)
+	assert.Nil(t, result.(*service.HTMLGenerationResult).Preview)
+}
+
 func TestGenerateHTMLTool_Execute_AutoGeneratesOutputPath(t *testing.T) {
 	claudeDir := setupTestClaudeDir(t)
 	services := NewServices(claudeDir)
@@ -223,6 +259,27 @@ func TestRegisterAllTools_IncludesGenerateHTML(t *testing.T) {
 	assert.NotNil(t, server)
 }
 
+func TestServer_TruncatesOversizedResponses(t *testing.T) {
+	server := NewServer()
+	RegisterAllTools(server, NewServices(""))
+	server.SetMaxResponseBytes(200)
+
+	params, err := json.Marshal(map[string]interface{}{
+		"name":      "get_session_logs",
+		"arguments": map[string]interface{}{"file_path": createTestJSONLFile(t)},
+	})
+	require.NoError(t, err)
+
+	resp := server.handleRequest(&JSONRPCRequest{JSONRPC: "2.0", ID: 1, Method: "tools/call", Params: params})
+	require.Nil(t, resp.Error)
+
+	content := resp.Result.(map[string]interface{})["content"].([]map[string]interface{})
+	text := content[0]["text"].(string)
+	assert.True(t, strings.HasPrefix(text, "{"))
+	assert.Contains(t, text, "[Response truncated: 200 of")
+	assert.Contains(t, text, "output_path")
+}
+
 // createTestJSONLFile creates a temporary JSONL file with test session data.
 func createTestJSONLFile(t *testing.T) string {
 	t.Helper()
@@ -279,6 +336,62 @@ func TestGetSessionLogsTool_FilePath(t *testing.T) {
 	})
 }
 
+func TestGetSessionLogsTool_IncludeToolOutput(t *testing.T) {
+	services := NewServices("")
+	tool := NewGetSessionLogsTool(services)
+
+	inputFile := filepath.Join(t.TempDir(), "tools-session.jsonl")
+	sessionContent := `{"uuid":"msg-001","type":"user","timestamp":"2024-01-01T10:00:00Z","message":{"role":"user","content":"List files"}}
+{"uuid":"msg-002","type":"assistant","timestamp":"2024-01-01T10:00:01Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"tool-1","name":"Bash","input":{"command":"ls"}}]}}
+{"uuid":"msg-003","type":"user","timestamp":"2024-01-01T10:00:02Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"tool-1","content":"main.go\nREADME.md"}]}}
+`
+	require.NoError(t, os.WriteFile(inputFile, []byte(sessionContent), 0644))
+
+	findToolCall := func(logs *models.SessionLogs) models.SessionToolCall {
+		for _, e := range logs.Entries {
+			if len(e.ToolCalls) > 0 {
+				return e.ToolCalls[0]
+			}
+		}
+		t.Fatal("no tool call found")
+		return models.SessionToolCall{}
+	}
+
+	t.Run("omitted by default", func(t *testing.T) {
+		result, err := tool.Execute(map[string]interface{}{"file_path": inputFile})
+		require.NoError(t, err)
+
+		call := findToolCall(result.(*models.SessionLogs))
+		assert.Equal(t, "Bash", call.Name)
+		assert.Empty(t, call.Output)
+	})
+
+	t.Run("included when requested", func(t *testing.T) {
+		result, err := tool.Execute(map[string]interface{}{
+			"file_path":           inputFile,
+			"include_tool_output": true,
+		})
+		require.NoError(t, err)
+
+		call := findToolCall(result.(*models.SessionLogs))
+		assert.Contains(t, call.Output, "main.go")
+		assert.False(t, call.OutputTruncated)
+	})
+
+	t.Run("truncated to limit", func(t *testing.T) {
+		result, err := tool.Execute(map[string]interface{}{
+			"file_path":           inputFile,
+			"include_tool_output": true,
+			"tool_output_limit":   float64(4),
+		})
+		require.NoError(t, err)
+
+		call := findToolCall(result.(*models.SessionLogs))
+		assert.Equal(t, "main...", call.Output)
+		assert.True(t, call.OutputTruncated)
+	})
+}
+
 func TestGetSessionSummaryTool_FilePath(t *testing.T) {
 	services := NewServices("")
 	tool := NewGetSessionSummaryTool(services)
@@ -337,6 +450,19 @@ func TestGetToolUsageStatsTool_FilePath(t *testing.T) {
 		require.True(t, ok)
 		assert.Equal(t, "test-session", stats.SessionID)
 	})
+
+	t.Run("count_only returns total tool calls", func(t *testing.T) {
+		inputFile := createTestJSONLFile(t)
+		full, err := tool.Execute(map[string]interface{}{"file_path": inputFile})
+		require.NoError(t, err)
+
+		result, err := tool.Execute(map[string]interface{}{
+			"file_path":  inputFile,
+			"count_only": true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"count": full.(*models.ToolUsageStats).TotalCalls()}, result)
+	})
 }
 
 func TestGetSessionErrorsTool_FilePath(t *testing.T) {
@@ -366,6 +492,19 @@ func TestGetSessionErrorsTool_FilePath(t *testing.T) {
 		require.True(t, ok)
 		assert.Equal(t, "test-session", errors.SessionID)
 	})
+
+	t.Run("count_only returns total errors", func(t *testing.T) {
+		inputFile := createTestJSONLFile(t)
+		full, err := tool.Execute(map[string]interface{}{"file_path": inputFile})
+		require.NoError(t, err)
+
+		result, err := tool.Execute(map[string]interface{}{
+			"file_path":  inputFile,
+			"count_only": true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"count": full.(*models.SessionErrors).TotalErrors}, result)
+	})
 }
 
 func TestGetSessionTimelineTool_FilePath(t *testing.T) {
@@ -396,6 +535,71 @@ func TestGetSessionTimelineTool_FilePath(t *testing.T) {
 		assert.Equal(t, "test-session", timeline.SessionID)
 		assert.Greater(t, len(timeline.Timeline), 0)
 	})
+
+	costFile := filepath.Join(t.TempDir(), "cost-session.jsonl")
+	require.NoError(t, os.WriteFile(costFile, []byte(`{"uuid":"msg-001","type":"user","timestamp":"2024-01-01T10:00:00Z","message":{"role":"user","content":"Hello"}}
+{"uuid":"msg-002","type":"assistant","timestamp":"2024-01-01T10:00:01Z","message":{"role":"assistant","content":[{"type":"text","text":"Hi"}],"usage":{"input_tokens":1000000,"cache_read_input_tokens":1000000}}}
+`), 0644))
+
+	t.Run("include_cost prices steps with a known model", func(t *testing.T) {
+		result, err := tool.Execute(map[string]interface{}{
+			"file_path":    costFile,
+			"include_cost": true,
+			"model":        "claude-sonnet-4-5-20250929",
+		})
+		require.NoError(t, err)
+
+		timeline := result.(*models.SessionTimeline)
+		assert.Equal(t, "claude-sonnet-4-5-20250929", timeline.CostModel)
+		require.Len(t, timeline.Timeline, 2)
+		assert.Zero(t, timeline.Timeline[0].Cost)
+		// $3/M input + $0.30/M cache read, plus a few estimated output tokens
+		assert.InDelta(t, 3.30, timeline.Timeline[1].Cost, 0.001)
+		assert.Equal(t, timeline.Timeline[1].Cost, timeline.TotalCost)
+	})
+
+	t.Run("include_cost is skipped for an unknown model", func(t *testing.T) {
+		result, err := tool.Execute(map[string]interface{}{
+			"file_path":    costFile,
+			"include_cost": true,
+			"model":        "unknown-model",
+		})
+		require.NoError(t, err)
+
+		timeline := result.(*models.SessionTimeline)
+		assert.Empty(t, timeline.CostModel)
+		assert.Zero(t, timeline.TotalCost)
+		for _, e := range timeline.Timeline {
+			assert.Zero(t, e.Cost)
+		}
+	})
+
+	t.Run("include_tokens attaches token breakdowns", func(t *testing.T) {
+		result, err := tool.Execute(map[string]interface{}{
+			"file_path":      costFile,
+			"include_tokens": true,
+		})
+		require.NoError(t, err)
+
+		timeline := result.(*models.SessionTimeline)
+		require.Len(t, timeline.Timeline, 2)
+		breakdown := timeline.Timeline[1].TokenBreakdown
+		require.NotNil(t, breakdown)
+		assert.Equal(t, 1000000, breakdown.Input)
+		assert.Equal(t, 1000000, breakdown.CacheRead)
+		assert.Equal(t, timeline.Timeline[1].Tokens, breakdown.Output)
+	})
+
+	t.Run("token breakdowns are omitted by default", func(t *testing.T) {
+		result, err := tool.Execute(map[string]interface{}{
+			"file_path": costFile,
+		})
+		require.NoError(t, err)
+
+		for _, e := range result.(*models.SessionTimeline).Timeline {
+			assert.Nil(t, e.TokenBreakdown)
+		}
+	})
 }
 
 func TestGetSessionStatsTool_FilePath(t *testing.T) {
@@ -483,3 +687,711 @@ func TestGetLogsAroundEntryTool_FilePath(t *testing.T) {
 		assert.Contains(t, err.Error(), "not found")
 	})
 }
+
+func TestFindFileEditsTool(t *testing.T) {
+	claudeDir := setupTestClaudeDir(t)
+	sessionFile := filepath.Join(claudeDir, "projects", "-Users-test-myproject", "87654321-4321-4321-4321-cba987654321.jsonl")
+	sessionContent := `{"uuid":"edit-001","type":"assistant","timestamp":"2024-01-01T10:00:00Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"tool-1","name":"Edit","input":{"file_path":"/Users/test/myproject/main.go","old_string":"foo","new_string":"bar"}}]}}
+{"uuid":"edit-002","type":"assistant","timestamp":"2024-01-02T10:00:00Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"tool-2","name":"Write","input":{"file_path":"/Users/test/myproject/main.go","content":"package main"}}]}}
+{"uuid":"edit-003","type":"assistant","timestamp":"2024-01-03T10:00:00Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"tool-3","name":"Edit","input":{"file_path":"/Users/test/myproject/other.go","old_string":"a","new_string":"b"}}]}}
+`
+	require.NoError(t, os.WriteFile(sessionFile, []byte(sessionContent), 0644))
+
+	tool := NewFindFileEditsTool(NewServices(claudeDir))
+	assert.Equal(t, "find_file_edits", tool.Name())
+
+	t.Run("requires file", func(t *testing.T) {
+		_, err := tool.Execute(map[string]interface{}{})
+		assert.Error(t, err)
+	})
+
+	t.Run("returns matching edits newest first", func(t *testing.T) {
+		result, err := tool.Execute(map[string]interface{}{"file": "main.go"})
+		require.NoError(t, err)
+
+		resultMap := result.(map[string]interface{})
+		edits := resultMap["edits"].([]models.FileEditHit)
+		require.Len(t, edits, 2)
+
+		assert.Equal(t, "edit-002", edits[0].UUID)
+		assert.Equal(t, "Write", edits[0].Tool)
+		assert.Contains(t, edits[0].Snippet, "+package main")
+
+		assert.Equal(t, "edit-001", edits[1].UUID)
+		assert.Contains(t, edits[1].Snippet, "-foo")
+		assert.Contains(t, edits[1].Snippet, "+bar")
+	})
+}
+
+func TestGetSessionLogsTool_DryRun(t *testing.T) {
+	tool := NewGetSessionLogsTool(NewServices(""))
+	inputFile := createTestJSONLFile(t)
+	outputPath := filepath.Join(t.TempDir(), "nested", "dir", "logs.json")
+
+	t.Run("reports planned write without creating files", func(t *testing.T) {
+		result, err := tool.Execute(map[string]interface{}{
+			"file_path":   inputFile,
+			"output_path": outputPath,
+			"dry_run":     true,
+		})
+		require.NoError(t, err)
+
+		dryRun, ok := result.(*DryRunResult)
+		require.True(t, ok)
+		assert.True(t, dryRun.DryRun)
+		require.Len(t, dryRun.Files, 1)
+		assert.Equal(t, outputPath, dryRun.Files[0].Path)
+		assert.True(t, dryRun.Files[0].CreatesDirectories)
+		assert.Greater(t, dryRun.Files[0].Bytes, 0)
+
+		_, err = os.Stat(filepath.Dir(outputPath))
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("accepts any extension, like the real write", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "logs.txt")
+		result, err := tool.Execute(map[string]interface{}{
+			"file_path":   inputFile,
+			"output_path": path,
+			"dry_run":     true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, path, result.(*DryRunResult).Files[0].Path)
+
+		_, err = tool.Execute(map[string]interface{}{
+			"file_path":   inputFile,
+			"output_path": path,
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects path under a regular file", func(t *testing.T) {
+		_, err := tool.Execute(map[string]interface{}{
+			"file_path":   inputFile,
+			"output_path": filepath.Join(inputFile, "logs.json"),
+			"dry_run":     true,
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestGetSessionStatsTool_DryRun(t *testing.T) {
+	tool := NewGetSessionStatsTool(NewServices(""))
+	inputFile := createTestJSONLFile(t)
+	base := filepath.Join(t.TempDir(), "stats")
+
+	result, err := tool.Execute(map[string]interface{}{
+		"file_path":     inputFile,
+		"output_path":   base,
+		"generate_html": true,
+		"dry_run":       true,
+	})
+	require.NoError(t, err)
+
+	dryRun, ok := result.(*DryRunResult)
+	require.True(t, ok)
+	require.Len(t, dryRun.Files, 2)
+	assert.Equal(t, base+".json", dryRun.Files[0].Path)
+	assert.Equal(t, base+".html", dryRun.Files[1].Path)
+
+	_, err = os.Stat(base + ".json")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestGetSessionStatsTool_LinkConversation(t *testing.T) {
+	dir := t.TempDir()
+	inputFile := filepath.Join(dir, "linked.jsonl")
+	sessionContent := `{"uuid":"u1","type":"user","timestamp":"2024-01-01T10:00:00Z","message":{"role":"user","content":"List files"}}
+{"uuid":"a1","type":"assistant","timestamp":"2024-01-01T10:00:01Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t1","name":"Bash","input":{"command":"ls"}}]}}
+{"uuid":"r1","type":"user","timestamp":"2024-01-01T10:00:02Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t1","content":"main.go"}]}}
+`
+	require.NoError(t, os.WriteFile(inputFile, []byte(sessionContent), 0644))
+	tool := NewGetSessionStatsTool(NewServices(""))
+
+	base := filepath.Join(dir, "plain")
+	result, err := tool.Execute(map[string]interface{}{"file_path": inputFile, "output_path": base, "generate_html": true})
+	require.NoError(t, err)
+	files := result.(*models.SessionStats).Files
+	require.Len(t, files.Files, 2)
+	assert.Equal(t, models.OutputFile{Path: base + ".json", Type: models.OutputTypeJSON, Bytes: fileSize(t, base+".json")}, files.Files[0])
+	assert.Equal(t, models.OutputFile{Path: base + ".html", Type: models.OutputTypeHTML, Bytes: fileSize(t, base+".html")}, files.Files[1])
+	assert.False(t, files.OpenedBrowser)
+	statsHTML, err := os.ReadFile(base + ".html")
+	require.NoError(t, err)
+	assert.Contains(t, string(statsHTML), `<span class="tag is-info" title="t1">Bash</span>`)
+
+	base = filepath.Join(dir, "linked")
+	result, err = tool.Execute(map[string]interface{}{"file_path": inputFile, "output_path": base, "generate_html": true, "link_conversation": true})
+	require.NoError(t, err)
+	files = result.(*models.SessionStats).Files
+	require.Len(t, files.Files, 3)
+	assert.Equal(t, base+"-conversation.html", files.Files[2].Path)
+	assert.Equal(t, fileSize(t, files.Files[2].Path), files.Files[2].Bytes)
+
+	statsHTML, err = os.ReadFile(base + ".html")
+	require.NoError(t, err)
+	assert.Contains(t, string(statsHTML), `<a class="tag is-info" href="linked-conversation.html#entry-a1" title="t1">Bash</a>`)
+
+	conversationHTML, err := os.ReadFile(files.Files[2].Path)
+	require.NoError(t, err)
+	assert.Contains(t, string(conversationHTML), `id="entry-a1"`)
+}
+
+// fileSize returns the size of path on disk.
+func fileSize(t *testing.T, path string) int64 {
+	t.Helper()
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	return info.Size()
+}
+
+func TestGetProjectAgentTypesTool(t *testing.T) {
+	claudeDir := setupTestClaudeDir(t)
+	projectDir := filepath.Join(claudeDir, "projects", "-Users-test-myproject")
+	task := func(uuid, agentType string) string {
+		return `{"uuid":"` + uuid + `","type":"assistant","timestamp":"2024-01-02T10:00:00Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t-` + uuid + `","name":"Task","input":{"subagent_type":"` + agentType + `","prompt":"go"}}]}}` + "\n"
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "aaaaaaaa-0000-0000-0000-000000000001.jsonl"),
+		[]byte(task("a1", "Plan")+task("a2", "Explore")), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "aaaaaaaa-0000-0000-0000-000000000002.jsonl"),
+		[]byte(task("b1", "Explore")), 0644))
+
+	tool := NewGetProjectAgentTypesTool(NewServices(claudeDir))
+	assert.Equal(t, "get_project_agent_types", tool.Name())
+
+	result, err := tool.Execute(map[string]interface{}{})
+	require.NoError(t, err)
+
+	projects := result.(map[string]interface{})["projects"].(map[string][]models.AgentTypeUsage)
+	require.Len(t, projects, 1)
+	for _, usages := range projects {
+		assert.Equal(t, []models.AgentTypeUsage{
+			{AgentType: "Explore", Sessions: 2},
+			{AgentType: "Plan", Sessions: 1},
+		}, usages)
+	}
+}
+
+func TestExtractURLsTool(t *testing.T) {
+	inputFile := filepath.Join(t.TempDir(), "research.jsonl")
+	sessionContent := `{"uuid":"msg-001","type":"assistant","timestamp":"2024-01-01T10:00:00Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"ws-1","name":"WebSearch","input":{"query":"effective go"}}]}}
+{"uuid":"res-001","type":"user","timestamp":"2024-01-01T10:00:01Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"ws-1","content":"1. https://go.dev/doc/effective_go. 2. https://go.dev/blog"}]}}
+{"uuid":"msg-002","type":"assistant","timestamp":"2024-01-01T10:01:00Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"wf-1","name":"WebFetch","input":{"url":"https://go.dev/doc/effective_go","prompt":"summarize"}}]}}
+{"uuid":"res-002","type":"user","timestamp":"2024-01-01T10:01:01Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"wf-1","content":"Effective Go describes idioms."}]}}
+{"uuid":"msg-003","type":"assistant","timestamp":"2024-01-01T10:02:00Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"bash-1","name":"Bash","input":{"command":"curl https://example.com"}}]}}
+`
+	require.NoError(t, os.WriteFile(inputFile, []byte(sessionContent), 0644))
+
+	tool := NewExtractURLsTool(NewServices(""))
+	assert.Equal(t, "extract_urls", tool.Name())
+
+	t.Run("requires session_id or file_path", func(t *testing.T) {
+		_, err := tool.Execute(map[string]interface{}{})
+		assert.Error(t, err)
+	})
+
+	t.Run("merges repeated URLs from web tools", func(t *testing.T) {
+		result, err := tool.Execute(map[string]interface{}{"file_path": inputFile})
+		require.NoError(t, err)
+
+		urls := result.(map[string]interface{})["urls"].([]models.URLHit)
+		require.Len(t, urls, 2)
+
+		assert.Equal(t, "https://go.dev/doc/effective_go", urls[0].URL)
+		assert.Equal(t, 2, urls[0].Count)
+		assert.Equal(t, []string{"WebSearch", "WebFetch"}, urls[0].Tools)
+		assert.Equal(t, "2024-01-01T10:00:00Z", urls[0].FirstSeen)
+		assert.Equal(t, "2024-01-01T10:01:00Z", urls[0].LastSeen)
+
+		assert.Equal(t, "https://go.dev/blog", urls[1].URL)
+		assert.Equal(t, 1, urls[1].Count)
+	})
+}
+
+func TestSearchLogsTool_WholeWordAndMinContentLen(t *testing.T) {
+	services := NewServices("")
+	tool := NewSearchLogsTool(services)
+
+	inputFile := filepath.Join(t.TempDir(), "test-session.jsonl")
+	sessionContent := `{"uuid":"msg-001","type":"message","timestamp":"2024-01-01T10:00:00Z","message":{"role":"user","content":"go"}}
+{"uuid":"msg-002","type":"message","timestamp":"2024-01-01T10:00:01Z","message":{"role":"assistant","content":[{"type":"text","text":"Let's go build the project"}]}}
+{"uuid":"msg-003","type":"message","timestamp":"2024-01-01T10:00:02Z","message":{"role":"user","content":"Use the google client"}}
+{"uuid":"msg-004","type":"message","timestamp":"2024-01-01T10:00:03Z","message":{"role":"assistant","content":[{"type":"text","text":"Ça goé not a word boundary"}]}}
+`
+	require.NoError(t, os.WriteFile(inputFile, []byte(sessionContent), 0644))
+
+	count := func(args map[string]interface{}) int {
+		args["file_path"] = inputFile
+		args["query"] = "go"
+		args["count_only"] = true
+		result, err := tool.Execute(args)
+		require.NoError(t, err)
+		return result.(map[string]int)["count"]
+	}
+
+	assert.Equal(t, 4, count(map[string]interface{}{}))
+	assert.Equal(t, 2, count(map[string]interface{}{"whole_word": true}))
+	assert.Equal(t, 3, count(map[string]interface{}{"min_content_len": float64(3)}))
+	assert.Equal(t, 1, count(map[string]interface{}{"whole_word": true, "min_content_len": float64(3)}))
+}
+
+func TestSearchLogsTool_FullContent(t *testing.T) {
+	tool := NewSearchLogsTool(NewServices(""))
+
+	long := strings.Repeat("x", 300)
+	inputFile := filepath.Join(t.TempDir(), "test-session.jsonl")
+	sessionContent := `{"uuid":"msg-001","type":"message","timestamp":"2024-01-01T10:00:00Z","message":{"role":"assistant","content":[{"type":"text","text":"Running ` + long + `"},{"type":"tool_use","id":"t1","name":"Bash","input":{"command":"go test ./..."}}]}}
+{"uuid":"msg-002","type":"message","timestamp":"2024-01-01T10:00:01Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t1","content":"ok all tests"}]}}
+{"uuid":"msg-003","type":"message","timestamp":"2024-01-01T10:00:02Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t2","name":"Bash","input":{"command":"ls"}}]}}
+`
+	require.NoError(t, os.WriteFile(inputFile, []byte(sessionContent), 0644))
+
+	result, err := tool.Execute(map[string]interface{}{"file_path": inputFile, "tool_name": "bash", "full_content": true, "limit": float64(1)})
+	require.NoError(t, err)
+	results := result.(*service.SearchResults).Results
+	require.Len(t, results, 1)
+	assert.Equal(t, "Running "+long, results[0].Content)
+	assert.Equal(t, map[string]interface{}{"command": "go test ./..."}, results[0].ToolInput)
+
+	result, err = tool.Execute(map[string]interface{}{"file_path": inputFile, "role": "user", "full_content": true})
+	require.NoError(t, err)
+	results = result.(*service.SearchResults).Results
+	require.Len(t, results, 1)
+	assert.Equal(t, "ok all tests", results[0].ToolOutput)
+
+	result, err = tool.Execute(map[string]interface{}{"file_path": inputFile, "tool_name": "Bash"})
+	require.NoError(t, err)
+	for _, r := range result.(*service.SearchResults).Results {
+		assert.Empty(t, r.Content)
+		assert.Nil(t, r.ToolInput)
+	}
+}
+
+func TestGetToolUsageStatsTool_SequenceSignature(t *testing.T) {
+	services := NewServices("")
+	tool := NewGetToolUsageStatsTool(services)
+
+	inputFile := filepath.Join(t.TempDir(), "test-session.jsonl")
+	sessionContent := `{"uuid":"msg-001","type":"assistant","timestamp":"2024-01-01T10:00:00Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t1","name":"Read","input":{}}]}}
+{"uuid":"msg-002","type":"assistant","timestamp":"2024-01-01T10:00:01Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t2","name":"Edit","input":{}},{"type":"tool_use","id":"t3","name":"Edit","input":{}}]}}
+{"uuid":"msg-003","type":"assistant","timestamp":"2024-01-01T10:00:02Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t4","name":"Bash","input":{}}]}}
+{"uuid":"msg-004","type":"assistant","timestamp":"2024-01-01T10:00:03Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t5","name":"Edit","input":{}}]}}
+`
+	require.NoError(t, os.WriteFile(inputFile, []byte(sessionContent), 0644))
+
+	result, err := tool.Execute(map[string]interface{}{"file_path": inputFile})
+	require.NoError(t, err)
+
+	stats := result.(*models.ToolUsageStats)
+	assert.Equal(t, "Read>Edit>Edit>Bash>Edit", stats.SequenceSignature)
+	assert.Equal(t, "Read>Edit>Bash>Edit", stats.CollapsedSignature)
+}
+
+func TestGetSessionErrorsTool_RootCause(t *testing.T) {
+	services := NewServices("")
+	tool := NewGetSessionErrorsTool(services)
+
+	inputFile := filepath.Join(t.TempDir(), "test-session.jsonl")
+	sessionContent := `{"uuid":"a1","type":"assistant","timestamp":"2024-01-01T10:00:00Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t1","name":"Read","input":{}}]}}
+{"uuid":"u1","parentUuid":"a1","type":"user","timestamp":"2024-01-01T10:00:01Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t1","content":"File does not exist","is_error":true}]}}
+{"uuid":"a2","parentUuid":"u1","type":"assistant","timestamp":"2024-01-01T10:00:02Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t2","name":"Bash","input":{}}]}}
+{"uuid":"u2","parentUuid":"a2","type":"user","timestamp":"2024-01-01T10:00:03Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t2","content":"go: cannot find module in /tmp/a","is_error":true}]}}
+{"uuid":"a3","parentUuid":"u2","type":"assistant","timestamp":"2024-01-01T10:00:04Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t3","name":"Bash","input":{}}]}}
+{"uuid":"u3","parentUuid":"a3","type":"user","timestamp":"2024-01-01T10:00:05Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t3","content":"go: cannot find module in /tmp/b","is_error":true}]}}
+{"uuid":"a4","parentUuid":"u3","type":"assistant","timestamp":"2024-01-01T10:00:06Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t4","name":"Bash","input":{}}]}}
+{"uuid":"u4","parentUuid":"a4","type":"user","timestamp":"2024-01-01T10:00:07Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t4","content":"go: cannot find module in /tmp/c","is_error":true}]}}
+`
+	require.NoError(t, os.WriteFile(inputFile, []byte(sessionContent), 0644))
+
+	result, err := tool.Execute(map[string]interface{}{"file_path": inputFile})
+	require.NoError(t, err)
+
+	errors := result.(*models.SessionErrors)
+	require.NotNil(t, errors.RootCause, "expected a root cause among %d errors", errors.TotalErrors)
+	assert.Equal(t, "Bash", errors.RootCause.ToolName)
+	assert.Equal(t, "a2", errors.RootCause.UUID)
+	assert.Contains(t, errors.RootCauseReason, "related failures")
+}
+
+func TestNoiseTools_ExcludedFromHeadlineCounts(t *testing.T) {
+	services := NewServices("")
+	services.Session.SetNoiseTools([]string{"TodoWrite"})
+
+	inputFile := filepath.Join(t.TempDir(), "test-session.jsonl")
+	sessionContent := `{"uuid":"a1","type":"assistant","timestamp":"2024-01-01T10:00:00Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t1","name":"TodoWrite","input":{}}]}}
+{"uuid":"a2","type":"assistant","timestamp":"2024-01-01T10:00:01Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t2","name":"Read","input":{}}]}}
+{"uuid":"a3","type":"assistant","timestamp":"2024-01-01T10:00:02Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t3","name":"TodoWrite","input":{}}]}}
+`
+	require.NoError(t, os.WriteFile(inputFile, []byte(sessionContent), 0644))
+
+	result, err := NewGetToolUsageStatsTool(services).Execute(map[string]interface{}{"file_path": inputFile})
+	require.NoError(t, err)
+	stats := result.(*models.ToolUsageStats)
+	require.Len(t, stats.Tools, 1)
+	assert.Equal(t, "Read", stats.Tools[0].Name)
+	require.Len(t, stats.NoiseTools, 1)
+	assert.Equal(t, 2, stats.NoiseTools[0].Count)
+	// Headline tools share the headline calls; noise tools share all calls
+	assert.Equal(t, 100.0, stats.Tools[0].Percentage)
+	assert.InDelta(t, 66.7, stats.NoiseTools[0].Percentage, 0.1)
+	assert.Equal(t, "Read", stats.Patterns.MostUsed)
+	assert.Equal(t, 1, stats.TotalCalls())
+
+	result, err = NewGetSessionSummaryTool(services).Execute(map[string]interface{}{"file_path": inputFile})
+	require.NoError(t, err)
+	summary := result.(*models.SessionSummary)
+	assert.Equal(t, 1, summary.ToolCalls.Total)
+	assert.Equal(t, 1, summary.ToolCalls.UniqueTools)
+	assert.Equal(t, 2, summary.ToolCalls.NoiseCalls)
+}
+
+func TestExportSessionTextTool(t *testing.T) {
+	inputFile := filepath.Join(t.TempDir(), "export.jsonl")
+	sessionContent := `{"uuid":"u1","type":"user","timestamp":"2024-01-01T10:00:00Z","message":{"role":"user","content":"First question about parsing"}}
+{"uuid":"a1","type":"assistant","timestamp":"2024-01-01T10:00:01Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"r1","name":"Read","input":{"file_path":"/tmp/a.go"}}]}}
+{"uuid":"t1","type":"user","timestamp":"2024-01-01T10:00:02Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"r1","content":"package main with a very large body that should never be exported"}]}}
+{"uuid":"a2","type":"assistant","timestamp":"2024-01-01T10:00:03Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"r2","name":"Read","input":{"file_path":"/tmp/b.go"}}]}}
+{"uuid":"a3","type":"assistant","timestamp":"2024-01-01T10:00:04Z","message":{"role":"assistant","content":[{"type":"text","text":"The parser reads one line at a time."}]}}
+{"uuid":"u2","type":"user","timestamp":"2024-01-01T10:01:00Z","message":{"role":"user","content":"Second question about rendering"}}
+{"uuid":"a4","type":"assistant","timestamp":"2024-01-01T10:01:01Z","message":{"role":"assistant","content":[{"type":"text","text":"Rendering uses templates."}]}}
+{"uuid":"u3","type":"user","timestamp":"2024-01-01T10:02:00Z","message":{"role":"user","content":"Third question about output"}}
+{"uuid":"a5","type":"assistant","timestamp":"2024-01-01T10:02:01Z","message":{"role":"assistant","content":[{"type":"text","text":"Output goes to a file."}]}}
+`
+	require.NoError(t, os.WriteFile(inputFile, []byte(sessionContent), 0644))
+
+	tool := NewExportSessionTextTool(NewServices(""))
+	assert.Equal(t, "export_session_text", tool.Name())
+
+	t.Run("requires session_id or file_path", func(t *testing.T) {
+		_, err := tool.Execute(map[string]interface{}{})
+		assert.Error(t, err)
+	})
+
+	t.Run("exports prompts, tool names and conclusions", func(t *testing.T) {
+		result, err := tool.Execute(map[string]interface{}{"file_path": inputFile})
+		require.NoError(t, err)
+
+		export := result.(*models.SessionTextExport)
+		assert.Equal(t, 3, export.TotalTurns)
+		assert.Zero(t, export.OmittedTurns)
+		assert.Contains(t, export.Text, "[1] User: First question about parsing")
+		assert.Contains(t, export.Text, "Tools: Read x2")
+		assert.Contains(t, export.Text, "Assistant: The parser reads one line at a time.")
+		assert.NotContains(t, export.Text, "very large body")
+		assert.Positive(t, export.EstimatedTokens)
+	})
+
+	t.Run("oldest-first keeps the latest turns", func(t *testing.T) {
+		result, err := tool.Execute(map[string]interface{}{"file_path": inputFile, "max_tokens": float64(25)})
+		require.NoError(t, err)
+
+		export := result.(*models.SessionTextExport)
+		assert.LessOrEqual(t, export.EstimatedTokens, 25)
+		assert.Equal(t, "oldest-first", export.Strategy)
+		assert.Positive(t, export.OmittedTurns)
+		assert.NotContains(t, export.Text, "First question")
+		assert.Contains(t, export.Text, "Third question")
+		assert.Contains(t, export.Text, "turns omitted")
+	})
+
+	t.Run("middle-out keeps the first and last turns", func(t *testing.T) {
+		result, err := tool.Execute(map[string]interface{}{"file_path": inputFile, "max_tokens": float64(50), "strategy": "middle-out"})
+		require.NoError(t, err)
+
+		export := result.(*models.SessionTextExport)
+		assert.LessOrEqual(t, export.EstimatedTokens, 50)
+		assert.Equal(t, 1, export.OmittedTurns)
+		assert.Contains(t, export.Text, "First question")
+		assert.NotContains(t, export.Text, "Second question")
+		assert.Contains(t, export.Text, "Third question")
+	})
+
+	t.Run("rejects unknown strategy", func(t *testing.T) {
+		_, err := tool.Execute(map[string]interface{}{"file_path": inputFile, "strategy": "random"})
+		assert.Error(t, err)
+	})
+}
+
+func TestGetSessionLogsTool_IncludeHashes(t *testing.T) {
+	tool := NewGetSessionLogsTool(NewServices(""))
+	inputFile := createTestJSONLFile(t)
+
+	result, err := tool.Execute(map[string]interface{}{"file_path": inputFile})
+	require.NoError(t, err)
+	for _, e := range result.(*models.SessionLogs).Entries {
+		assert.Empty(t, e.ContentHash)
+	}
+
+	result, err = tool.Execute(map[string]interface{}{"file_path": inputFile, "include_hashes": true})
+	require.NoError(t, err)
+	entries := result.(*models.SessionLogs).Entries
+	require.NotEmpty(t, entries)
+	seen := make(map[string]bool)
+	for _, e := range entries {
+		assert.Len(t, e.ContentHash, 16)
+		seen[e.ContentHash] = true
+	}
+	assert.Len(t, seen, len(entries), "distinct messages should hash differently")
+}
+
+func TestGetSessionLogsTool_AfterUUID(t *testing.T) {
+	tool := NewGetSessionLogsTool(NewServices(""))
+	inputFile := createTestJSONLFile(t)
+
+	result, err := tool.Execute(map[string]interface{}{"file_path": inputFile})
+	require.NoError(t, err)
+	all := result.(*models.SessionLogs).Entries
+	require.Len(t, all, 4)
+
+	result, err = tool.Execute(map[string]interface{}{"file_path": inputFile, "after_uuid": all[1].UUID})
+	require.NoError(t, err)
+	logs := result.(*models.SessionLogs)
+	assert.Equal(t, all[2:], logs.Entries)
+	assert.Equal(t, 4, logs.TotalEntries)
+	assert.Equal(t, 2, logs.FilteredEntries)
+
+	result, err = tool.Execute(map[string]interface{}{"file_path": inputFile, "after_uuid": all[3].UUID})
+	require.NoError(t, err)
+	assert.Empty(t, result.(*models.SessionLogs).Entries)
+
+	_, err = tool.Execute(map[string]interface{}{"file_path": inputFile, "after_uuid": "no-such-uuid"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "entry with UUID no-such-uuid not found")
+}
+
+func TestGetSessionSummaryTool_LongestToolFreeStretch(t *testing.T) {
+	inputFile := filepath.Join(t.TempDir(), "stretch.jsonl")
+	sessionContent := `{"uuid":"u1","type":"user","timestamp":"2024-01-01T10:00:00Z","message":{"role":"user","content":"Plan the refactor"}}
+{"uuid":"a1","type":"assistant","timestamp":"2024-01-01T10:00:01Z","message":{"role":"assistant","content":[{"type":"text","text":"First I will think about the layout of the package."}],"usage":{"input_tokens":10,"output_tokens":12}}}
+{"uuid":"a2","type":"assistant","timestamp":"2024-01-01T10:00:02Z","message":{"role":"assistant","content":[{"type":"text","text":"Then about the tests."}]}}
+{"uuid":"a3","type":"assistant","timestamp":"2024-01-01T10:00:03Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t1","name":"Read","input":{"file_path":"/tmp/a.go"}}]}}
+{"uuid":"r1","type":"user","timestamp":"2024-01-01T10:00:04Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t1","content":"package a"}]}}
+{"uuid":"a4","type":"assistant","timestamp":"2024-01-01T10:00:05Z","message":{"role":"assistant","content":[{"type":"text","text":"Done."}]}}
+`
+	require.NoError(t, os.WriteFile(inputFile, []byte(sessionContent), 0644))
+
+	result, err := NewGetSessionSummaryTool(NewServices("")).Execute(map[string]interface{}{"file_path": inputFile})
+	require.NoError(t, err)
+
+	stretch := result.(*models.SessionSummary).LongestToolFreeStretch
+	require.NotNil(t, stretch)
+	assert.Equal(t, 2, stretch.Turns)
+	assert.Equal(t, "a1", stretch.StartUUID)
+	assert.Equal(t, "a2", stretch.EndUUID)
+	assert.Equal(t, 2, stretch.StartStep)
+	assert.Equal(t, 3, stretch.EndStep)
+	assert.Positive(t, stretch.OutputTokens)
+}
+
+func TestGetSessionSummaryTool_TurnShape(t *testing.T) {
+	inputFile := filepath.Join(t.TempDir(), "shape.jsonl")
+	sessionContent := `{"uuid":"u1","type":"user","timestamp":"2024-01-01T10:00:00Z","message":{"role":"user","content":"Fix it"}}
+{"uuid":"a1","type":"assistant","timestamp":"2024-01-01T10:00:01Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t1","name":"Read","input":{"file_path":"/tmp/a.go"}}]}}
+{"uuid":"r1","type":"user","timestamp":"2024-01-01T10:00:02Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t1","content":"package a"}]}}
+{"uuid":"a2","type":"assistant","timestamp":"2024-01-01T10:00:03Z","message":{"role":"assistant","content":[{"type":"text","text":"Fixed the import."}]}}
+`
+	require.NoError(t, os.WriteFile(inputFile, []byte(sessionContent), 0644))
+
+	result, err := NewGetSessionSummaryTool(NewServices("")).Execute(map[string]interface{}{"file_path": inputFile})
+	require.NoError(t, err)
+
+	summary := result.(*models.SessionSummary)
+	assert.Equal(t, 6.0, summary.AvgUserMessageLength)
+	assert.Equal(t, 17.0, summary.AvgAssistantMessageLength)
+	assert.Equal(t, 0.5, summary.UserAssistantRatio)
+}
+
+func TestGetSessionSummaryTool_BranchSwitches(t *testing.T) {
+	inputFile := filepath.Join(t.TempDir(), "branches.jsonl")
+	sessionContent := `{"uuid":"u1","type":"user","gitBranch":"main","timestamp":"2024-01-01T10:00:00Z","message":{"role":"user","content":"Start"}}
+{"uuid":"a1","type":"assistant","gitBranch":"main","timestamp":"2024-01-01T10:00:01Z","message":{"role":"assistant","content":[{"type":"text","text":"Ok"}]}}
+{"uuid":"u2","type":"user","timestamp":"2024-01-01T10:00:02Z","message":{"role":"user","content":"No branch recorded"}}
+{"uuid":"a2","type":"assistant","gitBranch":"feature","timestamp":"2024-01-01T10:00:03Z","message":{"role":"assistant","content":[{"type":"text","text":"Switched"}]}}
+{"uuid":"u3","type":"user","gitBranch":"feature","timestamp":"2024-01-01T10:00:04Z","message":{"role":"user","content":"Go back"}}
+{"uuid":"a3","type":"assistant","gitBranch":"main","timestamp":"2024-01-01T10:00:05Z","message":{"role":"assistant","content":[{"type":"text","text":"Back on main"}]}}
+`
+	require.NoError(t, os.WriteFile(inputFile, []byte(sessionContent), 0644))
+
+	result, err := NewGetSessionSummaryTool(NewServices("")).Execute(map[string]interface{}{"file_path": inputFile})
+	require.NoError(t, err)
+
+	switches := result.(*models.SessionSummary).BranchSwitches
+	require.Len(t, switches, 2)
+	assert.Equal(t, models.BranchSwitch{From: "main", To: "feature", Timestamp: "2024-01-01T10:00:03Z", UUID: "a2"}, switches[0])
+	assert.Equal(t, models.BranchSwitch{From: "feature", To: "main", Timestamp: "2024-01-01T10:00:05Z", UUID: "a3"}, switches[1])
+}
+
+func TestGetSessionSummaryTool_HealthScore(t *testing.T) {
+	inputFile := filepath.Join(t.TempDir(), "health.jsonl")
+	sessionContent := `{"uuid":"u1","type":"user","timestamp":"2024-01-01T10:00:00Z","message":{"role":"user","content":"Run the tests"}}
+{"uuid":"a1","type":"assistant","timestamp":"2024-01-01T10:00:01Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t1","name":"Bash","input":{"command":"go test"}}]}}
+{"uuid":"r1","type":"user","timestamp":"2024-01-01T10:00:02Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t1","content":"exit 1","is_error":true}]}}
+{"uuid":"a2","type":"assistant","timestamp":"2024-01-01T10:00:03Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t2","name":"Bash","input":{"command":"go test -v"}}]}}
+{"uuid":"r2","type":"user","timestamp":"2024-01-01T10:00:04Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t2","content":"ok"}]}}
+{"uuid":"u2","type":"user","timestamp":"2024-01-01T10:00:05Z","message":{"role":"user","content":"[Request interrupted by user]"}}
+`
+	require.NoError(t, os.WriteFile(inputFile, []byte(sessionContent), 0644))
+
+	services := NewServices("")
+	result, err := NewGetSessionSummaryTool(services).Execute(map[string]interface{}{"file_path": inputFile})
+	require.NoError(t, err)
+
+	summary := result.(*models.SessionSummary)
+	assert.Equal(t, 1, summary.RetriedToolCalls)
+	assert.Equal(t, 1, summary.Interruptions)
+	// Half the tool calls failed and half were retries (both past their
+	// ceilings); one interruption is a third of its ceiling: 100-30-20-6.7
+	assert.Equal(t, 43, summary.HealthScore)
+	assert.Equal(t, summary.HealthScore, services.Session.ComputeHealthScore(&models.SessionStats{Summary: summary}))
+
+	services.Session.SetHealthWeights(models.HealthWeights{Interruptions: 10})
+	result, err = NewGetSessionSummaryTool(services).Execute(map[string]interface{}{"file_path": inputFile})
+	require.NoError(t, err)
+	assert.Equal(t, 97, result.(*models.SessionSummary).HealthScore)
+}
+
+func TestGetSessionSummaryTool_HeavyReads(t *testing.T) {
+	inputFile := filepath.Join(t.TempDir(), "reads.jsonl")
+	sessionContent := `{"uuid":"u1","type":"user","timestamp":"2024-01-01T10:00:00Z","message":{"role":"user","content":"Read some files"}}
+{"uuid":"a1","type":"assistant","timestamp":"2024-01-01T10:00:01Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t1","name":"Read","input":{"file_path":"/repo/small.go"}}]}}
+{"uuid":"r1","type":"user","timestamp":"2024-01-01T10:00:02Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t1","content":"package main"}]}}
+{"uuid":"a2","type":"assistant","timestamp":"2024-01-01T10:00:03Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t2","name":"Read","input":{"file_path":"/repo/big.log"}}]}}
+{"uuid":"r2","type":"user","timestamp":"2024-01-01T10:00:04Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t2","content":"` + strings.Repeat(`log line\n`, 200) + `"}]}}
+{"uuid":"a3","type":"assistant","timestamp":"2024-01-01T10:00:05Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t3","name":"Read","input":{"file_path":"/repo/logo.png"}}]}}
+{"uuid":"r3","type":"user","timestamp":"2024-01-01T10:00:06Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t3","content":"\u0089PNG\u0001\u0002\u0003\u0004"}]}}
+`
+	require.NoError(t, os.WriteFile(inputFile, []byte(sessionContent), 0644))
+
+	services := NewServices("")
+	services.Session.SetHeavyReadBytes(1000)
+	result, err := NewGetSessionSummaryTool(services).Execute(map[string]interface{}{"file_path": inputFile})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.(*models.SessionSummary).HeavyReads)
+
+	reads, err := services.Session.HeavyReadsFromFile(inputFile, 0)
+	require.NoError(t, err)
+	require.Len(t, reads, 2)
+	assert.Equal(t, "/repo/big.log", reads[0].FilePath)
+	assert.Equal(t, 1800, reads[0].Bytes)
+	assert.False(t, reads[0].Binary)
+	assert.Equal(t, "/repo/logo.png", reads[1].FilePath)
+	assert.True(t, reads[1].Binary)
+
+	reads, err = services.Session.HeavyReadsFromFile(inputFile, 5000)
+	require.NoError(t, err)
+	require.Len(t, reads, 1)
+	assert.Equal(t, "r3", reads[0].UUID)
+}
+
+func TestGetSessionSummaryTool_AgentTokens(t *testing.T) {
+	inputFile := filepath.Join(t.TempDir(), "agents.jsonl")
+	sessionContent := `{"uuid":"u1","type":"user","timestamp":"2024-01-01T10:00:00Z","message":{"role":"user","content":"Find the bug"}}
+{"uuid":"a1","type":"assistant","timestamp":"2024-01-01T10:00:01Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t1","name":"Task","input":{"subagent_type":"Explore","description":"Search","prompt":"Look for the bug"}}],"usage":{"input_tokens":100,"output_tokens":20}}}
+{"uuid":"s1","type":"user","isSidechain":true,"agentId":"agent-1","timestamp":"2024-01-01T10:00:02Z","message":{"role":"user","content":"Look for the bug"}}
+{"uuid":"s2","parentUuid":"s1","type":"assistant","isSidechain":true,"agentId":"agent-1","timestamp":"2024-01-01T10:00:03Z","message":{"role":"assistant","content":[{"type":"text","text":"Found it"}],"usage":{"input_tokens":250,"output_tokens":30}}}
+{"uuid":"r1","type":"user","timestamp":"2024-01-01T10:00:04Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t1","content":[{"type":"text","text":"Found it"}]}]}}
+{"uuid":"a2","type":"assistant","timestamp":"2024-01-01T10:00:05Z","message":{"role":"assistant","content":[{"type":"text","text":"Fixed"}],"usage":{"input_tokens":80,"output_tokens":20}}}
+`
+	require.NoError(t, os.WriteFile(inputFile, []byte(sessionContent), 0644))
+
+	services := NewServices("")
+	result, err := NewGetSessionSummaryTool(services).Execute(map[string]interface{}{"file_path": inputFile})
+	require.NoError(t, err)
+
+	usage := result.(*models.SessionSummary).AgentTokens
+	require.Len(t, usage, 2)
+	assert.Equal(t, "", usage[0].AgentID)
+	assert.Equal(t, 2, usage[0].Messages)
+	assert.Equal(t, 180, usage[0].InputTokens)
+	assert.Equal(t, "agent-1", usage[1].AgentID)
+	assert.Equal(t, "Explore", usage[1].AgentType)
+	assert.Equal(t, 1, usage[1].Messages)
+	assert.Equal(t, 250, usage[1].InputTokens)
+
+	total := usage[0].TotalTokens + usage[1].TotalTokens
+	assert.InDelta(t, float64(usage[1].TotalTokens)*100/float64(total), usage[1].Percent, 0.01)
+	assert.InDelta(t, 100.0, usage[0].Percent+usage[1].Percent, 0.01)
+
+	html := agentTokensHTML(usage)
+	assert.Contains(t, html, "Tokens by Agent")
+	assert.Contains(t, html, "Explore")
+	assert.Contains(t, html, fmt.Sprintf("%.1f%%", usage[1].Percent))
+
+	// Sessions without subagents get no table
+	assert.Empty(t, agentTokensHTML(usage[:1]))
+}
+
+func TestListSessionsTool_RecursiveDiscovery(t *testing.T) {
+	claudeDir := setupTestClaudeDir(t)
+	projectDir := filepath.Join(claudeDir, "projects", "-Users-test-myproject")
+	content := []byte(`{"uuid":"n1","type":"message","timestamp":"2024-01-02T10:00:00Z","message":{"role":"user","content":"Nested"}}
+{"uuid":"n2","type":"message","timestamp":"2024-01-02T10:00:01Z","message":{"role":"assistant","content":[{"type":"text","text":"Found"}]}}
+`)
+	write := func(rel string) {
+		path := filepath.Join(projectDir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, content, 0644))
+	}
+	nestedID := "aaaaaaaa-0000-0000-0000-000000000001"
+	write(filepath.Join("archive", "2024", nestedID+".jsonl"))
+	write(filepath.Join("a", "b", "c", "d", "aaaaaaaa-0000-0000-0000-000000000002.jsonl"))
+	write(filepath.Join("12345678-1234-1234-1234-123456789abc", "subagents", "aaaaaaaa-0000-0000-0000-000000000003.jsonl"))
+
+	services := NewServices(claudeDir)
+	tool := NewListSessionsTool(services)
+	sessionIDs := func() []string {
+		result, err := tool.Execute(map[string]interface{}{"project": "myproject"})
+		require.NoError(t, err)
+		var ids []string
+		for _, s := range result.(map[string]interface{})["sessions"].([]models.SessionInfo) {
+			ids = append(ids, s.SessionID)
+		}
+		return ids
+	}
+
+	assert.Equal(t, []string{"12345678-1234-1234-1234-123456789abc"}, sessionIDs())
+	_, err := NewGetSessionSummaryTool(services).Execute(map[string]interface{}{"session_id": nestedID})
+	assert.Error(t, err)
+
+	services.Session.SetDiscoveryDepth(2)
+	assert.Equal(t, []string{nestedID, "12345678-1234-1234-1234-123456789abc"}, sessionIDs())
+	result, err := NewGetSessionSummaryTool(services).Execute(map[string]interface{}{"session_id": nestedID})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.(*models.SessionSummary).MessageCount)
+}
+
+func TestGetToolUsageStatsTool_ToolAccess(t *testing.T) {
+	inputFile := filepath.Join(t.TempDir(), "access.jsonl")
+	sessionContent := `{"uuid":"a1","type":"assistant","timestamp":"2024-01-01T10:00:00Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t1","name":"Read","input":{}}]}}
+{"uuid":"a2","type":"assistant","timestamp":"2024-01-01T10:00:01Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t2","name":"Edit","input":{}}]}}
+{"uuid":"a3","type":"assistant","timestamp":"2024-01-01T10:00:02Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t3","name":"Bash","input":{}}]}}
+{"uuid":"a4","type":"assistant","timestamp":"2024-01-01T10:00:03Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t4","name":"mcp__github__create_issue","input":{}}]}}
+{"uuid":"a5","type":"assistant","timestamp":"2024-01-01T10:00:04Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t5","name":"Edit","input":{}}]}}
+`
+	require.NoError(t, os.WriteFile(inputFile, []byte(sessionContent), 0644))
+	services := NewServices("")
+
+	result, err := NewGetToolUsageStatsTool(services).Execute(map[string]interface{}{"file_path": inputFile})
+	require.NoError(t, err)
+	stats := result.(*models.ToolUsageStats)
+	access := make(map[string]string)
+	for _, tool := range stats.Tools {
+		access[tool.Name] = tool.Access
+		assert.Equal(t, tool.Access == "write", tool.Mutating, tool.Name)
+	}
+	assert.Equal(t, map[string]string{"Read": "read", "Edit": "write", "Bash": "write", "mcp__github__create_issue": "unknown"}, access)
+
+	service.FilterMutatingTools(stats)
+	require.Len(t, stats.Tools, 2)
+	assert.Equal(t, "Edit", stats.Tools[0].Name)
+	assert.Equal(t, "Bash", stats.Tools[1].Name)
+	require.Len(t, stats.ToolSequence, 3)
+	assert.Equal(t, "t2", stats.ToolSequence[0].ToolUseID)
+
+	result, err = NewGetSessionSummaryTool(services).Execute(map[string]interface{}{"file_path": inputFile})
+	require.NoError(t, err)
+	calls := result.(*models.SessionSummary).ToolCalls
+	assert.Equal(t, 3, calls.Mutating)
+	assert.Equal(t, 1, calls.ReadOnly)
+	assert.Equal(t, 1, calls.UnknownAccess)
+}
@@ -0,0 +1,20 @@
+package models
+
+// DayActivity represents Claude usage on a single calendar day.
+type DayActivity struct {
+	Date     string `json:"date"` // YYYY-MM-DD, local time
+	Sessions int    `json:"sessions"`
+	Messages int    `json:"messages"`
+	Tokens   int    `json:"tokens"` // Input, output, and cache tokens
+}
+
+// TrendPoint holds a project's error rates for one time bucket.
+type TrendPoint struct {
+	Start                 string  `json:"start"` // First day of the bucket, YYYY-MM-DD local time
+	End                   string  `json:"end"`   // Last day of the bucket
+	Sessions              int     `json:"sessions"`
+	Messages              int     `json:"messages"`
+	Errors                int     `json:"errors"`
+	ErrorsPerSession      float64 `json:"errors_per_session"`
+	ErrorsPer1000Messages float64 `json:"errors_per_1000_messages"`
+}
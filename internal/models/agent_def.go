@@ -2,11 +2,26 @@ package models
 
 // AgentDefinition represents a custom agent definition from .md files.
 type AgentDefinition struct {
-	Name        string   `json:"name" yaml:"name"`
-	Description string   `json:"description" yaml:"description"`
-	Tools       []string `json:"tools,omitempty" yaml:"tools"`
-	Model       string   `json:"model,omitempty" yaml:"model"`
-	Color       string   `json:"color,omitempty" yaml:"color"`
-	Scope       string   `json:"scope"` // "global" or "project"
-	FilePath    string   `json:"file_path"`
+	Name           string   `json:"name" yaml:"name"`
+	Description    string   `json:"description" yaml:"description"`
+	Tools          []string `json:"tools,omitempty" yaml:"tools"`
+	Model          string   `json:"model,omitempty" yaml:"model"`
+	EffectiveModel string   `json:"effective_model,omitempty" yaml:"-"` // Model, or the settings.json default when unset
+	Color          string   `json:"color,omitempty" yaml:"color"`
+	Scope          string   `json:"scope"` // "global" or "project"
+	FilePath       string   `json:"file_path"`
+}
+
+// AgentDetail is an agent definition with its full frontmatter and prompt body.
+type AgentDetail struct {
+	AgentDefinition
+	Frontmatter map[string]interface{} `json:"frontmatter"`
+	Body        string                 `json:"body"`
+	Raw         string                 `json:"-"` // The file contents as written
+}
+
+// AgentTypeUsage counts the sessions in which a subagent type was used.
+type AgentTypeUsage struct {
+	AgentType string `json:"agent_type"`
+	Sessions  int    `json:"sessions"`
 }
@@ -0,0 +1,21 @@
+package models
+
+// MetricComparison compares one metric between a session and its baseline.
+type MetricComparison struct {
+	Metric       string  `json:"metric"`
+	Current      float64 `json:"current"`
+	Baseline     float64 `json:"baseline"`
+	Delta        float64 `json:"delta"`         // Current - Baseline
+	DeltaPercent float64 `json:"delta_percent"` // Delta relative to Baseline; 0 when Baseline is 0
+	Tolerance    float64 `json:"tolerance"`     // Allowed increase over Baseline, in percent
+	Passed       bool    `json:"passed"`
+}
+
+// StatsComparison reports how a session's stats differ from a baseline session.
+type StatsComparison struct {
+	SessionID  string             `json:"session_id"`
+	BaselineID string             `json:"baseline_id"`
+	Project    string             `json:"project"`
+	Metrics    []MetricComparison `json:"metrics"`
+	Passed     bool               `json:"passed"` // True when every metric is within tolerance
+}
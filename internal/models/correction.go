@@ -0,0 +1,16 @@
+package models
+
+// Correction is an assistant turn that corrects itself after a failed tool call,
+// e.g. "Actually, let me fix that".
+type Correction struct {
+	BeforeUUID      string `json:"before_uuid"` // Entry that issued the failed tool call
+	AfterUUID       string `json:"after_uuid"`  // Assistant turn containing the correction
+	Timestamp       string `json:"timestamp"`
+	Phrase          string `json:"phrase"` // Correction phrase that matched
+	Text            string `json:"text"`   // Correcting turn's text
+	FailedTool      string `json:"failed_tool"`
+	FailedToolUseID string `json:"failed_tool_use_id,omitempty"`
+	Error           string `json:"error,omitempty"` // Failed tool call's output
+	IsSidechain     bool   `json:"is_sidechain,omitempty"`
+	AgentID         string `json:"agent_id,omitempty"`
+}
@@ -0,0 +1,14 @@
+package models
+
+// EntryLocation tells where a log entry was found: its session, project, and
+// position among the session's parsed entries.
+type EntryLocation struct {
+	UUID        string `json:"uuid"`
+	SessionID   string `json:"session_id"`
+	Project     string `json:"project"`
+	Index       int    `json:"index"` // Position in the session log, subagent entries following the main ones
+	Timestamp   string `json:"timestamp"`
+	Type        string `json:"type"`
+	IsSidechain bool   `json:"is_sidechain,omitempty"`
+	AgentID     string `json:"agent_id,omitempty"`
+}
@@ -0,0 +1,20 @@
+package models
+
+// ErrorCluster groups errors with the same normalized message across sessions.
+type ErrorCluster struct {
+	Signature   string           `json:"signature"` // Normalized message shared by the cluster
+	Type        string           `json:"type"`
+	ToolName    string           `json:"tool_name,omitempty"`
+	Occurrences int              `json:"occurrences"`
+	Sessions    int              `json:"sessions"` // Distinct sessions the error occurred in
+	Example     string           `json:"example"`  // One original message, whitespace-normalized
+	References  []ErrorReference `json:"references"`
+}
+
+// ErrorReference points at one occurrence of a clustered error.
+type ErrorReference struct {
+	SessionID string `json:"session_id"`
+	Project   string `json:"project"`
+	UUID      string `json:"uuid"`
+	Timestamp string `json:"timestamp"`
+}
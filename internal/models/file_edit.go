@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// FileEditHit represents a single Edit, MultiEdit, or Write tool call on a matching file.
+type FileEditHit struct {
+	SessionID   string    `json:"session_id"`
+	Project     string    `json:"project"`
+	UUID        string    `json:"uuid"`
+	Timestamp   time.Time `json:"timestamp"`
+	Tool        string    `json:"tool"`
+	FilePath    string    `json:"file_path"`
+	Snippet     string    `json:"snippet"` // Diff of the edit, or the start of the written content
+	IsSidechain bool      `json:"is_sidechain,omitempty"`
+}
+
+// EditOp is one change an Edit, MultiEdit, or Write call made to a file.
+// A MultiEdit call yields one op per edit, numbered by EditIndex.
+type EditOp struct {
+	UUID        string `json:"uuid"`
+	ToolUseID   string `json:"tool_use_id"`
+	Timestamp   string `json:"timestamp"`
+	Tool        string `json:"tool"`
+	FilePath    string `json:"file_path"`
+	EditIndex   int    `json:"edit_index"`           // Position within a MultiEdit call; 0 for Edit and Write
+	OldString   string `json:"old_string,omitempty"` // Empty for Write, which replaces the whole file
+	NewString   string `json:"new_string"`           // Written content for Write
+	ReplaceAll  bool   `json:"replace_all,omitempty"`
+	Failed      bool   `json:"failed,omitempty"` // The tool result was an error, so the change was not applied
+	IsSidechain bool   `json:"is_sidechain,omitempty"`
+}
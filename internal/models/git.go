@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// GitCommit is a commit made in a session's working directory while it ran.
+type GitCommit struct {
+	Hash      string    `json:"hash"`
+	Author    string    `json:"author"`
+	Timestamp time.Time `json:"timestamp"`
+	Subject   string    `json:"subject"`
+}
@@ -0,0 +1,34 @@
+package models
+
+// HealthWeights sets how many points each problem signal can take off a
+// session's 100-point health score. A signal at or past its ceiling costs its
+// full weight; below that the cost scales linearly.
+type HealthWeights struct {
+	Errors        float64 `json:"errors"`        // Error entries per message
+	ToolFailures  float64 `json:"tool_failures"` // Failed tool calls per tool call
+	Retries       float64 `json:"retries"`       // Retried tool calls per tool call
+	Interruptions float64 `json:"interruptions"` // User interruptions
+}
+
+// DefaultHealthWeights returns the standard weights, which add up to 100.
+func DefaultHealthWeights() HealthWeights {
+	return HealthWeights{
+		Errors:        30,
+		ToolFailures:  30,
+		Retries:       20,
+		Interruptions: 20,
+	}
+}
+
+// HealthGrade buckets a health score: "good" from 80, "fair" from 50,
+// otherwise "poor".
+func HealthGrade(score int) string {
+	switch {
+	case score >= 80:
+		return "good"
+	case score >= 50:
+		return "fair"
+	default:
+		return "poor"
+	}
+}
@@ -0,0 +1,13 @@
+package models
+
+// HeavyRead is a Read tool call whose result was large or binary, pulling a
+// lot of content into the context window.
+type HeavyRead struct {
+	UUID        string `json:"uuid"` // UUID of the tool result entry
+	ToolUseID   string `json:"tool_use_id"`
+	FilePath    string `json:"file_path"`
+	Timestamp   string `json:"timestamp"`
+	Bytes       int    `json:"bytes"`
+	Binary      bool   `json:"binary,omitempty"` // Result content looked like binary data
+	IsSidechain bool   `json:"is_sidechain,omitempty"`
+}
@@ -0,0 +1,15 @@
+package models
+
+// HookEvent represents a single hook execution recorded in a session log.
+type HookEvent struct {
+	UUID        string `json:"uuid"`
+	Timestamp   string `json:"timestamp"`
+	Event       string `json:"event"`                 // e.g. "PreToolUse", "PostToolUse", "Stop"
+	Tool        string `json:"tool,omitempty"`        // Tool the hook matched, if any
+	ToolUseID   string `json:"tool_use_id,omitempty"` // ID of the tool call the hook ran for
+	Command     string `json:"command,omitempty"`
+	Outcome     string `json:"outcome"` // "success", "error", or "blocked"
+	Blocked     bool   `json:"blocked"`
+	Message     string `json:"message,omitempty"`
+	IsSidechain bool   `json:"is_sidechain,omitempty"`
+}
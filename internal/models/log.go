@@ -21,53 +21,89 @@ type LogEntry struct {
 	IsMeta        bool            `json:"isMeta"`
 	ToolUseResult interface{}     `json:"toolUseResult"`
 	AgentID       string          `json:"agentId"`
+	Subtype       string          `json:"subtype"`
+	// CompactMetadata is set on system compact_boundary entries
+	CompactMetadata *CompactMetadata `json:"compactMetadata"`
+	// Hook traces: system entries carry a status line in Content, attachment entries a HookAttachment
+	Content    json.RawMessage `json:"content"`
+	Level      string          `json:"level"`
+	ToolUseID  string          `json:"toolUseID"`
+	Attachment json.RawMessage `json:"attachment"`
+}
+
+// CompactMetadata describes a context compaction recorded in the log.
+type CompactMetadata struct {
+	Trigger   string `json:"trigger"`   // "auto" or "manual"
+	PreTokens int    `json:"preTokens"` // Context size before compaction
 }
 
 // TokenMetrics groups token usage and counting metrics.
 type TokenMetrics struct {
-	TokenCount          int // Tokens in this message (output tokens for assistant, estimated for user)
-	TotalTokens         int // Running total of all tokens up to this message
-	InputTokens         int // Input tokens from usage
-	OutputTokens        int // Output tokens from usage
-	CacheReadTokens     int // Cache read tokens from usage
-	CacheCreationTokens int // Cache creation tokens from usage
+	TokenCount          int `json:"token_count"`           // Tokens in this message (output tokens for assistant, estimated for user)
+	TotalTokens         int `json:"total_tokens"`          // Running total of all tokens up to this message
+	InputTokens         int `json:"input_tokens"`          // Input tokens from usage
+	OutputTokens        int `json:"output_tokens"`         // Output tokens from usage
+	CacheReadTokens     int `json:"cache_read_tokens"`     // Cache read tokens from usage
+	CacheCreationTokens int `json:"cache_creation_tokens"` // Cache creation tokens from usage
+
+	ThinkingTokens          int  `json:"thinking_tokens,omitempty"`           // Output tokens spent on thinking blocks
+	ThinkingTokensEstimated bool `json:"thinking_tokens_estimated,omitempty"` // True when ThinkingTokens was estimated from the thinking text
 }
 
 // CommandInfo groups local command execution data.
 type CommandInfo struct {
-	IsCommandMessage bool   // True if this is a command message with XML syntax
-	CommandName      string // The command name (e.g., "/add-dir")
-	CommandArgs      string // The command arguments
-	CommandOutput    string // The stdout output from the command
+	IsCommandMessage bool   `json:"is_command_message,omitempty"` // True if this is a command message with XML syntax
+	CommandName      string `json:"command_name,omitempty"`       // The command name (e.g., "/add-dir")
+	CommandArgs      string `json:"command_args,omitempty"`       // The command arguments
+	CommandOutput    string `json:"command_output,omitempty"`     // The stdout output from the command
 }
 
 // ProcessedEntry is a LogEntry enriched with hierarchy and metadata.
+// The JSON form is for debugging the processor; field order is fixed so dumps diff cleanly.
 type ProcessedEntry struct {
 	// Core fields
-	UUID         string
-	ParentUUID   string
-	Type         string
-	Timestamp    string
-	RawTimestamp string // Keep the raw timestamp for comparisons
-	Role         string
-	Content      string // Raw content, HTML escaping happens in templates
-	AgentID      string // Agent ID for sidechain entries
+	UUID         string `json:"uuid"`
+	ParentUUID   string `json:"parent_uuid,omitempty"`
+	Type         string `json:"type"`
+	Timestamp    string `json:"timestamp"`
+	RawTimestamp string `json:"raw_timestamp"` // Keep the raw timestamp for comparisons
+	Role         string `json:"role,omitempty"`
+	MessageID    string `json:"message_id,omitempty"` // API message ID, shared by every entry logged from one response
+	Content      string `json:"content"`              // Raw content, HTML escaping happens in templates
+	AgentID      string `json:"agent_id,omitempty"`   // Agent ID for sidechain entries
+	GitBranch    string `json:"git_branch,omitempty"`
 
 	// Relationships
-	Children []*ProcessedEntry
-	Depth    int
+	Children []*ProcessedEntry `json:"children,omitempty"`
+	Depth    int               `json:"depth"`
 
 	// Tool-related
-	ToolCalls    []ToolCall
-	IsToolResult bool
-	ToolResultID string // For matching tool results to tool calls
+	ToolCalls    []ToolCall `json:"tool_calls,omitempty"`
+	IsToolResult bool       `json:"is_tool_result,omitempty"`
+	ToolResultID string     `json:"tool_result_id,omitempty"` // For matching tool results to tool calls
 
 	// Embedded structs for grouping
 	TokenMetrics
 	CommandInfo
 
 	// Flags
-	IsSidechain     bool
-	IsError         bool
-	IsCaveatMessage bool // True if this is a special caveat message from local commands
+	IsSidechain     bool `json:"is_sidechain,omitempty"`
+	IsError         bool `json:"is_error,omitempty"`
+	IsCaveatMessage bool `json:"is_caveat_message,omitempty"` // True if this is a special caveat message from local commands
+	IsCompaction    bool `json:"is_compaction,omitempty"`     // True if this entry marks a context compaction boundary
+	IsEmpty         bool `json:"is_empty,omitempty"`          // True for assistant entries with no text, tool calls, or thinking
+
+	// Compaction details, set when IsCompaction is true
+	CompactionTrigger   string `json:"compaction_trigger,omitempty"`
+	PreCompactionTokens int    `json:"pre_compaction_tokens,omitempty"`
+
+	// Hook is set when this entry records a hook execution
+	Hook *HookEvent `json:"hook,omitempty"`
+}
+
+// SubagentTranscript is the sidechain conversation of one subagent.
+type SubagentTranscript struct {
+	AgentID   string            `json:"agent_id"`
+	AgentType string            `json:"agent_type,omitempty"` // Subagent type, or the Task description when untyped
+	Entries   []*ProcessedEntry `json:"entries"`
 }
@@ -0,0 +1,73 @@
+package models
+
+// OTLPTrace is a session exported in the OTLP/JSON trace format, as accepted
+// by an OpenTelemetry collector's /v1/traces endpoint.
+type OTLPTrace struct {
+	ResourceSpans []OTLPResourceSpans `json:"resourceSpans"`
+}
+
+// OTLPResourceSpans groups the spans produced by one resource.
+type OTLPResourceSpans struct {
+	Resource   OTLPResource     `json:"resource"`
+	ScopeSpans []OTLPScopeSpans `json:"scopeSpans"`
+}
+
+// OTLPResource describes the entity that produced the spans.
+type OTLPResource struct {
+	Attributes []OTLPAttribute `json:"attributes"`
+}
+
+// OTLPScopeSpans groups spans by instrumentation scope.
+type OTLPScopeSpans struct {
+	Scope OTLPScope  `json:"scope"`
+	Spans []OTLPSpan `json:"spans"`
+}
+
+// OTLPScope names the instrumentation that produced the spans.
+type OTLPScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// OTLPSpan is a single span. IDs are lowercase hex and times are Unix
+// nanoseconds encoded as strings, as the OTLP/JSON mapping requires.
+type OTLPSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []OTLPAttribute `json:"attributes,omitempty"`
+	Status            OTLPStatus      `json:"status"`
+}
+
+// OTLPStatus is a span's outcome.
+type OTLPStatus struct {
+	Code    int    `json:"code"` // 0 unset, 1 ok, 2 error
+	Message string `json:"message,omitempty"`
+}
+
+// OTLPAttribute is a key/value pair attached to a span or resource.
+type OTLPAttribute struct {
+	Key   string       `json:"key"`
+	Value OTLPAnyValue `json:"value"`
+}
+
+// OTLPAnyValue holds exactly one typed attribute value. Integers are encoded
+// as strings, as the OTLP/JSON mapping requires.
+type OTLPAnyValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	BoolValue   *bool   `json:"boolValue,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"`
+}
+
+// OTLP span kinds and status codes used by the exporter.
+const (
+	OTLPSpanKindInternal = 1
+
+	OTLPStatusUnset = 0
+	OTLPStatusOK    = 1
+	OTLPStatusError = 2
+)
@@ -0,0 +1,40 @@
+package models
+
+// Output file types recorded in an OutputManifest.
+const (
+	OutputTypeJSON     = "json"
+	OutputTypeHTML     = "html"
+	OutputTypeNotebook = "ipynb"
+	OutputTypeMermaid  = "mermaid"
+	OutputTypeDOT      = "dot"
+	OutputTypeScript   = "script"
+)
+
+// OutputFile is one file written by a command.
+type OutputFile struct {
+	Path  string `json:"path"`
+	Type  string `json:"type"` // One of the OutputType constants
+	Bytes int64  `json:"bytes"`
+}
+
+// OutputManifest lists the files a command wrote, so scripts can find its
+// output without parsing messages.
+type OutputManifest struct {
+	Files         []OutputFile `json:"files"`
+	OpenedBrowser bool         `json:"opened_browser"`
+}
+
+// AddFile records a written file.
+func (m *OutputManifest) AddFile(path, fileType string, bytes int64) {
+	m.Files = append(m.Files, OutputFile{Path: path, Type: fileType, Bytes: bytes})
+}
+
+// Path returns the path of the first file of the given type, or "".
+func (m *OutputManifest) Path(fileType string) string {
+	for _, f := range m.Files {
+		if f.Type == fileType {
+			return f.Path
+		}
+	}
+	return ""
+}
@@ -0,0 +1,11 @@
+package models
+
+// OutputSizeHit describes the size of a single tool result.
+type OutputSizeHit struct {
+	UUID        string `json:"uuid"` // UUID of the tool result entry
+	ToolUseID   string `json:"tool_use_id"`
+	Tool        string `json:"tool"`
+	Timestamp   string `json:"timestamp"`
+	Bytes       int    `json:"bytes"`
+	IsSidechain bool   `json:"is_sidechain,omitempty"`
+}
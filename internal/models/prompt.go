@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// PromptOccurrence represents one session whose first prompt belongs to a cluster.
+type PromptOccurrence struct {
+	SessionID  string    `json:"session_id"`
+	Project    string    `json:"project"`
+	StartTime  time.Time `json:"start_time"`
+	Prompt     string    `json:"prompt"`
+	Similarity float64   `json:"similarity"` // Similarity to the cluster's representative prompt
+}
+
+// PromptCluster represents a group of similar user prompts across sessions.
+type PromptCluster struct {
+	Prompt      string             `json:"prompt"` // Representative prompt (first seen)
+	Count       int                `json:"count"`
+	Occurrences []PromptOccurrence `json:"occurrences"`
+}
@@ -0,0 +1,33 @@
+package models
+
+// SessionFailure records a session that could not be read during a
+// cross-session operation.
+type SessionFailure struct {
+	SessionID string `json:"session_id"`
+	Project   string `json:"project"`
+	FilePath  string `json:"file_path"`
+	Error     string `json:"error"`
+}
+
+// ScanReport tells how many sessions an aggregation read and which ones failed,
+// so partial results are not mistaken for complete ones.
+type ScanReport struct {
+	SessionsScanned int              `json:"sessions_scanned"`
+	Failures        []SessionFailure `json:"failures,omitempty"`
+}
+
+// AddFailure records a session that could not be read.
+func (r *ScanReport) AddFailure(sessionID, project, filePath string, err error) {
+	r.Failures = append(r.Failures, SessionFailure{
+		SessionID: sessionID,
+		Project:   project,
+		FilePath:  filePath,
+		Error:     err.Error(),
+	})
+}
+
+// Merge adds another report's counts and failures to r.
+func (r *ScanReport) Merge(other ScanReport) {
+	r.SessionsScanned += other.SessionsScanned
+	r.Failures = append(r.Failures, other.Failures...)
+}
@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // SessionInfo represents metadata about a Claude Code session.
 type SessionInfo struct {
@@ -8,20 +11,39 @@ type SessionInfo struct {
 	Project          string    `json:"project"`
 	StartTime        time.Time `json:"start_time"`
 	EndTime          time.Time `json:"end_time"`
+	LocalStartTime   string    `json:"local_start_time,omitempty"` // StartTime in the configured time zone, if any
 	MessageCount     int       `json:"message_count"`
 	AgentTypesUsed   []string  `json:"agent_types_used,omitempty"`
 	FirstUserMessage string    `json:"first_user_message,omitempty"`
 	CWD              string    `json:"cwd,omitempty"`
+	CWDs             []string  `json:"cwds,omitempty"` // Distinct working directories in first-seen order
 	GitBranch        string    `json:"git_branch,omitempty"`
-	FilePath         string    `json:"-"` // Internal use only
+	ContinuedFrom    string    `json:"continued_from,omitempty"` // Session this one resumed or continued, if logged
+	ErrorCount       int       `json:"error_count,omitempty"`
+	Status           string    `json:"status"` // SessionStatusOK, SessionStatusTruncated, or SessionStatusEmpty
+	FilePath         string    `json:"-"`      // Internal use only
+	// Task invocations per subagent type, set with AgentTypesUsed; internal use only
+	AgentTypeCounts map[string]int `json:"-"`
+	// Session file modification time, set by RecentSessions
+	ModifiedTime *time.Time `json:"modified_time,omitempty"`
 }
 
+// Session status values reported in SessionInfo.Status.
+const (
+	SessionStatusOK        = "ok"        // Has both a user message and an assistant response
+	SessionStatusTruncated = "truncated" // Has entries but no complete user/assistant exchange
+	SessionStatusEmpty     = "empty"     // Zero-length, whitespace-only, or no parseable entries
+)
+
 // SessionLogs represents full processed logs for a session.
 type SessionLogs struct {
 	SessionID  string              `json:"session_id"`
 	Project    string              `json:"project"`
 	Entries    []SessionLogEntry   `json:"entries"`
 	TokenStats *SessionTokenStats  `json:"token_stats,omitempty"`
+	// Set only when a filter is applied
+	TotalEntries    int `json:"total_entries,omitempty"`
+	FilteredEntries int `json:"filtered_entries,omitempty"`
 }
 
 // SessionLogEntry represents a single entry in session logs.
@@ -33,13 +55,18 @@ type SessionLogEntry struct {
 	IsSidechain bool                `json:"is_sidechain,omitempty"`
 	AgentID     string              `json:"agent_id,omitempty"`
 	ToolCalls   []SessionToolCall   `json:"tool_calls,omitempty"`
+	RawUsage    json.RawMessage     `json:"raw_usage,omitempty"`    // Original usage block, when requested
+	ContentHash string              `json:"content_hash,omitempty"` // Stable content hash, when requested
+	// RFC3339 timestamp as logged; internal use only
+	RawTimestamp string `json:"-"`
 }
 
 // SessionToolCall represents a tool call in session logs.
 type SessionToolCall struct {
-	Name   string      `json:"name"`
-	Input  interface{} `json:"input,omitempty"`
-	Output string      `json:"output,omitempty"`
+	Name            string      `json:"name"`
+	Input           interface{} `json:"input,omitempty"`
+	Output          string      `json:"output,omitempty"`
+	OutputTruncated bool        `json:"output_truncated,omitempty"`
 }
 
 // SessionTokenStats represents token usage statistics.
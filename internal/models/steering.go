@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// SteeringCounts tallies how often the user had to redirect Claude.
+type SteeringCounts struct {
+	UserMessages      int `json:"user_messages"`
+	Interruptions     int `json:"interruptions"`       // "[Request interrupted by user]" markers
+	RejectedToolCalls int `json:"rejected_tool_calls"` // Tool uses the user declined to run
+	Followups         int `json:"followups"`           // Short continuations such as "yes" or "continue"
+}
+
+// Total returns the number of steering events.
+func (c SteeringCounts) Total() int {
+	return c.Interruptions + c.RejectedToolCalls + c.Followups
+}
+
+// SessionSteering holds steering counts for one session.
+type SessionSteering struct {
+	SessionID string    `json:"session_id"`
+	StartTime time.Time `json:"start_time"`
+	SteeringCounts
+}
+
+// SteeringStats aggregates steering counts across a project's sessions.
+type SteeringStats struct {
+	Project  string            `json:"project"`
+	Days     int               `json:"days,omitempty"`
+	Sessions []SessionSteering `json:"sessions"`
+	Total    SteeringCounts    `json:"total"`
+	ScanReport
+}
@@ -6,6 +6,12 @@ type TokenStats struct {
 	TotalOutput   int `json:"total_output"`
 	CacheRead     int `json:"cache_read"`
 	CacheCreation int `json:"cache_creation"`
+	// Assistant output split by whether its response made tool calls
+	TextOutputTokens int `json:"text_output_tokens"`
+	ToolOutputTokens int `json:"tool_output_tokens"`
+	// Reasoning tokens from thinking blocks, not included in TotalOutput
+	ThinkingTokens          int  `json:"thinking_tokens"`
+	ThinkingTokensEstimated bool `json:"thinking_tokens_estimated,omitempty"` // Some counts were estimated from thinking text
 }
 
 // ToolCallStats represents tool call statistics.
@@ -14,6 +20,12 @@ type ToolCallStats struct {
 	UniqueTools int `json:"unique_tools"`
 	Success     int `json:"success"`
 	Failed      int `json:"failed"`
+	// NoiseCalls counts calls to configured noise tools, which the fields above leave out
+	NoiseCalls int `json:"noise_calls,omitempty"`
+	// Calls split by tool access; unknown covers MCP and other unclassified tools
+	Mutating      int `json:"mutating"`
+	ReadOnly      int `json:"read_only"`
+	UnknownAccess int `json:"unknown_access"`
 }
 
 // SidechainStats represents sidechain/subagent statistics.
@@ -22,29 +34,94 @@ type SidechainStats struct {
 	AgentTypes []string `json:"agent_types"`
 }
 
+// AgentTokenUsage is the token usage of one thread of a session: the main
+// conversation, or one subagent.
+type AgentTokenUsage struct {
+	AgentID       string  `json:"agent_id"`             // Empty for the main thread
+	AgentType     string  `json:"agent_type,omitempty"` // Subagent type, or the Task description when untyped
+	Messages      int     `json:"messages"`             // Assistant messages
+	InputTokens   int     `json:"input_tokens"`
+	OutputTokens  int     `json:"output_tokens"`
+	CacheRead     int     `json:"cache_read"`
+	CacheCreation int     `json:"cache_creation"`
+	TotalTokens   int     `json:"total_tokens"`
+	Percent       float64 `json:"percent"` // Share of the session's total tokens
+}
+
 // SessionSummary is a lightweight overview of a session.
 type SessionSummary struct {
-	SessionID       string          `json:"session_id"`
-	AgentID         *string         `json:"agent_id"`
-	Project         string          `json:"project"`
-	Date            string          `json:"date"`
-	DurationMinutes int             `json:"duration_minutes"`
-	MessageCount    int             `json:"message_count"`
-	UserMessages    int             `json:"user_messages"`
-	AssistantMsgs   int             `json:"assistant_messages"`
-	Tokens          *TokenStats     `json:"tokens"`
-	ToolCalls       *ToolCallStats  `json:"tool_calls"`
-	Sidechains      *SidechainStats `json:"sidechains"`
-	HasErrors       bool            `json:"has_errors"`
-	ErrorCount      int             `json:"error_count"`
+	SessionID                 string            `json:"session_id"`
+	AgentID                   *string           `json:"agent_id"`
+	Project                   string            `json:"project"`
+	Date                      string            `json:"date"`
+	DurationMinutes           int               `json:"duration_minutes"`
+	MessageCount              int               `json:"message_count"`
+	UserMessages              int               `json:"user_messages"`
+	UserPrompts               int               `json:"user_prompts"` // Typed user prompts (tool results excluded)
+	AssistantMsgs             int               `json:"assistant_messages"`
+	EmptyAssistantMessages    int               `json:"empty_assistant_messages"`     // Assistant entries with no text, tool calls, or thinking
+	AvgUserMessageLength      float64           `json:"avg_user_message_length"`      // Mean characters per typed user prompt (tool results excluded)
+	AvgAssistantMessageLength float64           `json:"avg_assistant_message_length"` // Mean characters of text per assistant reply that has text
+	UserAssistantRatio        float64           `json:"user_assistant_ratio"`         // Typed user prompts per assistant message
+	Tokens                    *TokenStats       `json:"tokens"`
+	TokensPerMinute           float64           `json:"tokens_per_minute"` // All tokens (input, output, cache) over session duration
+	ToolCalls                 *ToolCallStats    `json:"tool_calls"`
+	Sidechains                *SidechainStats   `json:"sidechains"`
+	AgentTokens               []AgentTokenUsage `json:"agent_tokens,omitempty"` // Token usage of the main thread and each subagent
+	HasErrors                 bool              `json:"has_errors"`
+	ErrorCount                int               `json:"error_count"`
+	CompactionCount           int               `json:"compaction_count"`
+	Compactions               []CompactionEvent `json:"compactions,omitempty"`
+	LongestToolFreeStretch    *ToolFreeStretch  `json:"longest_tool_free_stretch,omitempty"` // Longest run of assistant turns without tool calls
+	BranchSwitches            []BranchSwitch    `json:"branch_switches,omitempty"`           // Git branch changes between entries
+	RetriedToolCalls          int               `json:"retried_tool_calls"`                  // Calls repeating the previous call exactly, or its tool after a failure
+	Interruptions             int               `json:"interruptions"`                       // User interruptions in the main conversation
+	HeavyReads                int               `json:"heavy_reads"`                         // Read calls with a large or binary result
+	HealthScore               int               `json:"health_score"`                        // 0-100 smoothness score from errors, failures, retries, and interruptions
+	ExcludedAgents            *AgentExclusion   `json:"excluded_agents,omitempty"`           // Set when subagents were left out of the counts
+	Commits                   []GitCommit       `json:"commits,omitempty"`                   // Set only when git correlation is requested
+}
+
+// CompactionEvent represents a point where the context window was compacted.
+type CompactionEvent struct {
+	UUID       string `json:"uuid"`
+	Timestamp  string `json:"timestamp"`
+	Trigger    string `json:"trigger,omitempty"` // "auto" or "manual"
+	PreTokens  int    `json:"pre_tokens"`        // Context size before compaction
+	PostTokens int    `json:"post_tokens"`       // Context size of the first turn after compaction
+}
+
+// ToolFreeStretch is a run of consecutive assistant turns in the main
+// conversation that made no tool calls.
+type ToolFreeStretch struct {
+	Turns          int    `json:"turns"`
+	StartStep      int    `json:"start_step"` // Timeline step of the first turn
+	EndStep        int    `json:"end_step"`   // Timeline step of the last turn
+	StartUUID      string `json:"start_uuid"`
+	EndUUID        string `json:"end_uuid"`
+	OutputTokens   int    `json:"output_tokens"`
+	ThinkingTokens int    `json:"thinking_tokens,omitempty"`
+}
+
+// BranchSwitch is a change of git branch between consecutive entries.
+type BranchSwitch struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Timestamp string `json:"timestamp"`
+	UUID      string `json:"uuid"` // First entry recorded on the new branch
 }
 
 // ToolUsageStat represents usage statistics for a single tool.
 type ToolUsageStat struct {
-	Name    string `json:"name"`
-	Count   int    `json:"count"`
-	Success int    `json:"success"`
-	Failed  int    `json:"failed"`
+	Name     string `json:"name"`
+	Count    int    `json:"count"`
+	Success  int    `json:"success"`
+	Failed   int    `json:"failed"`
+	Access   string `json:"access"`   // "read", "write", or "unknown" (see constants.ToolAccess)
+	Mutating bool   `json:"mutating"` // Access is "write"
+	// Percentage is Count as a share of the headline (non-noise) tool calls;
+	// for noise tools, of all tool calls
+	Percentage float64 `json:"percentage"`
 }
 
 // ToolPatterns represents patterns in tool usage.
@@ -57,8 +134,11 @@ type ToolPatterns struct {
 
 // ToolSequenceEntry represents a single tool in the execution sequence.
 type ToolSequenceEntry struct {
-	Name      string `json:"name"`
-	ToolUseID string `json:"tool_use_id"`
+	Name          string `json:"name"`
+	ToolUseID     string `json:"tool_use_id"`
+	UUID          string `json:"uuid,omitempty"` // Entry that made the call
+	ParallelGroup int    `json:"parallel_group"` // Calls sharing a group were issued in the same turn
+	GroupSize     int    `json:"group_size"`
 }
 
 // ToolUsageStats represents tool usage statistics for a session.
@@ -66,8 +146,30 @@ type ToolUsageStats struct {
 	SessionID    string              `json:"session_id"`
 	AgentID      *string             `json:"agent_id"`
 	Tools        []ToolUsageStat     `json:"tools"`
+	NoiseTools   []ToolUsageStat     `json:"noise_tools,omitempty"` // Configured noise tools, kept out of Tools and Patterns
 	ToolSequence []ToolSequenceEntry `json:"tool_sequence"`
 	Patterns     *ToolPatterns       `json:"patterns"`
+	// Tool names in call order joined by ">", e.g. "Read>Edit>Edit>Bash"
+	SequenceSignature string `json:"sequence_signature"`
+	// SequenceSignature with consecutive repeats collapsed, e.g. "Read>Edit>Bash"
+	CollapsedSignature string `json:"collapsed_signature"`
+	// Set when subagents were left out of the counts
+	ExcludedAgents *AgentExclusion `json:"excluded_agents,omitempty"`
+}
+
+// AgentExclusion reports subagents left out of summary or tool stats.
+type AgentExclusion struct {
+	AgentIDs       []string `json:"agent_ids"`
+	EntriesDropped int      `json:"entries_dropped"`
+}
+
+// TotalCalls returns the number of tool calls across all tools.
+func (s *ToolUsageStats) TotalCalls() int {
+	total := 0
+	for _, t := range s.Tools {
+		total += t.Count
+	}
+	return total
 }
 
 // ContextLog represents a log entry surrounding an error for context.
@@ -82,6 +184,8 @@ type ContextLog struct {
 	ToolOutput   string      `json:"tool_output,omitempty"`   // Tool result/output
 	IsToolResult bool        `json:"is_tool_result,omitempty"`
 	IsError      bool        `json:"is_error,omitempty"`
+	LocalTime    string      `json:"local_time,omitempty"`   // RFC3339 in the configured time zone, if any
+	ContentHash  string      `json:"content_hash,omitempty"` // Stable content hash, when requested
 }
 
 // SessionError represents a single error entry.
@@ -110,6 +214,9 @@ type SessionErrors struct {
 	TotalErrors int              `json:"total_errors"`
 	Errors      []SessionError   `json:"errors"`
 	Categories  *ErrorCategories `json:"categories"`
+	// RootCause is the earliest error followed by a run of related failures (heuristic)
+	RootCause       *SessionError `json:"root_cause,omitempty"`
+	RootCauseReason string        `json:"root_cause_reason,omitempty"`
 }
 
 // TimelineEntry represents a single entry in the session timeline.
@@ -124,6 +231,26 @@ type TimelineEntry struct {
 	Status    string `json:"status,omitempty"`
 	Tokens    int    `json:"tokens,omitempty"`
 	Sidechain string `json:"sidechain,omitempty"`
+	Followup  bool   `json:"followup,omitempty"` // Short user continuation such as "yes" or "continue"
+	LocalTime string `json:"local_time,omitempty"` // RFC3339 in the configured time zone, if any
+	// ParallelGroup is set on "parallel_tools" entries; entries sharing it ran concurrently
+	ParallelGroup int `json:"parallel_group,omitempty"`
+	// Cost is the step's estimated USD cost, set only when a priced model was requested.
+	// A turn with several tool calls carries its cost on the first call only.
+	Cost float64 `json:"cost,omitempty"`
+	// TokenBreakdown is set only when token details are requested, and like Cost
+	// appears on the first call of a turn with several tool calls.
+	TokenBreakdown *TokenBreakdown `json:"token_breakdown,omitempty"`
+	// RFC3339 timestamp as logged; internal use only
+	RawTimestamp string `json:"-"`
+}
+
+// TokenBreakdown splits the tokens of one timeline step by kind.
+type TokenBreakdown struct {
+	Input         int `json:"input"`
+	Output        int `json:"output"`
+	CacheRead     int `json:"cache_read"`
+	CacheCreation int `json:"cache_creation"`
 }
 
 // SessionTimeline represents a condensed timeline of session events.
@@ -132,6 +259,9 @@ type SessionTimeline struct {
 	AgentID         *string         `json:"agent_id"`
 	TotalEntries    int             `json:"total_entries"`
 	ReturnedEntries int             `json:"returned_entries"`
+	FilteredEntries int             `json:"filtered_entries,omitempty"` // Entries matching a filter, before the limit
+	CostModel       string          `json:"cost_model,omitempty"`       // Model whose rates priced each step
+	TotalCost       float64         `json:"total_cost,omitempty"`       // Sum of step costs in the timeline
 	Timeline        []TimelineEntry `json:"timeline"`
 }
 
@@ -146,21 +276,56 @@ type LogsAroundEntry struct {
 	TotalCount  int          `json:"total_count"`     // Total entries in session
 }
 
-// OutputFiles represents paths to generated output files.
-type OutputFiles struct {
-	JSONPath      string `json:"json_path"`
-	HTMLPath      string `json:"html_path,omitempty"`
-	OpenedBrowser bool   `json:"opened_browser"`
-}
-
 // SessionStats is the aggregated session statistics.
 type SessionStats struct {
 	SessionID   string          `json:"session_id"`
 	AgentID     *string         `json:"agent_id"`
 	Project     string          `json:"project"`
 	GeneratedAt string          `json:"generated_at"`
-	Files       *OutputFiles    `json:"files,omitempty"`
+	Files       *OutputManifest `json:"files,omitempty"`
 	Summary     *SessionSummary `json:"summary"`
 	ToolStats   *ToolUsageStats `json:"tool_stats"`
 	Errors      *SessionErrors  `json:"errors"`
+	// Combined size of all tool result content, including subagent results
+	TotalToolOutputBytes int `json:"total_tool_output_bytes"`
+	// Per-file summaries when stats were merged across several files and a breakdown was requested
+	Sources []SourceStats `json:"sources,omitempty"`
+}
+
+// SourceStats is one file's share of stats merged across several files.
+type SourceStats struct {
+	Path    string          `json:"path"`
+	Summary *SessionSummary `json:"summary"`
+}
+
+// ContextSizePoint represents the context window size at a single assistant turn.
+type ContextSizePoint struct {
+	Step          int    `json:"step"`
+	UUID          string `json:"uuid"`
+	Timestamp     string `json:"timestamp"`
+	ContextTokens int    `json:"context_tokens"` // Input + cache read + cache creation tokens
+	Delta         int    `json:"delta"`          // Change from the previous turn
+	IsMax         bool   `json:"is_max,omitempty"`
+}
+
+// ContextSizeHistory represents how the context window grew over a session.
+type ContextSizeHistory struct {
+	SessionID   string             `json:"session_id"`
+	AgentID     *string            `json:"agent_id"`
+	Points      []ContextSizePoint `json:"points"`
+	MaxTokens   int                `json:"max_tokens"`
+	MaxStep     int                `json:"max_step"`
+	FinalTokens int                `json:"final_tokens"`
+}
+
+// ContextBudget represents how much of a model's context window a session has left.
+type ContextBudget struct {
+	SessionID       string  `json:"session_id"`
+	Model           string  `json:"model"`
+	ContextWindow   int     `json:"context_window"`
+	UsedTokens      int     `json:"used_tokens"` // Context size of the last main-thread assistant turn
+	RemainingTokens int     `json:"remaining_tokens"`
+	UsedPercent     float64 `json:"used_percent"`
+	Warning         bool    `json:"warning"` // True once UsedPercent reaches WarnPercent
+	WarnPercent     float64 `json:"warn_percent"`
 }
@@ -0,0 +1,12 @@
+package models
+
+// SessionTextExport is a compact plain-text transcript of a session, trimmed to fit a token budget.
+type SessionTextExport struct {
+	SessionID       string `json:"session_id"`
+	Text            string `json:"text"`
+	EstimatedTokens int    `json:"estimated_tokens"`
+	MaxTokens       int    `json:"max_tokens,omitempty"`
+	Strategy        string `json:"strategy,omitempty"` // How turns were dropped to fit MaxTokens
+	TotalTurns      int    `json:"total_turns"`
+	OmittedTurns    int    `json:"omitted_turns,omitempty"`
+}
@@ -0,0 +1,20 @@
+package models
+
+// TodoItem represents a single task in a TodoWrite list.
+type TodoItem struct {
+	Content        string `json:"content"`
+	Status         string `json:"status"`
+	ActiveForm     string `json:"active_form,omitempty"`
+	IsNew          bool   `json:"is_new,omitempty"`          // Not present in the previous snapshot
+	NewlyCompleted bool   `json:"newly_completed,omitempty"` // Completed since the previous snapshot
+}
+
+// TodoSnapshot represents the task list captured by one TodoWrite invocation.
+type TodoSnapshot struct {
+	Step           int        `json:"step"`
+	UUID           string     `json:"uuid"`
+	Timestamp      string     `json:"timestamp"`
+	Items          []TodoItem `json:"items"`
+	AddedCount     int        `json:"added_count"`
+	CompletedCount int        `json:"completed_count"`
+}
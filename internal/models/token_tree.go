@@ -0,0 +1,20 @@
+package models
+
+// Token tree node kinds.
+const (
+	TokenNodeSession = "session" // Root: the main conversation
+	TokenNodeAgent   = "agent"   // A subagent conversation started by a Task call
+	TokenNodeTool    = "tool"    // Turns that drove a tool, grouped by tool name
+	TokenNodeText    = "text"    // Turns without tool calls
+)
+
+// TokenTree attributes a session's tokens hierarchically, e.g. for a treemap.
+// Tokens is SelfTokens plus the Tokens of all children.
+type TokenTree struct {
+	Name       string       `json:"name"`
+	Kind       string       `json:"kind"`
+	AgentID    string       `json:"agent_id,omitempty"`
+	Tokens     int          `json:"tokens"`
+	SelfTokens int          `json:"self_tokens"`
+	Children   []*TokenTree `json:"children,omitempty"`
+}
@@ -4,16 +4,25 @@ import "html/template"
 
 // ToolCall represents a tool invocation with its result.
 type ToolCall struct {
-	ID                  string
-	Name                string
-	Description         string
-	Input               template.HTML
-	RawInput            interface{}       // Raw input data before formatting
-	CompactView         template.HTML     // Optional compact view for specific tools
-	Result              *ProcessedEntry   // Tool result entry
-	TaskEntries         []*ProcessedEntry // For Task tool - sidechain entries
-	IsInterrupted       bool              // Whether the tool was interrupted by the user
-	HasMissingResult    bool              // Whether the tool result is missing
-	HasMissingSidechain bool              // Whether Task tool sidechain conversation is missing
-	CWD                 string            // Current working directory when the tool was called
+	ID                  string            `json:"id"`
+	Name                string            `json:"name"`
+	Description         string            `json:"description,omitempty"`
+	Input               template.HTML     `json:"input,omitempty"`
+	RawInput            interface{}       `json:"raw_input,omitempty"`             // Raw input data before formatting
+	CompactView         template.HTML     `json:"compact_view,omitempty"`          // Optional compact view for specific tools
+	Result              *ProcessedEntry   `json:"result,omitempty"`                // Tool result entry
+	TaskEntries         []*ProcessedEntry `json:"task_entries,omitempty"`          // For Task tool - sidechain entries
+	IsInterrupted       bool              `json:"is_interrupted,omitempty"`        // Whether the tool was interrupted by the user
+	HasMissingResult    bool              `json:"has_missing_result,omitempty"`    // Whether the tool result is missing
+	HasMissingSidechain bool              `json:"has_missing_sidechain,omitempty"` // Whether Task tool sidechain conversation is missing
+	CWD                 string            `json:"cwd,omitempty"`                   // Current working directory when the tool was called
+	ParallelGroup       int               `json:"parallel_group,omitempty"`        // 1-based index of the assistant turn that issued this call
+	ParallelGroupSize   int               `json:"parallel_group_size,omitempty"`   // Number of tool calls issued together in that turn (1 if sequential)
+}
+
+// ToolNameCount is a tool name with how often it was called across sessions.
+type ToolNameCount struct {
+	Name     string `json:"name"` // As logged, including any "mcp__server__" prefix
+	Count    int    `json:"count"`
+	Sessions int    `json:"sessions"` // Sessions that called the tool at least once
 }
@@ -0,0 +1,34 @@
+package models
+
+// Edge kinds in a ToolGraph.
+const (
+	ToolGraphEdgeFile     = "file"     // Both calls touched the same file
+	ToolGraphEdgeSequence = "sequence" // The calls ran back to back in one conversation
+)
+
+// ToolGraph shows how a session's tool calls feed into each other. Edges are
+// inferred heuristically and are an analysis aid, not exact provenance.
+type ToolGraph struct {
+	SessionID string          `json:"session_id"`
+	Nodes     []ToolGraphNode `json:"nodes"`
+	Edges     []ToolGraphEdge `json:"edges"`
+}
+
+// ToolGraphNode is a single tool call.
+type ToolGraphNode struct {
+	ID          string   `json:"id"` // tool_use ID
+	Tool        string   `json:"tool"`
+	Timestamp   string   `json:"timestamp"`
+	Summary     string   `json:"summary"`
+	Files       []string `json:"files,omitempty"` // Paths the call read, wrote, or referenced
+	Failed      bool     `json:"failed,omitempty"`
+	IsSidechain bool     `json:"is_sidechain,omitempty"`
+}
+
+// ToolGraphEdge links an earlier tool call to a later one.
+type ToolGraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Kind string `json:"kind"`           // ToolGraphEdgeFile or ToolGraphEdgeSequence
+	File string `json:"file,omitempty"` // Shared path for file edges
+}
@@ -0,0 +1,11 @@
+package models
+
+// URLHit describes a URL seen in WebFetch or WebSearch tool calls, merged across repeat visits.
+type URLHit struct {
+	URL         string   `json:"url"`
+	Tools       []string `json:"tools"` // Tools that referenced the URL, in first-seen order
+	Count       int      `json:"count"`
+	FirstSeen   string   `json:"first_seen"`
+	LastSeen    string   `json:"last_seen"`
+	IsSidechain bool     `json:"is_sidechain,omitempty"` // True if every access came from a subagent
+}
@@ -7,16 +7,42 @@ import (
 	"path/filepath"
 	"log"
 	"math"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/brads3290/cclogviewer/internal/constants"
 	"github.com/brads3290/cclogviewer/internal/debug"
 	"github.com/brads3290/cclogviewer/internal/models"
 )
 
+// Options bounds how much of a session ReadJSONLFileWithOptions loads.
+// The zero value loads everything.
+type Options struct {
+	// MaxSubagentFiles caps how many subagent files are loaded. 0 is unlimited.
+	MaxSubagentFiles int
+	// MaxSubagentBytes caps the combined size of the subagent files loaded. 0 is unlimited.
+	MaxSubagentBytes int64
+}
+
+// limited reports whether any subagent cap is set.
+func (o Options) limited() bool {
+	return o.MaxSubagentFiles > 0 || o.MaxSubagentBytes > 0
+}
+
 // ReadJSONLFile reads a JSONL file and returns a slice of LogEntry.
 // It also automatically loads any subagent files from the {session_id}/subagents/ directory.
+// Entries present both inline and in a subagent file are returned once.
 func ReadJSONLFile(filename string) ([]models.LogEntry, error) {
+	return ReadJSONLFileWithOptions(filename, Options{})
+}
+
+// ReadJSONLFileWithOptions is ReadJSONLFile with caps on subagent loading.
+// When a cap is set, the most recently modified subagent files are loaded
+// first and a warning is logged for any left out.
+func ReadJSONLFileWithOptions(filename string, opts Options) ([]models.LogEntry, error) {
+	start := time.Now()
+
 	// Read main session file
 	entries, err := readSingleJSONLFile(filename)
 	if err != nil {
@@ -24,7 +50,7 @@ func ReadJSONLFile(filename string) ([]models.LogEntry, error) {
 	}
 
 	// Try to load subagent files
-	subagentEntries, err := loadSubagentFiles(filename)
+	subagentEntries, subagentFiles, err := loadSubagentFiles(filename, opts)
 	if err != nil {
 		if debug.Enabled {
 			log.Printf("Note: Could not load subagent files: %v", err)
@@ -34,12 +60,34 @@ func ReadJSONLFile(filename string) ([]models.LogEntry, error) {
 		if debug.Enabled {
 			log.Printf("Loaded %d entries from subagent files", len(subagentEntries))
 		}
-		entries = append(entries, subagentEntries...)
+		entries = dedupeByUUID(append(entries, subagentEntries...))
 	}
 
+	debug.Event("parse", "file", filename, "entries", len(entries), "subagent_files", subagentFiles, "ms", time.Since(start))
 	return entries, nil
 }
 
+// dedupeByUUID drops entries whose UUID was already seen, keeping the first
+// occurrence. Sessions from the transition between the inline sidechain format
+// and the subagent file format can carry the same sidechain entries in both.
+func dedupeByUUID(entries []models.LogEntry) []models.LogEntry {
+	seen := make(map[string]bool, len(entries))
+	result := entries[:0]
+	for _, entry := range entries {
+		if entry.UUID != "" {
+			if seen[entry.UUID] {
+				if debug.Enabled {
+					log.Printf("Skipping duplicate entry %s", entry.UUID)
+				}
+				continue
+			}
+			seen[entry.UUID] = true
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
 // readSingleJSONLFile reads a single JSONL file and returns a slice of LogEntry
 func readSingleJSONLFile(filename string) ([]models.LogEntry, error) {
 	file, err := os.Open(filename)
@@ -85,7 +133,8 @@ func readSingleJSONLFile(filename string) ([]models.LogEntry, error) {
 
 // loadSubagentFiles loads all subagent files from the {session_id}/subagents/ directory.
 // In newer Claude Code versions, subagent/sidechain logs are stored in separate files.
-func loadSubagentFiles(mainSessionFile string) ([]models.LogEntry, error) {
+// Also returns how many subagent files were read.
+func loadSubagentFiles(mainSessionFile string, opts Options) ([]models.LogEntry, int, error) {
 	// Extract session ID from filename (e.g., "abc123.jsonl" -> "abc123")
 	baseName := filepath.Base(mainSessionFile)
 	sessionID := strings.TrimSuffix(baseName, filepath.Ext(baseName))
@@ -99,12 +148,12 @@ func loadSubagentFiles(mainSessionFile string) ([]models.LogEntry, error) {
 	if err != nil {
 		if os.IsNotExist(err) {
 			// No subagents directory - this is normal for older sessions
-			return nil, nil
+			return nil, 0, nil
 		}
-		return nil, err
+		return nil, 0, err
 	}
 	if !info.IsDir() {
-		return nil, nil
+		return nil, 0, nil
 	}
 
 	if debug.Enabled {
@@ -115,14 +164,19 @@ func loadSubagentFiles(mainSessionFile string) ([]models.LogEntry, error) {
 	pattern := filepath.Join(subagentsDir, "agent-*.jsonl")
 	matches, err := filepath.Glob(pattern)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	if debug.Enabled {
 		log.Printf("Found %d subagent files", len(matches))
 	}
 
+	if opts.limited() {
+		matches = capSubagentFiles(matches, opts)
+	}
+
 	var allEntries []models.LogEntry
+	loaded := 0
 	for _, agentFile := range matches {
 		entries, err := readSingleJSONLFile(agentFile)
 		if err != nil {
@@ -135,7 +189,49 @@ func loadSubagentFiles(mainSessionFile string) ([]models.LogEntry, error) {
 			log.Printf("Loaded %d entries from %s", len(entries), filepath.Base(agentFile))
 		}
 		allEntries = append(allEntries, entries...)
+		loaded++
+	}
+
+	return allEntries, loaded, nil
+}
+
+// capSubagentFiles orders subagent files newest first and keeps those within
+// the caps, logging a warning when some are left out.
+func capSubagentFiles(files []string, opts Options) []string {
+	type subagentFile struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+
+	candidates := make([]subagentFile, 0, len(files))
+	for _, path := range files {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		candidates = append(candidates, subagentFile{path: path, size: info.Size(), modTime: info.ModTime()})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].modTime.After(candidates[j].modTime)
+	})
+
+	var kept []string
+	var total int64
+	for _, f := range candidates {
+		if opts.MaxSubagentFiles > 0 && len(kept) >= opts.MaxSubagentFiles {
+			break
+		}
+		if opts.MaxSubagentBytes > 0 && total+f.size > opts.MaxSubagentBytes {
+			break
+		}
+		kept = append(kept, f.path)
+		total += f.size
+	}
+
+	if skipped := len(candidates) - len(kept); skipped > 0 {
+		log.Printf("Warning: subagent cap reached, skipped %d of %d subagent files (%d loaded, %d bytes)", skipped, len(candidates), len(kept), total)
 	}
 
-	return allEntries, nil
+	return kept
 }
@@ -1,11 +1,16 @@
 package parser
 
 import (
+	"bytes"
+	"log"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/brads3290/cclogviewer/internal/debug"
+	"github.com/brads3290/cclogviewer/internal/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -185,4 +190,117 @@ func getTestDataDir(t *testing.T) string {
 	
 	t.Fatal("Could not find testdata directory")
 	return ""
-}
\ No newline at end of file
+}
+func TestReadJSONLFile_DedupesInlineAndSubagentEntries(t *testing.T) {
+	dir := t.TempDir()
+	sessionID := "11111111-2222-3333-4444-555555555555"
+	mainFile := filepath.Join(dir, sessionID+".jsonl")
+
+	// Old format: the sidechain is inline in the main session file
+	mainContent := `{"uuid":"msg-001","type":"user","timestamp":"2024-01-01T10:00:00Z","message":{"role":"user","content":"Start"}}
+{"uuid":"side-001","type":"user","isSidechain":true,"timestamp":"2024-01-01T10:00:01Z","message":{"role":"user","content":"Agent prompt"}}
+{"uuid":"side-002","type":"assistant","isSidechain":true,"timestamp":"2024-01-01T10:00:02Z","message":{"role":"assistant","content":[{"type":"text","text":"Agent answer"}]}}
+`
+	require.NoError(t, os.WriteFile(mainFile, []byte(mainContent), 0644))
+
+	// New format: the same sidechain, plus one new entry, in a subagent file
+	subagentsDir := filepath.Join(dir, sessionID, "subagents")
+	require.NoError(t, os.MkdirAll(subagentsDir, 0755))
+	agentContent := `{"uuid":"side-001","type":"user","isSidechain":true,"timestamp":"2024-01-01T10:00:01Z","message":{"role":"user","content":"Agent prompt"}}
+{"uuid":"side-002","type":"assistant","isSidechain":true,"timestamp":"2024-01-01T10:00:02Z","message":{"role":"assistant","content":[{"type":"text","text":"Agent answer"}]}}
+{"uuid":"side-003","type":"assistant","isSidechain":true,"timestamp":"2024-01-01T10:00:03Z","message":{"role":"assistant","content":[{"type":"text","text":"Follow-up"}]}}
+`
+	require.NoError(t, os.WriteFile(filepath.Join(subagentsDir, "agent-abc.jsonl"), []byte(agentContent), 0644))
+
+	entries, err := ReadJSONLFile(mainFile)
+	require.NoError(t, err)
+
+	var uuids []string
+	for _, entry := range entries {
+		uuids = append(uuids, entry.UUID)
+	}
+	assert.Equal(t, []string{"msg-001", "side-001", "side-002", "side-003"}, uuids)
+}
+
+func TestReadJSONLFile_DebugTiming(t *testing.T) {
+	dir := t.TempDir()
+	sessionID := "11111111-2222-3333-4444-555555555555"
+	mainFile := filepath.Join(dir, sessionID+".jsonl")
+	require.NoError(t, os.WriteFile(mainFile, []byte(`{"uuid":"msg-001","type":"user","timestamp":"2024-01-01T10:00:00Z","message":{"role":"user","content":"Start"}}`+"\n"), 0644))
+
+	subagentsDir := filepath.Join(dir, sessionID, "subagents")
+	require.NoError(t, os.MkdirAll(subagentsDir, 0755))
+	for _, name := range []string{"agent-a.jsonl", "agent-b.jsonl"} {
+		require.NoError(t, os.WriteFile(filepath.Join(subagentsDir, name), []byte(`{"uuid":"`+name+`","type":"user","isSidechain":true,"timestamp":"2024-01-01T10:00:01Z","message":{"role":"user","content":"Agent prompt"}}`+"\n"), 0644))
+	}
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	debug.Enabled = true
+	defer func() {
+		log.SetOutput(os.Stderr)
+		debug.Enabled = false
+	}()
+
+	_, err := ReadJSONLFile(mainFile)
+	require.NoError(t, err)
+
+	assert.Regexp(t, `event=parse file=\S+ entries=3 subagent_files=2 ms=\d+\.\d{3}`, logs.String())
+}
+
+func TestReadJSONLFileWithOptions_SubagentCap(t *testing.T) {
+	dir := t.TempDir()
+	sessionID := "11111111-2222-3333-4444-555555555555"
+	mainFile := filepath.Join(dir, sessionID+".jsonl")
+	require.NoError(t, os.WriteFile(mainFile, []byte(`{"uuid":"msg-001","type":"user","timestamp":"2024-01-01T10:00:00Z","message":{"role":"user","content":"Start"}}`+"\n"), 0644))
+
+	// agent-a is the oldest file and agent-c the newest
+	subagentsDir := filepath.Join(dir, sessionID, "subagents")
+	require.NoError(t, os.MkdirAll(subagentsDir, 0755))
+	base := time.Now().Add(-time.Hour)
+	for i, name := range []string{"agent-a", "agent-b", "agent-c"} {
+		path := filepath.Join(subagentsDir, name+".jsonl")
+		require.NoError(t, os.WriteFile(path, []byte(`{"uuid":"`+name+`","type":"user","isSidechain":true,"timestamp":"2024-01-01T10:00:01Z","message":{"role":"user","content":"Agent prompt"}}`+"\n"), 0644))
+		modTime := base.Add(time.Duration(i) * time.Minute)
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+
+	uuids := func(entries []models.LogEntry) []string {
+		var ids []string
+		for _, entry := range entries {
+			ids = append(ids, entry.UUID)
+		}
+		return ids
+	}
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	t.Run("unlimited by default", func(t *testing.T) {
+		logs.Reset()
+		entries, err := ReadJSONLFileWithOptions(mainFile, Options{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"msg-001", "agent-a", "agent-b", "agent-c"}, uuids(entries))
+		assert.Empty(t, logs.String())
+	})
+
+	t.Run("file cap keeps the newest", func(t *testing.T) {
+		logs.Reset()
+		entries, err := ReadJSONLFileWithOptions(mainFile, Options{MaxSubagentFiles: 2})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"msg-001", "agent-c", "agent-b"}, uuids(entries))
+		assert.Contains(t, logs.String(), "skipped 1 of 3 subagent files")
+	})
+
+	t.Run("byte cap", func(t *testing.T) {
+		info, err := os.Stat(filepath.Join(subagentsDir, "agent-c.jsonl"))
+		require.NoError(t, err)
+
+		logs.Reset()
+		entries, err := ReadJSONLFileWithOptions(mainFile, Options{MaxSubagentBytes: info.Size()})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"msg-001", "agent-c"}, uuids(entries))
+		assert.Contains(t, logs.String(), "skipped 2 of 3 subagent files")
+	})
+}
@@ -0,0 +1,159 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// sessionFilePattern matches main session files, named by session UUID.
+var sessionFilePattern = regexp.MustCompile(`^([0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})\.jsonl$`)
+
+// SessionIDFromFilename returns the session ID of a main session file name
+// such as "<uuid>.jsonl". ok is false for any other file, including subagent
+// logs.
+func SessionIDFromFilename(name string) (sessionID string, ok bool) {
+	matches := sessionFilePattern.FindStringSubmatch(name)
+	if len(matches) != 2 {
+		return "", false
+	}
+	return matches[1], true
+}
+
+// ErrorPolicy decides what WalkSessions does when a session cannot be read or
+// its callback returns an error.
+type ErrorPolicy int
+
+const (
+	// StopOnError ends the walk and returns the first error.
+	StopOnError ErrorPolicy = iota
+	// SkipOnError reports the error to WalkOptions.OnSkip and moves on.
+	SkipOnError
+)
+
+// WalkFunc is called with each session's entries. project is the encoded
+// project directory name.
+type WalkFunc func(project, sessionID string, entries []models.LogEntry) error
+
+// WalkOptions configures WalkSessions. The zero value parses one session at a
+// time and stops at the first error.
+type WalkOptions struct {
+	// Concurrency is how many sessions are parsed and handled at once, and so
+	// bounds how many are held in memory. Values below 1 mean 1.
+	Concurrency int
+	// OnError is the policy for unreadable sessions and callback errors.
+	OnError ErrorPolicy
+	// OnSkip, if set, is told about each error skipped under SkipOnError.
+	// sessionID is empty when a whole project directory could not be read.
+	// Calls are serialized.
+	OnSkip func(project, sessionID string, err error)
+	// Parse bounds subagent loading for each session.
+	Parse Options
+}
+
+// WalkSessions parses every session under claudeDir/projects and passes its
+// entries, subagent entries included, to fn. Sessions are handed out in
+// project and file name order, but with Concurrency above 1 fn runs on several
+// goroutines at once and must be safe for concurrent use. A new session is only
+// parsed once a worker is free, so memory stays bounded however large the
+// archive. Cancelling ctx stops the walk after the sessions in progress and
+// returns ctx.Err().
+func WalkSessions(ctx context.Context, claudeDir string, fn WalkFunc, opts WalkOptions) error {
+	projectsDir := filepath.Join(claudeDir, "projects")
+	projects, err := os.ReadDir(projectsDir)
+	if err != nil {
+		return err
+	}
+
+	workers := opts.Concurrency
+	if workers < 1 {
+		workers = 1
+	}
+
+	walkCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var mu sync.Mutex
+	var firstErr error
+	fail := func(project, sessionID string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if opts.OnError == SkipOnError {
+			if opts.OnSkip != nil {
+				opts.OnSkip(project, sessionID, err)
+			}
+			return
+		}
+		if firstErr == nil {
+			firstErr = fmt.Errorf("%s: %w", filepath.Join(project, sessionID), err)
+			cancel()
+		}
+	}
+
+	type job struct {
+		project   string
+		sessionID string
+		path      string
+	}
+	jobs := make(chan job)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				// A job may still be handed out as the walk is stopping
+				if walkCtx.Err() != nil {
+					continue
+				}
+				entries, err := ReadJSONLFileWithOptions(j.path, opts.Parse)
+				if err == nil {
+					err = fn(j.project, j.sessionID, entries)
+				}
+				if err != nil {
+					fail(j.project, j.sessionID, err)
+				}
+			}
+		}()
+	}
+
+dispatch:
+	for _, project := range projects {
+		if !project.IsDir() {
+			continue
+		}
+
+		projectDir := filepath.Join(projectsDir, project.Name())
+		files, err := os.ReadDir(projectDir)
+		if err != nil {
+			fail(project.Name(), "", err)
+			continue
+		}
+
+		for _, file := range files {
+			sessionID, ok := SessionIDFromFilename(file.Name())
+			if !ok || file.IsDir() {
+				continue
+			}
+
+			select {
+			case jobs <- job{project: project.Name(), sessionID: sessionID, path: filepath.Join(projectDir, file.Name())}:
+			case <-walkCtx.Done():
+				break dispatch
+			}
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,149 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeWalkArchive creates a claude dir with two projects holding sessions,
+// plus files WalkSessions must ignore, and returns it.
+func writeWalkArchive(t *testing.T) string {
+	t.Helper()
+	claudeDir := t.TempDir()
+
+	sessions := map[string][]string{
+		"-home-a": {"11111111-1111-1111-1111-111111111111", "22222222-2222-2222-2222-222222222222"},
+		"-home-b": {"33333333-3333-3333-3333-333333333333"},
+	}
+	for project, ids := range sessions {
+		dir := filepath.Join(claudeDir, "projects", project)
+		require.NoError(t, os.MkdirAll(dir, 0755))
+		for _, id := range ids {
+			line := `{"uuid":"` + id + `-msg","type":"user","timestamp":"2024-01-01T10:00:00Z","message":{"role":"user","content":"Hi"}}` + "\n"
+			require.NoError(t, os.WriteFile(filepath.Join(dir, id+".jsonl"), []byte(line), 0644))
+		}
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(claudeDir, "projects", "-home-a", "notes.jsonl"), []byte("{}\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(claudeDir, "projects", "stray.txt"), []byte("x"), 0644))
+
+	return claudeDir
+}
+
+func TestWalkSessions(t *testing.T) {
+	claudeDir := writeWalkArchive(t)
+
+	var mu sync.Mutex
+	var visited []string
+	err := WalkSessions(context.Background(), claudeDir, func(project, sessionID string, entries []models.LogEntry) error {
+		mu.Lock()
+		defer mu.Unlock()
+		require.Len(t, entries, 1)
+		assert.Equal(t, sessionID+"-msg", entries[0].UUID)
+		visited = append(visited, project+"/"+sessionID)
+		return nil
+	}, WalkOptions{Concurrency: 2})
+	require.NoError(t, err)
+
+	sort.Strings(visited)
+	assert.Equal(t, []string{
+		"-home-a/11111111-1111-1111-1111-111111111111",
+		"-home-a/22222222-2222-2222-2222-222222222222",
+		"-home-b/33333333-3333-3333-3333-333333333333",
+	}, visited)
+}
+
+func TestWalkSessions_Concurrency(t *testing.T) {
+	claudeDir := writeWalkArchive(t)
+
+	for _, concurrency := range []int{0, 1, 2} {
+		var inFlight, peak int32
+		err := WalkSessions(context.Background(), claudeDir, func(project, sessionID string, entries []models.LogEntry) error {
+			n := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		}, WalkOptions{Concurrency: concurrency})
+		require.NoError(t, err)
+
+		limit := int32(concurrency)
+		if limit < 1 {
+			limit = 1
+		}
+		assert.LessOrEqual(t, peak, limit, "concurrency %d", concurrency)
+	}
+}
+
+func TestWalkSessions_ErrorPolicy(t *testing.T) {
+	claudeDir := writeWalkArchive(t)
+	errBad := errors.New("bad session")
+	failFirst := func(project, sessionID string, entries []models.LogEntry) error {
+		if sessionID == "11111111-1111-1111-1111-111111111111" {
+			return errBad
+		}
+		return nil
+	}
+
+	t.Run("stop returns the first error", func(t *testing.T) {
+		err := WalkSessions(context.Background(), claudeDir, failFirst, WalkOptions{})
+		require.ErrorIs(t, err, errBad)
+		assert.Contains(t, err.Error(), "11111111-1111-1111-1111-111111111111")
+	})
+
+	t.Run("skip reports and continues", func(t *testing.T) {
+		var skipped []string
+		var handled int32
+		err := WalkSessions(context.Background(), claudeDir, func(project, sessionID string, entries []models.LogEntry) error {
+			if err := failFirst(project, sessionID, entries); err != nil {
+				return err
+			}
+			atomic.AddInt32(&handled, 1)
+			return nil
+		}, WalkOptions{
+			Concurrency: 2,
+			OnError:     SkipOnError,
+			OnSkip: func(project, sessionID string, err error) {
+				assert.ErrorIs(t, err, errBad)
+				skipped = append(skipped, sessionID)
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"11111111-1111-1111-1111-111111111111"}, skipped)
+		assert.Equal(t, int32(2), handled)
+	})
+}
+
+func TestWalkSessions_Cancel(t *testing.T) {
+	claudeDir := writeWalkArchive(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var handled int32
+	err := WalkSessions(ctx, claudeDir, func(project, sessionID string, entries []models.LogEntry) error {
+		atomic.AddInt32(&handled, 1)
+		cancel()
+		return nil
+	}, WalkOptions{})
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, int32(1), handled)
+}
+
+func TestWalkSessions_MissingDir(t *testing.T) {
+	err := WalkSessions(context.Background(), t.TempDir(), func(string, string, []models.LogEntry) error { return nil }, WalkOptions{})
+	assert.Error(t, err)
+}
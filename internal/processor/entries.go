@@ -2,17 +2,20 @@ package processor
 
 import (
 	"encoding/json"
+	"html/template"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/brads3290/cclogviewer/internal/constants"
+	"github.com/brads3290/cclogviewer/internal/debug"
 	"github.com/brads3290/cclogviewer/internal/models"
 	"github.com/brads3290/cclogviewer/internal/utils"
 )
 
 // ProcessEntries builds a hierarchical structure from flat log entries.
 func ProcessEntries(entries []models.LogEntry) []*models.ProcessedEntry {
+	start := time.Now()
 	state := initializeProcessingState(len(entries))
 	entryMap := make(map[string]*models.ProcessedEntry)
 
@@ -24,13 +27,21 @@ func ProcessEntries(entries []models.LogEntry) []*models.ProcessedEntry {
 
 	// Phase 3: Process sidechains
 	processSidechainConversations(state, entries, entryMap)
+	reconcileToolResults(state.Entries)
 
-	// Phase 4-7: Post-processing
+	// Phase 4-8: Post-processing
 	rootEntries := getRootEntries(state)
 	calculateAllTokens(rootEntries)
 	checkAllMissingResults(rootEntries)
 	linkAllCommandOutputs(rootEntries)
 	buildFinalHierarchy(rootEntries)
+	markParallelToolCalls(rootEntries)
+
+	session := ""
+	if len(entries) > 0 {
+		session = entries[0].SessionID
+	}
+	debug.Event("process", "session", session, "entries", len(entries), "root_entries", len(rootEntries), "ms", time.Since(start))
 
 	return rootEntries
 }
@@ -93,12 +104,29 @@ func processEntry(entry models.LogEntry) *models.ProcessedEntry {
 		Timestamp:    formatTimestamp(entry.Timestamp),
 		RawTimestamp: entry.Timestamp,
 		AgentID:      entry.AgentID,
+		GitBranch:    entry.GitBranch,
 	}
 
 	if entry.ParentUUID != nil {
 		processed.ParentUUID = *entry.ParentUUID
 	}
 
+	// Compaction boundaries carry no message; record them as markers only
+	if entry.Type == constants.TypeSystem && entry.Subtype == constants.SubtypeCompactBoundary {
+		processed.IsCompaction = true
+		if entry.CompactMetadata != nil {
+			processed.CompactionTrigger = entry.CompactMetadata.Trigger
+			processed.PreCompactionTokens = entry.CompactMetadata.PreTokens
+		}
+		return processed
+	}
+
+	// Hook traces carry no message; record the parsed execution only
+	if hook := parseHookEntry(entry); hook != nil {
+		processed.Hook = hook
+		return processed
+	}
+
 	// Process the message content
 	var msg map[string]interface{}
 	if err := json.Unmarshal(entry.Message, &msg); err == nil {
@@ -106,6 +134,7 @@ func processEntry(entry models.LogEntry) *models.ProcessedEntry {
 		if err := processMessage(processed, msg, entry); err != nil {
 			// Log error but continue processing
 		}
+		sanitizeEntryText(processed)
 
 		// Process token counts
 		tokenProcessor := NewTokenProcessor()
@@ -118,6 +147,21 @@ func processEntry(entry models.LogEntry) *models.ProcessedEntry {
 	return processed
 }
 
+// sanitizeEntryText replaces invalid UTF-8 in an entry's text and tool calls,
+// which binary tool output can contain, so JSON and HTML output stay valid.
+func sanitizeEntryText(processed *models.ProcessedEntry) {
+	processed.Content = utils.SanitizeUTF8(processed.Content)
+	processed.CommandArgs = utils.SanitizeUTF8(processed.CommandArgs)
+	processed.CommandOutput = utils.SanitizeUTF8(processed.CommandOutput)
+
+	for i := range processed.ToolCalls {
+		toolCall := &processed.ToolCalls[i]
+		toolCall.Description = utils.SanitizeUTF8(toolCall.Description)
+		toolCall.Input = template.HTML(utils.SanitizeUTF8(string(toolCall.Input)))
+		toolCall.CompactView = template.HTML(utils.SanitizeUTF8(string(toolCall.CompactView)))
+	}
+}
+
 func collectSidechainEntries(root *models.ProcessedEntry, entryMap map[string]*models.ProcessedEntry) []*models.ProcessedEntry {
 	var result []*models.ProcessedEntry
 
@@ -133,6 +177,18 @@ func collectSidechainEntries(root *models.ProcessedEntry, entryMap map[string]*m
 		}
 	}
 
+	// Index sidechain entries by parent, in a stable order so traversal does not
+	// depend on map iteration order
+	childrenByParent := make(map[string][]*models.ProcessedEntry)
+	for _, e := range entryMap {
+		if e.IsSidechain && e.ParentUUID != "" {
+			childrenByParent[e.ParentUUID] = append(childrenByParent[e.ParentUUID], e)
+		}
+	}
+	for _, children := range childrenByParent {
+		sortEntriesByTimestamp(children)
+	}
+
 	// Build the sidechain tree structure
 	var buildTree func(entry *models.ProcessedEntry, skipEntry bool)
 	buildTree = func(entry *models.ProcessedEntry, skipEntry bool) {
@@ -142,11 +198,7 @@ func collectSidechainEntries(root *models.ProcessedEntry, entryMap map[string]*m
 		}
 
 		// Find and add children
-		for _, e := range entryMap {
-			if e.ParentUUID == entry.UUID && e.IsSidechain {
-				entry.Children = append(entry.Children, e)
-			}
-		}
+		entry.Children = append(entry.Children, childrenByParent[entry.UUID]...)
 
 		// Recursively process children
 		for _, child := range entry.Children {
@@ -159,48 +211,40 @@ func collectSidechainEntries(root *models.ProcessedEntry, entryMap map[string]*m
 
 	buildTree(root, false)
 
-	// Fallback: If tree traversal found few entries and we have an AgentID,
-	// collect all entries with the same AgentID (handles broken parent-child chains)
+	// When the root has an AgentID, collect every entry with the same AgentID instead
+	// of relying on the tree: parent-child chains in subagent transcripts are not
+	// always intact, and AgentID collection gives the same result either way.
 	if root.AgentID != "" {
-		// Count how many entries we expect with this AgentID
-		expectedCount := 0
+		result = nil
 		for _, e := range entryMap {
-			if e.AgentID == root.AgentID && e.IsSidechain {
-				expectedCount++
+			if e.AgentID != root.AgentID || !e.IsSidechain {
+				continue
 			}
-		}
-
-		// If tree traversal missed entries, use AgentID-based collection
-		if len(result) < expectedCount {
-			result = nil // Reset result
-			collected := make(map[string]bool)
-
-			// Collect all entries with matching AgentID, sorted by timestamp
-			for _, e := range entryMap {
-				if e.AgentID == root.AgentID && e.IsSidechain {
-					// Skip tool results that are attached to tool calls
-					if e.IsToolResult && attachedToolResults[e.UUID] {
-						continue
-					}
-					if !collected[e.UUID] {
-						result = append(result, e)
-						collected[e.UUID] = true
-					}
-				}
+			// Skip tool results that are attached to tool calls
+			if e.IsToolResult && attachedToolResults[e.UUID] {
+				continue
 			}
-
-			// Sort by timestamp for consistent ordering
-			sort.Slice(result, func(i, j int) bool {
-				return result[i].RawTimestamp < result[j].RawTimestamp
-			})
+			result = append(result, e)
 		}
+
+		sortEntriesByTimestamp(result)
 	}
 
 	return result
 }
 
+// sortEntriesByTimestamp orders entries by raw timestamp, breaking ties by UUID.
+func sortEntriesByTimestamp(entries []*models.ProcessedEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].RawTimestamp != entries[j].RawTimestamp {
+			return entries[i].RawTimestamp < entries[j].RawTimestamp
+		}
+		return entries[i].UUID < entries[j].UUID
+	})
+}
+
 func formatTimestamp(ts string) string {
-	t, err := time.Parse(time.RFC3339, ts)
+	t, err := utils.ParseTimestamp(ts)
 	if err != nil {
 		return ts
 	}
@@ -278,7 +322,7 @@ func getFirstUserMessage(root *models.ProcessedEntry, entryMap map[string]*model
 				if content == "" {
 					continue
 				}
-				if t, err := time.Parse(time.RFC3339, e.RawTimestamp); err == nil {
+				if t, err := utils.ParseTimestamp(e.RawTimestamp); err == nil {
 					if firstUserContent == "" || t.Before(firstUserTime) {
 						firstUserContent = content
 						firstUserTime = t
@@ -304,7 +348,7 @@ func getLastAssistantMessage(root *models.ProcessedEntry, entryMap map[string]*m
 			content := extractContent(entry)
 			if content != "" {
 				// Parse timestamp
-				if t, err := time.Parse(time.RFC3339, entry.RawTimestamp); err == nil {
+				if t, err := utils.ParseTimestamp(entry.RawTimestamp); err == nil {
 					if lastAssistantContent == "" || t.After(lastAssistantTime) {
 						lastAssistantContent = content
 						lastAssistantTime = t
@@ -351,7 +395,7 @@ func getLastAssistantMessage(root *models.ProcessedEntry, entryMap map[string]*m
 				if content == "" {
 					continue
 				}
-				if t, err := time.Parse(time.RFC3339, e.RawTimestamp); err == nil {
+				if t, err := utils.ParseTimestamp(e.RawTimestamp); err == nil {
 					if lastAssistantContent == "" || t.After(lastAssistantTime) {
 						lastAssistantContent = content
 						lastAssistantTime = t
@@ -364,18 +408,6 @@ func getLastAssistantMessage(root *models.ProcessedEntry, entryMap map[string]*m
 	return lastAssistantContent
 }
 
-// normalizeText normalizes text for comparison by removing extra whitespace and newlines
-func normalizeText(text string) string {
-	// Replace all newlines with spaces
-	text = strings.ReplaceAll(text, "\n", " ")
-	text = strings.ReplaceAll(text, "\r", " ")
-
-	// Replace multiple spaces with single space
-	text = strings.Join(strings.Fields(text), " ")
-
-	return strings.TrimSpace(text)
-}
-
 // extractXMLContent extracts content between XML tags
 func extractXMLContent(text, tag string) string {
 	startTag := "<" + tag + ">"
@@ -1,9 +1,15 @@
 package processor
 
 import (
+	"encoding/json"
+	"html/template"
+	"os"
+	"path/filepath"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/parser"
 	"github.com/brads3290/cclogviewer/internal/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -49,6 +55,11 @@ func TestFormatTimestamp_Simple(t *testing.T) {
 			input: "2024-01-01T15:30:45Z",
 			want:  "15:30:45",
 		},
+		{
+			name:  "timestamp without zone",
+			input: "2024-01-01T15:30:45.123",
+			want:  "15:30:45",
+		},
 		{
 			name:  "invalid timestamp",
 			input: "invalid",
@@ -64,37 +75,6 @@ func TestFormatTimestamp_Simple(t *testing.T) {
 	}
 }
 
-func TestNormalizeText_Simple(t *testing.T) {
-	tests := []struct {
-		name  string
-		input string
-		want  string
-	}{
-		{
-			name:  "removes extra spaces",
-			input: "hello   world",
-			want:  "hello world",
-		},
-		{
-			name:  "removes newlines",
-			input: "hello\nworld",
-			want:  "hello world",
-		},
-		{
-			name:  "trims whitespace",
-			input: "  hello world  ",
-			want:  "hello world",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := normalizeText(tt.input)
-			assert.Equal(t, tt.want, got)
-		})
-	}
-}
-
 func TestExtractXMLContent_Simple(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -178,4 +158,233 @@ func TestProcessEntry_Simple(t *testing.T) {
 	assert.Equal(t, entry.UUID, result.UUID)
 	assert.Equal(t, entry.Type, result.Type)
 	assert.NotEmpty(t, result.Timestamp)
-}
\ No newline at end of file
+}
+
+func TestProcessEntry_CompactBoundary(t *testing.T) {
+	entry := models.LogEntry{
+		UUID:      "compact-1",
+		Type:      "system",
+		Subtype:   "compact_boundary",
+		Timestamp: "2024-01-01T15:30:45Z",
+		CompactMetadata: &models.CompactMetadata{
+			Trigger:   "auto",
+			PreTokens: 155000,
+		},
+	}
+
+	result := processEntry(entry)
+
+	require.NotNil(t, result)
+	assert.True(t, result.IsCompaction)
+	assert.Equal(t, "auto", result.CompactionTrigger)
+	assert.Equal(t, 155000, result.PreCompactionTokens)
+	assert.Empty(t, result.Content)
+}
+
+func TestProcessEntry_HookTraces(t *testing.T) {
+	tests := []struct {
+		name    string
+		entry   models.LogEntry
+		event   string
+		tool    string
+		outcome string
+		blocked bool
+	}{
+		{
+			name: "system status line",
+			entry: models.LogEntry{
+				UUID:      "hook-1",
+				Type:      "system",
+				Content:   json.RawMessage(`"\u001b[1mPostToolUse:Edit\u001b[22m [prettier --write] completed successfully"`),
+				ToolUseID: "toolu_1",
+			},
+			event:   "PostToolUse",
+			tool:    "Edit",
+			outcome: "success",
+		},
+		{
+			name: "system blocking status line",
+			entry: models.LogEntry{
+				UUID:    "hook-2",
+				Type:    "system",
+				Content: json.RawMessage(`"PreToolUse:Bash [~/hooks/guard.sh] failed with status code 2: rm is not allowed"`),
+			},
+			event:   "PreToolUse",
+			tool:    "Bash",
+			outcome: "blocked",
+			blocked: true,
+		},
+		{
+			name: "blocking attachment",
+			entry: models.LogEntry{
+				UUID:       "hook-3",
+				Type:       "attachment",
+				Attachment: json.RawMessage(`{"type":"hook_blocking_error","hookName":"PreToolUse:Write","toolUseID":"toolu_3","blockingError":{"blockingError":"protected file","command":"guard.sh"}}`),
+			},
+			event:   "PreToolUse",
+			tool:    "Write",
+			outcome: "blocked",
+			blocked: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := processEntry(tt.entry)
+
+			require.NotNil(t, result.Hook)
+			assert.Equal(t, tt.entry.UUID, result.Hook.UUID)
+			assert.Equal(t, tt.event, result.Hook.Event)
+			assert.Equal(t, tt.tool, result.Hook.Tool)
+			assert.Equal(t, tt.outcome, result.Hook.Outcome)
+			assert.Equal(t, tt.blocked, result.Hook.Blocked)
+		})
+	}
+
+	t.Run("ordinary system message is not a hook", func(t *testing.T) {
+		result := processEntry(models.LogEntry{
+			UUID:    "sys-1",
+			Type:    "system",
+			Content: json.RawMessage(`"Conversation resumed"`),
+		})
+		assert.Nil(t, result.Hook)
+	})
+}
+
+func TestProcessEntry_EmptyAssistant(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		empty   bool
+	}{
+		{"empty content array", `{"role":"assistant","content":[]}`, true},
+		{"blank text", `{"role":"assistant","content":[{"type":"text","text":"  "}]}`, true},
+		{"text", `{"role":"assistant","content":[{"type":"text","text":"Done"}]}`, false},
+		{"tool call", `{"role":"assistant","content":[{"type":"tool_use","id":"t1","name":"Bash","input":{}}]}`, false},
+		{"thinking only", `{"role":"assistant","content":[{"type":"thinking","thinking":"Let me check"}]}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := models.LogEntry{
+				UUID:      "assistant-1",
+				Type:      "assistant",
+				Timestamp: "2024-01-01T10:00:00Z",
+				Message:   json.RawMessage(tt.message),
+			}
+
+			result := processEntry(entry)
+
+			require.NotNil(t, result)
+			assert.Equal(t, tt.empty, result.IsEmpty)
+		})
+	}
+}
+
+func TestProcessEntry_ThinkingTokens(t *testing.T) {
+	tests := []struct {
+		name      string
+		message   string
+		tokens    int
+		estimated bool
+	}{
+		{"no thinking", `{"role":"assistant","content":[{"type":"text","text":"Done"}],"usage":{"input_tokens":10}}`, 0, false},
+		{"estimated from text", `{"role":"assistant","content":[{"type":"thinking","thinking":"one two three four five six seven eight nine ten"},{"type":"text","text":"Done"}],"usage":{"input_tokens":10}}`, 13, true},
+		{"reported by usage", `{"role":"assistant","content":[{"type":"thinking","thinking":"one two"}],"usage":{"input_tokens":10,"thinking_tokens":42}}`, 42, false},
+		{"redacted only", `{"role":"assistant","content":[{"type":"redacted_thinking","data":"abc"}]}`, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := models.LogEntry{
+				UUID:      "assistant-1",
+				Type:      "assistant",
+				Timestamp: "2024-01-01T10:00:00Z",
+				Message:   json.RawMessage(tt.message),
+			}
+
+			result := processEntry(entry)
+
+			require.NotNil(t, result)
+			assert.Equal(t, tt.tokens, result.ThinkingTokens)
+			assert.Equal(t, tt.estimated, result.ThinkingTokensEstimated)
+		})
+	}
+}
+
+func TestSanitizeEntryText_InvalidUTF8(t *testing.T) {
+	processed := &models.ProcessedEntry{
+		Content: "binary \xff\xfe output",
+		ToolCalls: []models.ToolCall{
+			{Name: "Bash", Description: "cat \xc3", Input: template.HTML("<pre>\x80</pre>")},
+		},
+	}
+
+	sanitizeEntryText(processed)
+
+	assert.Equal(t, "binary \uFFFD output", processed.Content)
+	assert.Equal(t, "cat \uFFFD", processed.ToolCalls[0].Description)
+	assert.Equal(t, template.HTML("<pre>\uFFFD</pre>"), processed.ToolCalls[0].Input)
+
+	data, err := json.Marshal(processed.Content)
+	require.NoError(t, err)
+	assert.True(t, utf8.Valid(data))
+}
+
+func TestProcessEntries_ResultInSubagentFile(t *testing.T) {
+	dir := t.TempDir()
+	sessionID := "11111111-2222-3333-4444-555555555555"
+	mainLog := `{"type":"assistant","uuid":"call-1","timestamp":"2024-01-01T10:00:00Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"toolu_1","name":"Bash","input":{"command":"ls"}}]}}
+`
+	agentLog := `{"type":"user","uuid":"result-1","parentUuid":"call-1","isSidechain":true,"agentId":"a1b2c3d","timestamp":"2024-01-01T10:00:01Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"toolu_1","content":"file.txt"}]}}
+`
+	sessionFile := filepath.Join(dir, sessionID+".jsonl")
+	require.NoError(t, os.WriteFile(sessionFile, []byte(mainLog), 0644))
+	subagentsDir := filepath.Join(dir, sessionID, "subagents")
+	require.NoError(t, os.MkdirAll(subagentsDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(subagentsDir, "agent-a1b2c3d.jsonl"), []byte(agentLog), 0644))
+
+	entries, err := parser.ReadJSONLFile(sessionFile)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	result := ProcessEntries(entries)
+	require.Len(t, result, 1)
+	require.Len(t, result[0].ToolCalls, 1)
+
+	toolCall := result[0].ToolCalls[0]
+	require.NotNil(t, toolCall.Result)
+	assert.Equal(t, "result-1", toolCall.Result.UUID)
+	assert.False(t, toolCall.HasMissingResult)
+}
+
+func TestProcessedEntry_JSON(t *testing.T) {
+	var entries []models.LogEntry
+	for _, line := range []string{
+		`{"type":"assistant","uuid":"call-1","timestamp":"2024-01-01T10:00:00Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"toolu_1","name":"Bash","input":{"command":"ls"}}]}}`,
+		`{"type":"user","uuid":"result-1","parentUuid":"call-1","timestamp":"2024-01-01T10:00:01Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"toolu_1","content":"file.txt"}]}}`,
+	} {
+		var entry models.LogEntry
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		entries = append(entries, entry)
+	}
+
+	result := ProcessEntries(entries)
+	first, err := json.Marshal(result)
+	require.NoError(t, err)
+	second, err := json.Marshal(ProcessEntries(entries))
+	require.NoError(t, err)
+	assert.Equal(t, string(first), string(second))
+
+	var decoded []map[string]interface{}
+	require.NoError(t, json.Unmarshal(first, &decoded))
+	require.Len(t, decoded, 1)
+	assert.Equal(t, "call-1", decoded[0]["uuid"])
+
+	toolCalls := decoded[0]["tool_calls"].([]interface{})
+	require.Len(t, toolCalls, 1)
+	toolCall := toolCalls[0].(map[string]interface{})
+	assert.Equal(t, "Bash", toolCall["name"])
+	assert.Equal(t, map[string]interface{}{"command": "ls"}, toolCall["raw_input"])
+	assert.Equal(t, "result-1", toolCall["result"].(map[string]interface{})["uuid"])
+}
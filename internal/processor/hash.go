@@ -0,0 +1,44 @@
+package processor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/utils"
+)
+
+// contentHashLength is the number of hex digits kept from the SHA-256 sum.
+const contentHashLength = 16
+
+// ContentHash returns a stable hash of what an entry says, for spotting the
+// same content across sessions and exports. UUIDs and timestamps are left out,
+// so a repeated message hashes the same. The hash is the first 16 hex digits
+// of the SHA-256 of these lines joined by "\n":
+//
+//	role
+//	content, with runs of whitespace collapsed to one space and trimmed
+//	for each tool call, in order:
+//	  tool name
+//	  input as compact JSON with object keys sorted and no HTML escaping
+//	  result content, whitespace collapsed as above (empty if no result)
+func ContentHash(e *models.ProcessedEntry) string {
+	parts := []string{e.Role, utils.NormalizeText(e.Content)}
+	for _, tc := range e.ToolCalls {
+		var input strings.Builder
+		enc := json.NewEncoder(&input)
+		enc.SetEscapeHTML(false)
+		_ = enc.Encode(tc.RawInput)
+
+		output := ""
+		if tc.Result != nil {
+			output = utils.NormalizeText(tc.Result.Content)
+		}
+		parts = append(parts, tc.Name, strings.TrimSuffix(input.String(), "\n"), output)
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\n")))
+	return hex.EncodeToString(sum[:])[:contentHashLength]
+}
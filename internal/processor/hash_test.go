@@ -0,0 +1,42 @@
+package processor
+
+import (
+	"testing"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentHash(t *testing.T) {
+	entry := func(uuid, timestamp, content, result string) *models.ProcessedEntry {
+		return &models.ProcessedEntry{
+			UUID:         uuid,
+			RawTimestamp: timestamp,
+			Role:         "assistant",
+			Content:      content,
+			ToolCalls: []models.ToolCall{{
+				Name:     "Bash",
+				RawInput: map[string]interface{}{"command": "go test ./... > out.txt", "description": "Run tests"},
+				Result:   &models.ProcessedEntry{Content: result},
+			}},
+		}
+	}
+
+	base := ContentHash(entry("a", "2024-01-01T10:00:00Z", "Running the tests", "ok"))
+	assert.Len(t, base, 16)
+	// sha256("assistant\nRunning the tests\nBash\n{\"command\":\"go test ./... > out.txt\",\"description\":\"Run tests\"}\nok")
+	assert.Equal(t, "66c083bf21664546", base)
+
+	// UUIDs, timestamps, and whitespace do not change the hash
+	assert.Equal(t, base, ContentHash(entry("b", "2024-02-01T10:00:00Z", "  Running   the\ntests ", "ok\n")))
+
+	// Content, tool output, and role do
+	assert.NotEqual(t, base, ContentHash(entry("a", "2024-01-01T10:00:00Z", "Running the build", "ok")))
+	assert.NotEqual(t, base, ContentHash(entry("a", "2024-01-01T10:00:00Z", "Running the tests", "FAIL")))
+	user := entry("a", "2024-01-01T10:00:00Z", "Running the tests", "ok")
+	user.Role = "user"
+	assert.NotEqual(t, base, ContentHash(user))
+
+	// Matches the documented input: "role\ncontent" with no tool calls
+	assert.Equal(t, "fa82b1cf60263d97", ContentHash(&models.ProcessedEntry{Role: "user", Content: "hello"}))
+}
@@ -0,0 +1,168 @@
+package processor
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/brads3290/cclogviewer/internal/constants"
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/utils"
+)
+
+// hookEvents lists the hook event names Claude Code can fire.
+var hookEvents = map[string]bool{
+	"PreToolUse":       true,
+	"PostToolUse":      true,
+	"Notification":     true,
+	"UserPromptSubmit": true,
+	"Stop":             true,
+	"SubagentStop":     true,
+	"PreCompact":       true,
+	"SessionStart":     true,
+	"SessionEnd":       true,
+}
+
+// hookStatusRegex matches system status lines such as
+// "PreToolUse:Bash [~/hooks/check.sh] completed successfully".
+var hookStatusRegex = regexp.MustCompile(`^(\w+)(?::(\S+))?\s+\[(.*?)\]\s*(.*)$`)
+
+// hookAttachment is the payload of an attachment entry recording a hook result.
+type hookAttachment struct {
+	Type          string          `json:"type"`
+	HookName      string          `json:"hookName"`
+	HookEvent     string          `json:"hookEvent"`
+	ToolUseID     string          `json:"toolUseID"`
+	Command       string          `json:"command"`
+	Content       string          `json:"content"`
+	Stderr        string          `json:"stderr"`
+	BlockingError json.RawMessage `json:"blockingError"`
+}
+
+// parseHookEntry returns the hook execution recorded by an entry, or nil if the
+// entry is not a hook trace.
+func parseHookEntry(entry models.LogEntry) *models.HookEvent {
+	var hook *models.HookEvent
+	switch entry.Type {
+	case constants.TypeSystem:
+		hook = parseHookStatusLine(entry.Content)
+	case constants.TypeAttachment:
+		hook = parseHookAttachment(entry.Attachment)
+	}
+	if hook == nil {
+		return nil
+	}
+
+	hook.UUID = entry.UUID
+	hook.Timestamp = entry.Timestamp
+	hook.IsSidechain = entry.IsSidechain
+	if hook.ToolUseID == "" {
+		hook.ToolUseID = entry.ToolUseID
+	}
+	return hook
+}
+
+// parseHookStatusLine parses the status line Claude Code writes after running a hook.
+func parseHookStatusLine(raw json.RawMessage) *models.HookEvent {
+	var content string
+	if err := json.Unmarshal(raw, &content); err != nil {
+		return nil
+	}
+
+	content = strings.TrimSpace(utils.StripANSI(content))
+	matches := hookStatusRegex.FindStringSubmatch(content)
+	if matches == nil || !hookEvents[matches[1]] {
+		return nil
+	}
+
+	hook := &models.HookEvent{
+		Event:   matches[1],
+		Tool:    matches[2],
+		Command: matches[3],
+		Message: matches[4],
+	}
+
+	status := strings.ToLower(matches[4])
+	switch {
+	case strings.Contains(status, "completed successfully"):
+		hook.Outcome = constants.HookOutcomeSuccess
+	case strings.Contains(status, "non-blocking"):
+		hook.Outcome = constants.HookOutcomeError
+	case strings.Contains(status, "blocking") || strings.Contains(status, "blocked") ||
+		strings.Contains(status, "denied") || strings.Contains(status, "status code 2"):
+		hook.Outcome = constants.HookOutcomeBlocked
+		hook.Blocked = true
+	default:
+		hook.Outcome = constants.HookOutcomeError
+	}
+
+	return hook
+}
+
+// parseHookAttachment parses hook_* attachment entries written by newer Claude Code versions.
+func parseHookAttachment(raw json.RawMessage) *models.HookEvent {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var att hookAttachment
+	if err := json.Unmarshal(raw, &att); err != nil || !strings.HasPrefix(att.Type, "hook_") {
+		return nil
+	}
+
+	event, tool := att.HookEvent, ""
+	if name, matcher, ok := strings.Cut(att.HookName, ":"); ok {
+		event, tool = name, matcher
+	} else if event == "" {
+		event = att.HookName
+	}
+
+	hook := &models.HookEvent{
+		Event:     event,
+		Tool:      tool,
+		ToolUseID: att.ToolUseID,
+		Command:   att.Command,
+		Message:   att.Content,
+	}
+
+	switch att.Type {
+	case "hook_success":
+		hook.Outcome = constants.HookOutcomeSuccess
+	case "hook_blocking_error":
+		hook.Outcome = constants.HookOutcomeBlocked
+		hook.Blocked = true
+		hook.Message = blockingErrorMessage(att.BlockingError, hook)
+	default:
+		hook.Outcome = constants.HookOutcomeError
+		if hook.Message == "" {
+			hook.Message = att.Stderr
+		}
+	}
+
+	return hook
+}
+
+// blockingErrorMessage extracts the reason from a blockingError payload, which is
+// either a plain string or an object with blockingError and command fields.
+func blockingErrorMessage(raw json.RawMessage, hook *models.HookEvent) string {
+	if len(raw) == 0 {
+		return hook.Message
+	}
+
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text
+	}
+
+	var detail struct {
+		BlockingError string `json:"blockingError"`
+		Command       string `json:"command"`
+	}
+	if err := json.Unmarshal(raw, &detail); err != nil {
+		return hook.Message
+	}
+	if hook.Command == "" {
+		hook.Command = detail.Command
+	}
+	return detail.BlockingError
+}
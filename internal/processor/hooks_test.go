@@ -0,0 +1,24 @@
+package processor
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/brads3290/cclogviewer/internal/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHookStatusLine_StripsEscapes(t *testing.T) {
+	// Colors and an OSC hyperlink around the command, as a terminal-aware hook may print
+	line := "\x1b[1mPreToolUse:Bash\x1b[0m [\x1b]8;;file:///h\x07~/hooks/check.sh\x1b]8;;\x07] completed successfully"
+	raw, err := json.Marshal(line)
+	require.NoError(t, err)
+
+	hook := parseHookStatusLine(raw)
+	require.NotNil(t, hook)
+	assert.Equal(t, "PreToolUse", hook.Event)
+	assert.Equal(t, "Bash", hook.Tool)
+	assert.Equal(t, "~/hooks/check.sh", hook.Command)
+	assert.Equal(t, constants.HookOutcomeSuccess, hook.Outcome)
+}
@@ -15,7 +15,10 @@ func NewToolCallMatcher() *ToolCallMatcher {
 	return &ToolCallMatcher{}
 }
 
-// MatchToolCalls uses a 5-minute window to prevent false matches in long conversations.
+// MatchToolCalls pairs tool results with tool calls by tool_use_id across the
+// whole entry set, including entries merged in from subagent files. A result is
+// matched to a call on its own chain (main or sidechain) when one exists, and
+// to a call on the other chain otherwise.
 func (m *ToolCallMatcher) MatchToolCalls(entries []*models.ProcessedEntry) error {
 	// Build maps for both main and sidechain tool calls
 	mainToolCallMap := make(map[string]*models.ToolCall)
@@ -37,25 +40,68 @@ func (m *ToolCallMatcher) MatchToolCalls(entries []*models.ProcessedEntry) error
 	// Second, match tool results
 	for _, entry := range entries {
 		if entry.IsToolResult && entry.ToolResultID != "" {
-			var toolCall *models.ToolCall
+			own, other := mainToolCallMap, sidechainToolCallMap
+			if entry.IsSidechain {
+				own, other = sidechainToolCallMap, mainToolCallMap
+			}
 
-			if !entry.IsSidechain {
-				toolCall = mainToolCallMap[entry.ToolResultID]
-			} else {
-				toolCall = sidechainToolCallMap[entry.ToolResultID]
+			toolCall := own[entry.ToolResultID]
+			if toolCall == nil {
+				toolCall = other[entry.ToolResultID]
+			}
+			if toolCall != nil && toolCall.Result == nil {
+				attachToolResult(toolCall, entry)
 			}
+		}
+	}
 
-			if toolCall != nil {
-				toolCall.Result = entry
-				// Check if the tool was interrupted
-				if entry.IsError && strings.Contains(strings.ToLower(entry.Content), constants.UserInterruptionPattern) {
-					toolCall.IsInterrupted = true
+	return nil
+}
+
+// ReconcileToolResults attaches results to tool calls still missing one after
+// sidechains are merged, walking Task entries so calls made inside subagents
+// are covered. Results are looked up by tool_use_id only.
+func (m *ToolCallMatcher) ReconcileToolResults(entries []*models.ProcessedEntry) {
+	resultsByID := make(map[string]*models.ProcessedEntry)
+	for _, entry := range entries {
+		if entry.IsToolResult && entry.ToolResultID != "" {
+			if _, ok := resultsByID[entry.ToolResultID]; !ok {
+				resultsByID[entry.ToolResultID] = entry
+			}
+		}
+	}
+
+	visited := make(map[*models.ProcessedEntry]bool)
+	var walk func(entry *models.ProcessedEntry)
+	walk = func(entry *models.ProcessedEntry) {
+		if visited[entry] {
+			return
+		}
+		visited[entry] = true
+
+		for i := range entry.ToolCalls {
+			toolCall := &entry.ToolCalls[i]
+			if toolCall.Result == nil {
+				if result := resultsByID[toolCall.ID]; result != nil {
+					attachToolResult(toolCall, result)
 				}
 			}
+			for _, taskEntry := range toolCall.TaskEntries {
+				walk(taskEntry)
+			}
 		}
 	}
+	for _, entry := range entries {
+		walk(entry)
+	}
+}
 
-	return nil
+// attachToolResult sets a tool call's result and flags user interruptions.
+func attachToolResult(toolCall *models.ToolCall, result *models.ProcessedEntry) {
+	toolCall.Result = result
+	if result.IsError && strings.Contains(strings.ToLower(result.Content), constants.UserInterruptionPattern) {
+		toolCall.IsInterrupted = true
+	}
 }
 
 // FilterRootEntries filters entries to only include root conversation entries
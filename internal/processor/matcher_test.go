@@ -95,3 +95,116 @@ func TestFilterRootEntries(t *testing.T) {
 		t.Error("Expected entry 4 to be in root entries")
 	}
 }
+
+func TestMatchToolCalls_ResultInSubagentFile(t *testing.T) {
+	// The result was loaded from a subagent file and carries the sidechain flag,
+	// while the call that issued it is on the main chain
+	entries := []*models.ProcessedEntry{
+		{
+			UUID:      "msg-1",
+			Role:      constants.RoleAssistant,
+			ToolCalls: []models.ToolCall{{ID: "tool-1", Name: constants.ToolNameBash}},
+		},
+		{
+			UUID:         "agent-result-1",
+			Role:         constants.RoleUser,
+			IsSidechain:  true,
+			AgentID:      "a1b2c3d",
+			IsToolResult: true,
+			ToolResultID: "tool-1",
+		},
+	}
+
+	matcher := NewToolCallMatcher()
+	if err := matcher.MatchToolCalls(entries); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	toolCall := &entries[0].ToolCalls[0]
+	if toolCall.Result == nil || toolCall.Result.UUID != "agent-result-1" {
+		t.Fatalf("Expected tool-1 to match agent-result-1, got %v", toolCall.Result)
+	}
+}
+
+func TestMatchToolCalls_PrefersSameChain(t *testing.T) {
+	entries := []*models.ProcessedEntry{
+		{
+			UUID:      "main-call",
+			Role:      constants.RoleAssistant,
+			ToolCalls: []models.ToolCall{{ID: "tool-1"}},
+		},
+		{
+			UUID:        "agent-call",
+			Role:        constants.RoleAssistant,
+			IsSidechain: true,
+			ToolCalls:   []models.ToolCall{{ID: "tool-1"}},
+		},
+		{
+			UUID:         "agent-result",
+			Role:         constants.RoleUser,
+			IsSidechain:  true,
+			IsToolResult: true,
+			ToolResultID: "tool-1",
+		},
+	}
+
+	matcher := NewToolCallMatcher()
+	if err := matcher.MatchToolCalls(entries); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if entries[1].ToolCalls[0].Result == nil {
+		t.Error("Expected the sidechain call to take the sidechain result")
+	}
+	if entries[0].ToolCalls[0].Result != nil {
+		t.Error("Expected the main call to stay unmatched")
+	}
+}
+
+func TestReconcileToolResults_SubagentTaskEntries(t *testing.T) {
+	// A call made inside a subagent, only reachable through the Task tool call
+	// once sidechains are merged; its result came from the subagent file
+	agentCall := &models.ProcessedEntry{
+		UUID:        "agent-call",
+		Role:        constants.RoleAssistant,
+		IsSidechain: true,
+		AgentID:     "a1b2c3d",
+		ToolCalls:   []models.ToolCall{{ID: "tool-2", Name: constants.ToolNameBash}},
+	}
+	entries := []*models.ProcessedEntry{
+		{
+			UUID: "task-call",
+			Role: constants.RoleAssistant,
+			ToolCalls: []models.ToolCall{{
+				ID:          "task-1",
+				Name:        constants.TaskToolName,
+				Result:      &models.ProcessedEntry{UUID: "task-result"},
+				TaskEntries: []*models.ProcessedEntry{agentCall},
+			}},
+		},
+		{
+			UUID:         "agent-result",
+			Role:         constants.RoleUser,
+			IsSidechain:  true,
+			AgentID:      "a1b2c3d",
+			IsToolResult: true,
+			IsError:      true,
+			ToolResultID: "tool-2",
+			Content:      "[Request interrupted by user for tool use]",
+		},
+	}
+
+	matcher := NewToolCallMatcher()
+	matcher.ReconcileToolResults(entries)
+
+	toolCall := &agentCall.ToolCalls[0]
+	if toolCall.Result == nil || toolCall.Result.UUID != "agent-result" {
+		t.Fatalf("Expected tool-2 to match agent-result, got %v", toolCall.Result)
+	}
+	if !toolCall.IsInterrupted {
+		t.Error("Expected tool-2 to be marked interrupted")
+	}
+	if entries[0].ToolCalls[0].Result.UUID != "task-result" {
+		t.Error("Expected an existing result to be left alone")
+	}
+}
@@ -19,6 +19,7 @@ var messageHandlers = map[string]MessageHandler{
 // processMessage processes a message using the appropriate handler
 func processMessage(processed *models.ProcessedEntry, msg map[string]interface{}, entry models.LogEntry) error {
 	processed.Role = utils.ExtractString(msg, "role")
+	processed.MessageID = utils.ExtractString(msg, "id")
 
 	// For "message" type, use the role to determine handler
 	handlerKey := processed.Type
@@ -48,9 +49,36 @@ func handleUserMessage(processed *models.ProcessedEntry, msg map[string]interfac
 // handleAssistantMessage processes assistant messages
 func handleAssistantMessage(processed *models.ProcessedEntry, msg map[string]interface{}, entry models.LogEntry) error {
 	processed.Content, processed.ToolCalls = ProcessAssistantMessage(msg, entry.CWD)
+	processed.IsEmpty = isEmptyAssistantMessage(processed, msg)
 	return nil
 }
 
+// isEmptyAssistantMessage reports whether an assistant message carried no text,
+// tool calls, or thinking, which happens when the API returns stripped content
+func isEmptyAssistantMessage(processed *models.ProcessedEntry, msg map[string]interface{}) bool {
+	if strings.TrimSpace(processed.Content) != "" || len(processed.ToolCalls) > 0 {
+		return false
+	}
+
+	if text, ok := msg["content"].(string); ok {
+		return strings.TrimSpace(text) == ""
+	}
+
+	contentArray, _ := msg["content"].([]interface{})
+	for _, item := range contentArray {
+		contentItem, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		switch utils.ExtractString(contentItem, "type") {
+		case constants.ContentTypeThinking, constants.ContentTypeRedactedThinking:
+			return false
+		}
+	}
+
+	return true
+}
+
 // checkCaveatMessage checks if the message is a caveat message
 func checkCaveatMessage(processed *models.ProcessedEntry) {
 	if strings.HasPrefix(processed.Content, constants.CaveatMessagePrefix) {
@@ -105,6 +133,35 @@ func (tp *TokenProcessor) ProcessTokens(processed *models.ProcessedEntry, msg ma
 	} else {
 		tp.estimateTokens(processed)
 	}
+	tp.extractThinkingTokens(processed, msg)
+}
+
+// extractThinkingTokens attributes output tokens to thinking blocks. The usage
+// count is used when the API breaks it out; otherwise the count is estimated
+// from the thinking text and flagged as such.
+func (tp *TokenProcessor) extractThinkingTokens(processed *models.ProcessedEntry, msg map[string]interface{}) {
+	if usage, ok := msg["usage"].(map[string]interface{}); ok {
+		if thinkingTokens, ok := usage["thinking_tokens"].(float64); ok {
+			processed.ThinkingTokens = int(thinkingTokens)
+			return
+		}
+	}
+
+	contentArray, _ := msg["content"].([]interface{})
+	var thinking strings.Builder
+	for _, item := range contentArray {
+		contentItem, ok := item.(map[string]interface{})
+		if !ok || utils.ExtractString(contentItem, "type") != constants.ContentTypeThinking {
+			continue
+		}
+		thinking.WriteString(utils.ExtractString(contentItem, "thinking"))
+		thinking.WriteString("\n")
+	}
+
+	if thinking.Len() > 0 {
+		processed.ThinkingTokens = EstimateTokens(thinking.String())
+		processed.ThinkingTokensEstimated = true
+	}
 }
 
 // extractUsageTokens extracts token counts from usage field
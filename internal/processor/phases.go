@@ -33,6 +33,12 @@ func matchToolCallsWithResults(entries []*models.ProcessedEntry) {
 	}
 }
 
+// reconcileToolResults matches results left over once sidechains are merged
+func reconcileToolResults(entries []*models.ProcessedEntry) {
+	matcher := NewToolCallMatcher()
+	matcher.ReconcileToolResults(entries)
+}
+
 // processSidechainConversations processes Task tool sidechain conversations
 func processSidechainConversations(state *ProcessingState, entries []models.LogEntry, entryMap map[string]*models.ProcessedEntry) {
 	sidechainProc := NewSidechainProcessor()
@@ -73,3 +79,45 @@ func buildFinalHierarchy(rootEntries []*models.ProcessedEntry) {
 		log.Printf("Error building hierarchy: %v", err)
 	}
 }
+
+// markParallelToolCalls numbers the tool calls of each assistant response as
+// one group, in log order. Tool calls issued in the same response run in
+// parallel. Claude Code logs each tool_use block of a response as its own
+// entry, so consecutive entries sharing a message ID form one response.
+func markParallelToolCalls(rootEntries []*models.ProcessedEntry) {
+	group := 0
+
+	var walk func(entries []*models.ProcessedEntry)
+	walk = func(entries []*models.ProcessedEntry) {
+		for i := 0; i < len(entries); {
+			end := i + 1
+			if id := entries[i].MessageID; id != "" {
+				for end < len(entries) && entries[end].MessageID == id {
+					end++
+				}
+			}
+			response := entries[i:end]
+			i = end
+
+			size := 0
+			for _, entry := range response {
+				size += len(entry.ToolCalls)
+			}
+			if size > 0 {
+				group++
+			}
+			for _, entry := range response {
+				for j := range entry.ToolCalls {
+					entry.ToolCalls[j].ParallelGroup = group
+					entry.ToolCalls[j].ParallelGroupSize = size
+				}
+			}
+			for _, entry := range response {
+				for j := range entry.ToolCalls {
+					walk(entry.ToolCalls[j].TaskEntries)
+				}
+			}
+		}
+	}
+	walk(rootEntries)
+}
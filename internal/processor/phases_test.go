@@ -1,6 +1,7 @@
 package processor
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/brads3290/cclogviewer/internal/constants"
@@ -119,3 +120,73 @@ func TestCalculateAllTokens(t *testing.T) {
 		t.Errorf("Expected TotalTokens=230 for entry 2, got %d", entries[1].TotalTokens)
 	}
 }
+
+func TestMarkParallelToolCalls(t *testing.T) {
+	sidechain := &models.ProcessedEntry{
+		UUID:      "side-1",
+		ToolCalls: []models.ToolCall{{ID: "s1", Name: "Grep"}},
+	}
+	entries := []*models.ProcessedEntry{
+		{
+			UUID:      "1",
+			ToolCalls: []models.ToolCall{{ID: "t1", Name: "Read"}, {ID: "t2", Name: "Read"}, {ID: "t3", Name: "Task", TaskEntries: []*models.ProcessedEntry{sidechain}}},
+		},
+		{UUID: "2", Content: "no tools"},
+		{
+			UUID:      "3",
+			ToolCalls: []models.ToolCall{{ID: "t4", Name: "Bash"}},
+		},
+	}
+
+	markParallelToolCalls(entries)
+
+	for _, tc := range entries[0].ToolCalls {
+		if tc.ParallelGroup != 1 || tc.ParallelGroupSize != 3 {
+			t.Errorf("Expected %s in group 1 of size 3, got group %d size %d", tc.ID, tc.ParallelGroup, tc.ParallelGroupSize)
+		}
+	}
+
+	if tc := sidechain.ToolCalls[0]; tc.ParallelGroup != 2 || tc.ParallelGroupSize != 1 {
+		t.Errorf("Expected sidechain call in group 2 of size 1, got group %d size %d", tc.ParallelGroup, tc.ParallelGroupSize)
+	}
+
+	if tc := entries[2].ToolCalls[0]; tc.ParallelGroup != 3 || tc.ParallelGroupSize != 1 {
+		t.Errorf("Expected single call in group 3 of size 1, got group %d size %d", tc.ParallelGroup, tc.ParallelGroupSize)
+	}
+}
+
+func TestMarkParallelToolCalls_SplitResponse(t *testing.T) {
+	// One response (msg_1) logged as a text entry and two tool_use entries,
+	// followed by a separate single-call response (msg_2)
+	var logEntries []models.LogEntry
+	for _, line := range []string{
+		`{"type":"assistant","uuid":"a1","timestamp":"2024-01-01T10:00:00Z","message":{"id":"msg_1","role":"assistant","content":[{"type":"text","text":"Reading both files."}]}}`,
+		`{"type":"assistant","uuid":"a2","parentUuid":"a1","timestamp":"2024-01-01T10:00:00Z","message":{"id":"msg_1","role":"assistant","content":[{"type":"tool_use","id":"t1","name":"Read","input":{"file_path":"/a"}}]}}`,
+		`{"type":"assistant","uuid":"a3","parentUuid":"a2","timestamp":"2024-01-01T10:00:00Z","message":{"id":"msg_1","role":"assistant","content":[{"type":"tool_use","id":"t2","name":"Read","input":{"file_path":"/b"}}]}}`,
+		`{"type":"user","uuid":"r1","parentUuid":"a3","timestamp":"2024-01-01T10:00:01Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t1","content":"a"}]}}`,
+		`{"type":"user","uuid":"r2","parentUuid":"r1","timestamp":"2024-01-01T10:00:01Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t2","content":"b"}]}}`,
+		`{"type":"assistant","uuid":"a4","parentUuid":"r2","timestamp":"2024-01-01T10:00:02Z","message":{"id":"msg_2","role":"assistant","content":[{"type":"tool_use","id":"t3","name":"Bash","input":{"command":"ls"}}]}}`,
+	} {
+		var entry models.LogEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatal(err)
+		}
+		logEntries = append(logEntries, entry)
+	}
+
+	calls := make(map[string]models.ToolCall)
+	for _, e := range ProcessEntries(logEntries) {
+		for _, tc := range e.ToolCalls {
+			calls[tc.ID] = tc
+		}
+	}
+
+	for _, id := range []string{"t1", "t2"} {
+		if tc := calls[id]; tc.ParallelGroup != 1 || tc.ParallelGroupSize != 2 {
+			t.Errorf("Expected %s in group 1 of size 2, got group %d size %d", id, tc.ParallelGroup, tc.ParallelGroupSize)
+		}
+	}
+	if tc := calls["t3"]; tc.ParallelGroup != 2 || tc.ParallelGroupSize != 1 {
+		t.Errorf("Expected t3 in group 2 of size 1, got group %d size %d", tc.ParallelGroup, tc.ParallelGroupSize)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/brads3290/cclogviewer/internal/constants"
 	"github.com/brads3290/cclogviewer/internal/debug"
 	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/utils"
 	"log"
 	"strings"
 )
@@ -298,10 +299,10 @@ func (s *SidechainProcessor) calculateMatchScore(
 	sidechain *models.ProcessedEntry,
 ) int {
 	// Normalize texts for comparison (remove extra whitespace, newlines)
-	taskPromptNorm := normalizeText(taskPrompt)
-	firstUserNorm := normalizeText(firstUser)
-	taskResultNorm := normalizeText(taskResult)
-	lastAssistantNorm := normalizeText(lastAssistant)
+	taskPromptNorm := utils.NormalizeText(taskPrompt)
+	firstUserNorm := utils.NormalizeText(firstUser)
+	taskResultNorm := utils.NormalizeText(taskResult)
+	lastAssistantNorm := utils.NormalizeText(lastAssistant)
 
 	// Check for exact match first
 	promptMatch := taskPromptNorm == firstUserNorm
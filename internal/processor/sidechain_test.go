@@ -0,0 +1,122 @@
+package processor
+
+import (
+	"testing"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func sidechainEntry(uuid, parent, agentID, timestamp string) *models.ProcessedEntry {
+	return &models.ProcessedEntry{
+		UUID:         uuid,
+		ParentUUID:   parent,
+		AgentID:      agentID,
+		RawTimestamp: timestamp,
+		IsSidechain:  true,
+	}
+}
+
+func entryMapOf(entries ...*models.ProcessedEntry) map[string]*models.ProcessedEntry {
+	m := make(map[string]*models.ProcessedEntry)
+	for _, e := range entries {
+		m[e.UUID] = e
+	}
+	return m
+}
+
+func uuidsOf(entries []*models.ProcessedEntry) []string {
+	var uuids []string
+	for _, e := range entries {
+		uuids = append(uuids, e.UUID)
+	}
+	return uuids
+}
+
+func TestCollectSidechainEntries(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries func() []*models.ProcessedEntry
+		rootID  string
+		want    []string
+	}{
+		{
+			name: "broken parent chain collected by agent ID",
+			entries: func() []*models.ProcessedEntry {
+				return []*models.ProcessedEntry{
+					sidechainEntry("s1", "", "agent-1", "2024-01-01T10:00:00Z"),
+					sidechainEntry("s2", "missing-parent", "agent-1", "2024-01-01T10:00:01Z"),
+					sidechainEntry("s3", "s2", "agent-1", "2024-01-01T10:00:02Z"),
+				}
+			},
+			rootID: "s1",
+			want:   []string{"s1", "s2", "s3"},
+		},
+		{
+			name: "equal timestamps ordered by UUID",
+			entries: func() []*models.ProcessedEntry {
+				return []*models.ProcessedEntry{
+					sidechainEntry("s1", "", "agent-1", "2024-01-01T10:00:00Z"),
+					sidechainEntry("s4", "s1", "agent-1", "2024-01-01T10:00:01Z"),
+					sidechainEntry("s2", "s1", "agent-1", "2024-01-01T10:00:01Z"),
+					sidechainEntry("s3", "bogus", "agent-1", "2024-01-01T10:00:01Z"),
+				}
+			},
+			rootID: "s1",
+			want:   []string{"s1", "s2", "s3", "s4"},
+		},
+		{
+			name: "other agents are excluded",
+			entries: func() []*models.ProcessedEntry {
+				return []*models.ProcessedEntry{
+					sidechainEntry("s1", "", "agent-1", "2024-01-01T10:00:00Z"),
+					sidechainEntry("s2", "s1", "agent-2", "2024-01-01T10:00:01Z"),
+					sidechainEntry("s3", "s1", "agent-1", "2024-01-01T10:00:02Z"),
+				}
+			},
+			rootID: "s1",
+			want:   []string{"s1", "s3"},
+		},
+		{
+			name: "attached tool results are skipped",
+			entries: func() []*models.ProcessedEntry {
+				result := sidechainEntry("s2", "s1", "agent-1", "2024-01-01T10:00:01Z")
+				result.IsToolResult = true
+				call := sidechainEntry("s1", "", "agent-1", "2024-01-01T10:00:00Z")
+				call.ToolCalls = []models.ToolCall{{ID: "tool-1", Result: result}}
+				return []*models.ProcessedEntry{
+					call,
+					result,
+					sidechainEntry("s3", "s2", "agent-1", "2024-01-01T10:00:02Z"),
+				}
+			},
+			rootID: "s1",
+			want:   []string{"s1", "s3"},
+		},
+		{
+			name: "without agent ID children follow timestamp order",
+			entries: func() []*models.ProcessedEntry {
+				return []*models.ProcessedEntry{
+					sidechainEntry("s1", "", "", "2024-01-01T10:00:00Z"),
+					sidechainEntry("b", "s1", "", "2024-01-01T10:00:02Z"),
+					sidechainEntry("a", "s1", "", "2024-01-01T10:00:01Z"),
+					sidechainEntry("orphan", "missing-parent", "", "2024-01-01T10:00:03Z"),
+				}
+			},
+			rootID: "s1",
+			want:   []string{"s1", "a", "b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Repeat with fresh entries so map iteration order varies between runs
+			for i := 0; i < 20; i++ {
+				entries := tt.entries()
+				entryMap := entryMapOf(entries...)
+				got := collectSidechainEntries(entryMap[tt.rootID], entryMap)
+				assert.Equal(t, tt.want, uuidsOf(got))
+			}
+		})
+	}
+}
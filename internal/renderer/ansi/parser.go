@@ -4,6 +4,8 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/brads3290/cclogviewer/internal/utils"
 )
 
 // TokenType represents the type of ANSI token.
@@ -48,10 +50,7 @@ func (p *ANSIParser) Parse(input string) ([]ANSIToken, error) {
 	for _, match := range matches {
 		// Add text before escape sequence if any
 		if match[0] > lastEnd {
-			tokens = append(tokens, ANSIToken{
-				Type:    TokenText,
-				Content: input[lastEnd:match[0]],
-			})
+			tokens = appendText(tokens, input[lastEnd:match[0]])
 		}
 
 		// Parse escape codes
@@ -71,15 +70,26 @@ func (p *ANSIParser) Parse(input string) ([]ANSIToken, error) {
 
 	// Add remaining text after last escape sequence
 	if lastEnd < len(input) {
-		tokens = append(tokens, ANSIToken{
-			Type:    TokenText,
-			Content: input[lastEnd:],
-		})
+		tokens = appendText(tokens, input[lastEnd:])
 	}
 
 	return tokens, nil
 }
 
+// appendText adds a text token for text, first removing any escape sequences
+// other than colors, such as cursor movement or hyperlinks, which have no
+// HTML equivalent.
+func appendText(tokens []ANSIToken, text string) []ANSIToken {
+	text = utils.StripANSI(text)
+	if text == "" {
+		return tokens
+	}
+	return append(tokens, ANSIToken{
+		Type:    TokenText,
+		Content: text,
+	})
+}
+
 // parseCodes parses the numeric codes from an ANSI escape sequence
 func (p *ANSIParser) parseCodes(codesStr string) []int {
 	if codesStr == "" {
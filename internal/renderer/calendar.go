@@ -0,0 +1,130 @@
+package renderer
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// calendarColors are the heatmap cell colors from no activity to the busiest days.
+var calendarColors = []string{"#ebedf0", "#9be9a8", "#40c463", "#30a14e", "#216e39"}
+
+const (
+	calendarCellSize = 12
+	calendarCellGap  = 3
+	calendarLeftPad  = 30
+	calendarTopPad   = 20
+)
+
+// ActivityLevel buckets a session count into 0-4 relative to the busiest day.
+func ActivityLevel(sessions, maxSessions int) int {
+	if sessions <= 0 || maxSessions <= 0 {
+		return 0
+	}
+	level := (sessions*4 + maxSessions - 1) / maxSessions
+	if level > 4 {
+		level = 4
+	}
+	return level
+}
+
+// SortedActivityDays returns calendar days in date order.
+func SortedActivityDays(calendar map[string]models.DayActivity) []models.DayActivity {
+	days := make([]models.DayActivity, 0, len(calendar))
+	for _, d := range calendar {
+		days = append(days, d)
+	}
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].Date < days[j].Date
+	})
+	return days
+}
+
+// GenerateCalendarHTML writes a GitHub-style activity heatmap as a standalone HTML file.
+func GenerateCalendarHTML(calendar map[string]models.DayActivity, title, outputFile string) error {
+	days := SortedActivityDays(calendar)
+
+	maxSessions := 0
+	totalSessions, totalMessages, totalTokens := 0, 0, 0
+	for _, d := range days {
+		if d.Sessions > maxSessions {
+			maxSessions = d.Sessions
+		}
+		totalSessions += d.Sessions
+		totalMessages += d.Messages
+		totalTokens += d.Tokens
+	}
+
+	var cells strings.Builder
+	var monthLabels strings.Builder
+	weeks := 0
+	lastMonth := -1
+
+	if len(days) > 0 {
+		first, err := time.Parse("2006-01-02", days[0].Date)
+		if err != nil {
+			return fmt.Errorf("invalid calendar date %q: %w", days[0].Date, err)
+		}
+		offset := int(first.Weekday())
+
+		for i, d := range days {
+			date, err := time.Parse("2006-01-02", d.Date)
+			if err != nil {
+				continue
+			}
+			week := (i + offset) / 7
+			weekday := int(date.Weekday())
+			weeks = week + 1
+
+			x := calendarLeftPad + week*(calendarCellSize+calendarCellGap)
+			y := calendarTopPad + weekday*(calendarCellSize+calendarCellGap)
+
+			// Label each month above the week it starts in
+			if int(date.Month()) != lastMonth {
+				fmt.Fprintf(&monthLabels, `<text x="%d" y="%d" class="label">%s</text>`,
+					x, calendarTopPad-6, date.Format("Jan"))
+				lastMonth = int(date.Month())
+			}
+
+			fmt.Fprintf(&cells, `<rect x="%d" y="%d" width="%d" height="%d" rx="2" fill="%s"><title>%s: %d sessions, %d messages, %d tokens</title></rect>`,
+				x, y, calendarCellSize, calendarCellSize,
+				calendarColors[ActivityLevel(d.Sessions, maxSessions)],
+				d.Date, d.Sessions, d.Messages, d.Tokens)
+		}
+	}
+
+	width := calendarLeftPad + weeks*(calendarCellSize+calendarCellGap) + 10
+	height := calendarTopPad + 7*(calendarCellSize+calendarCellGap) + 10
+
+	dayLabels := fmt.Sprintf(`<text x="0" y="%d" class="label">Mon</text><text x="0" y="%d" class="label">Wed</text><text x="0" y="%d" class="label">Fri</text>`,
+		calendarTopPad+1*(calendarCellSize+calendarCellGap)+10,
+		calendarTopPad+3*(calendarCellSize+calendarCellGap)+10,
+		calendarTopPad+5*(calendarCellSize+calendarCellGap)+10)
+
+	page := `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <title>` + html.EscapeString(title) + ` - Activity</title>
+    <style>
+        body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2rem; color: #24292f; }
+        .label { font-size: 10px; fill: #57606a; }
+        .totals { color: #57606a; margin-bottom: 1rem; }
+    </style>
+</head>
+<body>
+    <h1>` + html.EscapeString(title) + `</h1>
+    <p class="totals">` + fmt.Sprintf("%d sessions, %d messages, %d tokens over %d days", totalSessions, totalMessages, totalTokens, len(days)) + `</p>
+    <svg width="` + fmt.Sprintf("%d", width) + `" height="` + fmt.Sprintf("%d", height) + `" xmlns="http://www.w3.org/2000/svg">
+        ` + monthLabels.String() + dayLabels + cells.String() + `
+    </svg>
+</body>
+</html>`
+
+	return os.WriteFile(outputFile, []byte(page), 0644)
+}
@@ -0,0 +1,42 @@
+package renderer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActivityLevel(t *testing.T) {
+	assert.Equal(t, 0, ActivityLevel(0, 10))
+	assert.Equal(t, 0, ActivityLevel(3, 0))
+	assert.Equal(t, 1, ActivityLevel(1, 10))
+	assert.Equal(t, 2, ActivityLevel(5, 10))
+	assert.Equal(t, 4, ActivityLevel(10, 10))
+}
+
+func TestGenerateCalendarHTML(t *testing.T) {
+	calendar := map[string]models.DayActivity{
+		"2024-01-01": {Date: "2024-01-01", Sessions: 2, Messages: 10, Tokens: 500},
+		"2024-01-02": {Date: "2024-01-02"},
+		"2024-01-03": {Date: "2024-01-03", Sessions: 1, Messages: 4, Tokens: 100},
+	}
+
+	outputFile := filepath.Join(t.TempDir(), "calendar.html")
+	require.NoError(t, GenerateCalendarHTML(calendar, "myproject", outputFile))
+
+	content, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+
+	html := string(content)
+	assert.Contains(t, html, "<svg")
+	assert.Contains(t, html, "myproject")
+	assert.Contains(t, html, "3 sessions, 14 messages, 600 tokens over 3 days")
+	// Empty days still render as cells
+	assert.Equal(t, 3, strings.Count(html, "<rect"))
+	assert.Contains(t, html, "2024-01-02: 0 sessions")
+}
@@ -6,19 +6,200 @@ import (
 	"github.com/brads3290/cclogviewer/internal/models"
 	"github.com/brads3290/cclogviewer/internal/renderer/ansi"
 	"github.com/brads3290/cclogviewer/internal/renderer/builders"
+	"github.com/brads3290/cclogviewer/internal/utils"
 	"html"
 	"html/template"
+	"io"
 	"os"
 	"regexp"
+	"sort"
 	"strings"
+	"time"
 )
 
 var ansiConverter = ansi.NewANSIConverter()
 
+// pageData is the template data for a single-session page.
+type pageData struct {
+	Entries []*models.ProcessedEntry
+	Debug   bool
+	Refresh int
+	// Health badge, shown when HasHealth is set
+	HasHealth   bool
+	Health      int
+	HealthGrade string
+}
+
 // GenerateHTML renders processed entries to an HTML file.
 func GenerateHTML(entries []*models.ProcessedEntry, outputFile string, debugMode bool) error {
-	// Create custom function map
-	funcMap := template.FuncMap{
+	return writePage(outputFile, pageData{Entries: entries, Debug: debugMode})
+}
+
+// GenerateHTMLWithHealth renders processed entries to an HTML file with a
+// health score badge, colored by grade, under the title.
+func GenerateHTMLWithHealth(entries []*models.ProcessedEntry, outputFile string, healthScore int) error {
+	return writePage(outputFile, pageData{
+		Entries:     entries,
+		HasHealth:   true,
+		Health:      healthScore,
+		HealthGrade: models.HealthGrade(healthScore),
+	})
+}
+
+func writePage(outputFile string, data pageData) error {
+	// Load templates from embedded filesystem
+	tmpl, err := LoadTemplates(templateFuncs())
+	if err != nil {
+		return fmt.Errorf("failed to load templates: %w", err)
+	}
+
+	file, err := os.Create(outputFile)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return ExecuteTemplate(tmpl, file, data)
+}
+
+// GenerateLiveHTML renders processed entries to an HTML file that reloads
+// itself every refreshSeconds, for following a session as it grows. The file
+// is replaced atomically so a reload never sees a partly written page.
+func GenerateLiveHTML(entries []*models.ProcessedEntry, outputFile string, refreshSeconds int) error {
+	tmpl, err := LoadTemplates(templateFuncs())
+	if err != nil {
+		return fmt.Errorf("failed to load templates: %w", err)
+	}
+
+	if refreshSeconds < 1 {
+		refreshSeconds = 1
+	}
+
+	tmpFile := outputFile + ".tmp"
+	file, err := os.Create(tmpFile)
+	if err != nil {
+		return err
+	}
+
+	data := pageData{
+		Entries: entries,
+		Refresh: refreshSeconds,
+	}
+
+	err = ExecuteTemplate(tmpl, file, data)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmpFile)
+		return err
+	}
+
+	return os.Rename(tmpFile, outputFile)
+}
+
+// multiSession is one section of a multi-session page. Label is shown in the
+// sidebar and Title above the section's entries.
+type multiSession struct {
+	ID      string
+	Label   string
+	Title   string
+	Entries []*models.ProcessedEntry
+}
+
+// multiPageData is the template data for a multi-session page.
+type multiPageData struct {
+	Nav      string
+	Sessions []multiSession
+	Debug    bool
+}
+
+// GenerateMultiHTML renders several sessions into one self-contained page with
+// a sidebar listing the sessions and a main pane showing the selected one.
+// Sessions are listed by start time, oldest first, with sessions lacking
+// timestamps last; the first one is shown initially.
+func GenerateMultiHTML(sessions map[string][]*models.ProcessedEntry, w io.Writer) error {
+	tmpl, err := LoadTemplates(templateFuncs())
+	if err != nil {
+		return fmt.Errorf("failed to load templates: %w", err)
+	}
+
+	ids := make([]string, 0, len(sessions))
+	starts := make(map[string]time.Time, len(sessions))
+	for id, entries := range sessions {
+		ids = append(ids, id)
+		starts[id] = sessionStart(entries)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := starts[ids[i]], starts[ids[j]]
+		if a.IsZero() != b.IsZero() {
+			return b.IsZero()
+		}
+		if !a.Equal(b) {
+			return a.Before(b)
+		}
+		return ids[i] < ids[j]
+	})
+
+	data := multiPageData{Nav: "Sessions"}
+	for _, id := range ids {
+		data.Sessions = append(data.Sessions, multiSession{
+			ID:      id,
+			Label:   id,
+			Title:   "Session " + id,
+			Entries: sessions[id],
+		})
+	}
+
+	return tmpl.ExecuteTemplate(w, "multi", data)
+}
+
+// sessionStart returns the earliest timestamp among entries, or the zero time
+// if none can be parsed.
+func sessionStart(entries []*models.ProcessedEntry) time.Time {
+	var start time.Time
+	for _, e := range entries {
+		t, err := utils.ParseTimestamp(e.RawTimestamp)
+		if err != nil {
+			continue
+		}
+		if start.IsZero() || t.Before(start) {
+			start = t
+		}
+	}
+	return start
+}
+
+// GenerateSubagentHTML renders subagent transcripts into one self-contained
+// page with a sidebar listing the subagents by type and a main pane showing
+// the selected transcript under its type header. Transcripts keep the given
+// order; the first one is shown initially.
+func GenerateSubagentHTML(transcripts []models.SubagentTranscript, w io.Writer) error {
+	tmpl, err := LoadTemplates(templateFuncs())
+	if err != nil {
+		return fmt.Errorf("failed to load templates: %w", err)
+	}
+
+	data := multiPageData{Nav: "Subagents"}
+	for _, t := range transcripts {
+		agentType := t.AgentType
+		if agentType == "" {
+			agentType = "subagent"
+		}
+		data.Sessions = append(data.Sessions, multiSession{
+			ID:      t.AgentID,
+			Label:   agentType,
+			Title:   fmt.Sprintf("%s (%s)", agentType, t.AgentID),
+			Entries: t.Entries,
+		})
+	}
+
+	return tmpl.ExecuteTemplate(w, "multi", data)
+}
+
+// templateFuncs returns the custom functions used by the HTML templates.
+func templateFuncs() template.FuncMap {
+	return template.FuncMap{
 		"mul": func(a, b int) int {
 			return a * b
 		},
@@ -121,29 +302,6 @@ func GenerateHTML(entries []*models.ProcessedEntry, outputFile string, debugMode
 			return formatter.Format(toolCall)
 		},
 	}
-
-	// Load templates from embedded filesystem
-	tmpl, err := LoadTemplates(funcMap)
-	if err != nil {
-		return fmt.Errorf("failed to load templates: %w", err)
-	}
-
-	file, err := os.Create(outputFile)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	// Create template data with entries and debug flag
-	data := struct {
-		Entries []*models.ProcessedEntry
-		Debug   bool
-	}{
-		Entries: entries,
-		Debug:   debugMode,
-	}
-
-	return ExecuteTemplate(tmpl, file, data)
 }
 
 // ConvertANSIToHTML converts ANSI escape sequences to styled HTML.
@@ -3,6 +3,7 @@ package renderer
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/brads3290/cclogviewer/internal/models"
@@ -189,6 +190,11 @@ func TestConvertANSIToHTML(t *testing.T) {
 			input: "\x1b[1mBold Text\x1b[0m",
 			want:  `ansi-bold`,
 		},
+		{
+			name:  "cursor and hyperlink escapes",
+			input: "\x1b[2K\x1b[31m\x1b]8;;https://example.com\x07Link\x1b]8;;\x07\x1b[0m",
+			want:  `>Link<`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -203,6 +209,7 @@ func TestConvertANSIToHTML(t *testing.T) {
 			} else {
 				assert.Contains(t, result, tt.want)
 			}
+			assert.NotContains(t, result, "\x1b")
 		})
 	}
 }
@@ -318,4 +325,104 @@ func TestRenderFlatEntries(t *testing.T) {
 	// Verify depth styling is applied
 	assert.Contains(t, html, "depth-1")
 	assert.Contains(t, html, "depth-2")
-}
\ No newline at end of file
+}
+
+func TestGenerateMultiHTML(t *testing.T) {
+	first := testutil.CreateTestProcessedEntry(t, "message", "First session")
+	first.RawTimestamp = "2024-01-01T09:00:00Z"
+	second := testutil.CreateTestProcessedEntry(t, "message", "Second session")
+	second.RawTimestamp = "2024-01-02T09:00:00Z"
+	undated := testutil.CreateTestProcessedEntry(t, "message", "Undated session")
+	undated.RawTimestamp = ""
+	// IDs sort opposite to start times
+	sessions := map[string][]*models.ProcessedEntry{
+		"session-a": {second},
+		"session-b": {first},
+		"session-0": {undated},
+	}
+
+	var buf strings.Builder
+	require.NoError(t, GenerateMultiHTML(sessions, &buf))
+
+	html := buf.String()
+	assert.Contains(t, html, "<!DOCTYPE html>")
+	assert.Contains(t, html, "First session")
+	assert.Contains(t, html, "Second session")
+	assert.Contains(t, html, `data-session="session-a"`)
+	assert.Contains(t, html, `id="session-session-b"`)
+
+	// Sessions are listed by start time, undated last, and the first one starts visible
+	assert.Less(t, strings.Index(html, `data-session="session-b"`), strings.Index(html, `data-session="session-a"`))
+	assert.Less(t, strings.Index(html, `data-session="session-a"`), strings.Index(html, `data-session="session-0"`))
+	assert.Contains(t, html, `session-pane active" id="session-session-b"`)
+
+	// Self-contained: no external stylesheets or scripts
+	assert.NotContains(t, html, "<link")
+	assert.NotContains(t, html, "<script src")
+}
+
+func TestGenerateSubagentHTML(t *testing.T) {
+	transcripts := []models.SubagentTranscript{
+		{AgentID: "agent-2", AgentType: "code-reviewer", Entries: []*models.ProcessedEntry{testutil.CreateTestProcessedEntry(t, "message", "Review done")}},
+		{AgentID: "agent-1", Entries: []*models.ProcessedEntry{testutil.CreateTestProcessedEntry(t, "message", "Search done")}},
+	}
+
+	var buf strings.Builder
+	require.NoError(t, GenerateSubagentHTML(transcripts, &buf))
+
+	html := buf.String()
+	assert.Contains(t, html, "<h2>Subagents</h2>")
+	assert.Contains(t, html, "<h1>code-reviewer (agent-2)</h1>")
+	assert.Contains(t, html, "<h1>subagent (agent-1)</h1>")
+	assert.Contains(t, html, "Review done")
+	assert.Contains(t, html, "Search done")
+
+	// Transcripts keep their given order
+	assert.Contains(t, html, `session-pane active" id="session-agent-2"`)
+	assert.Less(t, strings.Index(html, `data-session="agent-2"`), strings.Index(html, `data-session="agent-1"`))
+}
+
+func TestGenerateLiveHTML(t *testing.T) {
+	entries := []*models.ProcessedEntry{
+		testutil.CreateTestProcessedEntry(t, "message", "Still going"),
+	}
+
+	tmpfile := filepath.Join(t.TempDir(), "live.html")
+	require.NoError(t, GenerateLiveHTML(entries, tmpfile, 3))
+
+	content, err := os.ReadFile(tmpfile)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `<meta http-equiv="refresh" content="3">`)
+	assert.Contains(t, string(content), "Still going")
+
+	_, err = os.Stat(tmpfile + ".tmp")
+	assert.True(t, os.IsNotExist(err), "temporary file should be renamed into place")
+
+	// Regular output does not reload itself
+	static := filepath.Join(t.TempDir(), "static.html")
+	require.NoError(t, GenerateHTML(entries, static, false))
+	content, err = os.ReadFile(static)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "http-equiv")
+}
+
+func TestGenerateHTMLWithHealth(t *testing.T) {
+	entries := []*models.ProcessedEntry{
+		testutil.CreateTestProcessedEntry(t, "message", "Hello"),
+	}
+
+	tmpfile := filepath.Join(t.TempDir(), "health.html")
+	require.NoError(t, GenerateHTMLWithHealth(entries, tmpfile, 64))
+
+	content, err := os.ReadFile(tmpfile)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `class="health-badge health-fair"`)
+	assert.Contains(t, string(content), "Health 64/100")
+
+	// Plain output has no badge element
+	plain := filepath.Join(t.TempDir(), "plain.html")
+	require.NoError(t, GenerateHTML(entries, plain, false))
+	content, err = os.ReadFile(plain)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), `<div class="health-badge`)
+}
@@ -0,0 +1,225 @@
+package renderer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/brads3290/cclogviewer/internal/constants"
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// maxNotebookCellIDLength is the longest cell id nbformat allows.
+const maxNotebookCellIDLength = 64
+
+// notebookCellIDPattern matches characters not allowed in a cell id.
+var notebookCellIDPattern = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+
+// notebook is the subset of the nbformat 4 document that the exporter writes.
+type notebook struct {
+	Cells         []notebookCell   `json:"cells"`
+	Metadata      notebookMetadata `json:"metadata"`
+	NBFormat      int              `json:"nbformat"`
+	NBFormatMinor int              `json:"nbformat_minor"`
+}
+
+type notebookMetadata struct {
+	KernelSpec   notebookKernelSpec   `json:"kernelspec"`
+	LanguageInfo notebookLanguageInfo `json:"language_info"`
+}
+
+type notebookKernelSpec struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	Language    string `json:"language"`
+}
+
+type notebookLanguageInfo struct {
+	Name string `json:"name"`
+}
+
+// notebookCell is a markdown or code cell. Outputs and ExecutionCount are
+// pointers so markdown cells omit them while code cells always carry them.
+// ID is required from nbformat 4.5.
+type notebookCell struct {
+	ID             string                 `json:"id"`
+	CellType       string                 `json:"cell_type"`
+	Metadata       map[string]interface{} `json:"metadata"`
+	Source         []string               `json:"source"`
+	ExecutionCount *int                   `json:"execution_count,omitempty"`
+	Outputs        *[]notebookOutput      `json:"outputs,omitempty"`
+}
+
+type notebookOutput struct {
+	OutputType string   `json:"output_type"`
+	Name       string   `json:"name"`
+	Text       []string `json:"text"`
+}
+
+// GenerateNotebook writes the conversation as a Jupyter notebook (nbformat 4).
+// User prompts and assistant text become markdown cells, Bash calls become
+// bash code cells with their result as the cell output, and other tool calls
+// become short markdown descriptions. Subagent conversations are summarized by
+// their Task call rather than expanded.
+func GenerateNotebook(entries []*models.ProcessedEntry, outputFile string) error {
+	nb := buildNotebook(entries)
+
+	data, err := json.MarshalIndent(nb, "", " ")
+	if err != nil {
+		return fmt.Errorf("failed to encode notebook: %w", err)
+	}
+	data = append(data, '\n')
+
+	if err := os.WriteFile(outputFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write notebook: %w", err)
+	}
+	return nil
+}
+
+// buildNotebook converts root entries into notebook cells in log order.
+// Cell ids come from the entry UUID or tool_use id, so re-exports are stable.
+func buildNotebook(entries []*models.ProcessedEntry) notebook {
+	nb := notebook{
+		Cells: make([]notebookCell, 0),
+		Metadata: notebookMetadata{
+			KernelSpec:   notebookKernelSpec{Name: "bash", DisplayName: "Bash", Language: "bash"},
+			LanguageInfo: notebookLanguageInfo{Name: "bash"},
+		},
+		NBFormat:      4,
+		NBFormatMinor: 5,
+	}
+
+	ids := make(map[string]bool)
+	addCell := func(source string, cell notebookCell) {
+		cell.ID = notebookCellID(source, len(nb.Cells), ids)
+		nb.Cells = append(nb.Cells, cell)
+	}
+
+	executionCount := 0
+	for _, e := range entries {
+		if e.IsSidechain || e.IsToolResult || e.IsCaveatMessage {
+			continue
+		}
+
+		switch e.Role {
+		case constants.RoleUser:
+			prompt := strings.TrimSpace(e.Content)
+			if e.IsCommandMessage {
+				prompt = "`" + strings.TrimSpace(e.CommandName+" "+e.CommandArgs) + "`"
+			}
+			if prompt != "" {
+				addCell(e.UUID, markdownCell("**User:**\n\n"+prompt))
+			}
+
+		case constants.RoleAssistant:
+			if text := strings.TrimSpace(e.Content); text != "" {
+				addCell(e.UUID, markdownCell(text))
+			}
+			for _, tc := range e.ToolCalls {
+				if tc.Name == constants.ToolNameBash {
+					executionCount++
+					addCell(tc.ID, bashCell(tc, executionCount))
+					continue
+				}
+				addCell(tc.ID, markdownCell(toolCallMarkdown(tc)))
+			}
+		}
+	}
+
+	return nb
+}
+
+// notebookCellID turns source into a cell id nbformat accepts: 1-64
+// characters from [a-zA-Z0-9-_]. An empty source falls back to the cell
+// index, and a repeated id gets the index appended so ids stay unique.
+func notebookCellID(source string, index int, seen map[string]bool) string {
+	id := notebookCellIDPattern.ReplaceAllString(source, "-")
+	if len(id) > maxNotebookCellIDLength {
+		id = id[:maxNotebookCellIDLength]
+	}
+	if id == "" || seen[id] {
+		suffix := fmt.Sprintf("cell-%d", index)
+		if id != "" && len(id)+1+len(suffix) <= maxNotebookCellIDLength {
+			suffix = id + "-" + suffix
+		}
+		id = suffix
+	}
+	seen[id] = true
+	return id
+}
+
+// markdownCell returns a markdown cell with the given text.
+func markdownCell(text string) notebookCell {
+	return notebookCell{
+		CellType: "markdown",
+		Metadata: map[string]interface{}{},
+		Source:   notebookLines(text),
+	}
+}
+
+// bashCell returns a code cell running the call's command, with its result as
+// a stdout stream, or stderr when the call failed.
+func bashCell(tc models.ToolCall, executionCount int) notebookCell {
+	command := ""
+	if input, ok := tc.RawInput.(map[string]interface{}); ok {
+		command, _ = input["command"].(string)
+	}
+
+	outputs := make([]notebookOutput, 0, 1)
+	if tc.Result != nil && tc.Result.Content != "" {
+		stream := "stdout"
+		if tc.Result.IsError {
+			stream = "stderr"
+		}
+		outputs = append(outputs, notebookOutput{
+			OutputType: "stream",
+			Name:       stream,
+			Text:       notebookLines(tc.Result.Content),
+		})
+	}
+
+	metadata := map[string]interface{}{}
+	if tc.Description != "" {
+		metadata["description"] = tc.Description
+	}
+
+	return notebookCell{
+		CellType:       "code",
+		Metadata:       metadata,
+		Source:         notebookLines(command),
+		ExecutionCount: &executionCount,
+		Outputs:        &outputs,
+	}
+}
+
+// toolCallMarkdown describes a non-Bash tool call as a bold tool name followed
+// by its description, or its input when there is no description.
+func toolCallMarkdown(tc models.ToolCall) string {
+	text := "**" + tc.Name + "**"
+	if tc.Description != "" {
+		text += ": " + tc.Description
+	} else if tc.RawInput != nil {
+		if input, err := json.MarshalIndent(tc.RawInput, "", "  "); err == nil {
+			text += "\n\n```json\n" + string(input) + "\n```"
+		}
+	}
+	if tc.Result != nil && tc.Result.IsError {
+		text += " *(failed)*"
+	}
+	return text
+}
+
+// notebookLines splits text into the line list nbformat uses for sources and
+// stream outputs, keeping the newline on every line but the last.
+func notebookLines(text string) []string {
+	if text == "" {
+		return []string{}
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
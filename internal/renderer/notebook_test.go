@@ -0,0 +1,131 @@
+package renderer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateNotebook(t *testing.T) {
+	entries := []*models.ProcessedEntry{
+		{UUID: "u1", Role: "user", Content: "List the files"},
+		{
+			UUID:    "a1",
+			Role:    "assistant",
+			Content: "Let me look.",
+			ToolCalls: []models.ToolCall{
+				{
+					ID:       "t1",
+					Name:     "Bash",
+					RawInput: map[string]interface{}{"command": "ls -la"},
+					Result:   &models.ProcessedEntry{Content: "a.go\nb.go\n"},
+				},
+				{
+					ID:          "t2",
+					Name:        "Read",
+					Description: "Read a.go",
+					RawInput:    map[string]interface{}{"file_path": "a.go"},
+					Result:      &models.ProcessedEntry{Content: "package a"},
+				},
+				{
+					ID:       "t3",
+					Name:     "Bash",
+					RawInput: map[string]interface{}{"command": "false"},
+					Result:   &models.ProcessedEntry{Content: "exit 1", IsError: true},
+				},
+			},
+		},
+		{UUID: "r1", Role: "user", IsToolResult: true, Content: "a.go\nb.go\n"},
+		{UUID: "s1", Role: "user", IsSidechain: true, Content: "subagent prompt"},
+	}
+
+	outputFile := filepath.Join(t.TempDir(), "session.ipynb")
+	require.NoError(t, GenerateNotebook(entries, outputFile))
+
+	content, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+
+	var nb struct {
+		NBFormat      int `json:"nbformat"`
+		NBFormatMinor int `json:"nbformat_minor"`
+		Metadata      struct {
+			KernelSpec struct {
+				Name string `json:"name"`
+			} `json:"kernelspec"`
+		} `json:"metadata"`
+		Cells []struct {
+			ID             string   `json:"id"`
+			CellType       string   `json:"cell_type"`
+			Source         []string `json:"source"`
+			ExecutionCount *int     `json:"execution_count"`
+			Outputs        []struct {
+				OutputType string   `json:"output_type"`
+				Name       string   `json:"name"`
+				Text       []string `json:"text"`
+			} `json:"outputs"`
+		} `json:"cells"`
+	}
+	require.NoError(t, json.Unmarshal(content, &nb))
+
+	assert.Equal(t, 4, nb.NBFormat)
+	assert.Equal(t, "bash", nb.Metadata.KernelSpec.Name)
+
+	// Tool results and sidechain entries do not get their own cells
+	require.Len(t, nb.Cells, 5)
+
+	// nbformat 4.5 requires a unique id on every cell
+	assert.Equal(t, 5, nb.NBFormatMinor)
+	var ids []string
+	for _, cell := range nb.Cells {
+		ids = append(ids, cell.ID)
+	}
+	assert.Equal(t, []string{"u1", "a1", "t1", "t2", "t3"}, ids)
+
+	assert.Equal(t, "markdown", nb.Cells[0].CellType)
+	assert.Equal(t, "**User:**\n\nList the files", strings.Join(nb.Cells[0].Source, ""))
+
+	assert.Equal(t, "markdown", nb.Cells[1].CellType)
+	assert.Equal(t, []string{"Let me look."}, nb.Cells[1].Source)
+
+	bash := nb.Cells[2]
+	assert.Equal(t, "code", bash.CellType)
+	assert.Equal(t, []string{"ls -la"}, bash.Source)
+	require.NotNil(t, bash.ExecutionCount)
+	assert.Equal(t, 1, *bash.ExecutionCount)
+	require.Len(t, bash.Outputs, 1)
+	assert.Equal(t, "stream", bash.Outputs[0].OutputType)
+	assert.Equal(t, "stdout", bash.Outputs[0].Name)
+	assert.Equal(t, []string{"a.go\n", "b.go\n"}, bash.Outputs[0].Text)
+
+	assert.Equal(t, "markdown", nb.Cells[3].CellType)
+	assert.Equal(t, []string{"**Read**: Read a.go"}, nb.Cells[3].Source)
+
+	failed := nb.Cells[4]
+	assert.Equal(t, "code", failed.CellType)
+	require.NotNil(t, failed.ExecutionCount)
+	assert.Equal(t, 2, *failed.ExecutionCount)
+	require.Len(t, failed.Outputs, 1)
+	assert.Equal(t, "stderr", failed.Outputs[0].Name)
+}
+
+func TestNotebookCellID(t *testing.T) {
+	seen := make(map[string]bool)
+	assert.Equal(t, "toolu_01", notebookCellID("toolu_01", 0, seen))
+	assert.Equal(t, "toolu_01-cell-1", notebookCellID("toolu_01", 1, seen))
+	assert.Equal(t, "cell-2", notebookCellID("", 2, seen))
+	assert.Equal(t, "a-b-c", notebookCellID("a.b c", 3, seen))
+	assert.Len(t, notebookCellID(strings.Repeat("x", 100), 4, seen), 64)
+}
+
+func TestNotebookLines(t *testing.T) {
+	assert.Equal(t, []string{}, notebookLines(""))
+	assert.Equal(t, []string{"one"}, notebookLines("one"))
+	assert.Equal(t, []string{"one\n", "two"}, notebookLines("one\ntwo"))
+	assert.Equal(t, []string{"one\n"}, notebookLines("one\n"))
+}
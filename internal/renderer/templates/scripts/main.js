@@ -120,6 +120,26 @@ document.addEventListener('click', (e) => {
     
 });
 
+// Reveal the entry named in the URL hash (#entry-<uuid>), expanding any
+// collapsed tool calls it is nested in so links from the stats page land on it
+function revealHashTarget() {
+    if (!location.hash) return;
+    const target = document.getElementById(decodeURIComponent(location.hash.slice(1)));
+    if (!target) return;
+
+    for (let elem = target.parentElement; elem; elem = elem.parentElement) {
+        if (elem.classList.contains('tool-call')) {
+            elem.classList.add('expanded');
+        }
+    }
+    document.querySelectorAll('.entry.hash-target').forEach((elem) => elem.classList.remove('hash-target'));
+    target.classList.add('hash-target');
+    target.scrollIntoView({ block: 'center' });
+}
+
+document.addEventListener('DOMContentLoaded', revealHashTarget);
+window.addEventListener('hashchange', revealHashTarget);
+
 // Global state for token details visibility
 let tokenDetailsExpanded = false;
 
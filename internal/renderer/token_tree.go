@@ -0,0 +1,85 @@
+package renderer
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"strings"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// tokenTreeColors maps token tree node kinds to treemap cell colors.
+var tokenTreeColors = map[string]string{
+	models.TokenNodeSession: "#f6f8fa",
+	models.TokenNodeAgent:   "#ddf4ff",
+	models.TokenNodeTool:    "#fff8c5",
+	models.TokenNodeText:    "#dafbe1",
+}
+
+// GenerateTokenTreeHTML writes a token attribution treemap as a standalone HTML
+// file. Nested levels alternate between horizontal and vertical slices, with
+// each cell sized by its share of the parent's tokens.
+func GenerateTokenTreeHTML(tree *models.TokenTree, outputFile string) error {
+	var cells strings.Builder
+	writeTokenTreeNode(&cells, tree, tree.Tokens, 0)
+
+	page := `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <title>` + html.EscapeString(tree.Name) + ` - Token Attribution</title>
+    <style>
+        body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2rem; color: #24292f; }
+        .totals { color: #57606a; margin-bottom: 1rem; }
+        .treemap { display: flex; height: 80vh; }
+        .node { display: flex; flex-direction: column; min-width: 0; min-height: 0; overflow: hidden; border: 1px solid #d0d7de; box-sizing: border-box; }
+        .node.row > .children { flex-direction: row; }
+        .children { display: flex; flex-direction: column; flex: 1; min-height: 0; }
+        .name { font-size: 11px; padding: 2px 4px; white-space: nowrap; overflow: hidden; text-overflow: ellipsis; }
+    </style>
+</head>
+<body>
+    <h1>` + html.EscapeString(tree.Name) + `</h1>
+    <p class="totals">` + fmt.Sprintf("%d tokens", tree.Tokens) + `</p>
+    <div class="treemap">
+        ` + cells.String() + `
+    </div>
+</body>
+</html>`
+
+	return os.WriteFile(outputFile, []byte(page), 0644)
+}
+
+// writeTokenTreeNode renders a node and its children as nested flex boxes.
+func writeTokenTreeNode(b *strings.Builder, node *models.TokenTree, total, depth int) {
+	direction := "column"
+	if depth%2 == 0 {
+		direction = "row"
+	}
+
+	share := 0.0
+	if total > 0 {
+		share = float64(node.Tokens) * 100 / float64(total)
+	}
+
+	fmt.Fprintf(b, `<div class="node %s" style="flex: %d 1 0; background: %s;" title="%s (%s): %d tokens, %.1f%%">`,
+		direction, node.Tokens, tokenTreeColors[node.Kind],
+		html.EscapeString(node.Name), node.Kind, node.Tokens, share)
+	fmt.Fprintf(b, `<div class="name">%s &middot; %d</div>`, html.EscapeString(node.Name), node.Tokens)
+
+	if len(node.Children) > 0 {
+		b.WriteString(`<div class="children">`)
+		// Self tokens get their own cell so children stay proportional
+		if node.SelfTokens > 0 {
+			fmt.Fprintf(b, `<div class="node" style="flex: %d 1 0; background: %s;" title="%s (self): %d tokens"></div>`,
+				node.SelfTokens, tokenTreeColors[node.Kind], html.EscapeString(node.Name), node.SelfTokens)
+		}
+		for _, c := range node.Children {
+			writeTokenTreeNode(b, c, total, depth+1)
+		}
+		b.WriteString(`</div>`)
+	}
+
+	b.WriteString(`</div>`)
+}
@@ -0,0 +1,40 @@
+package renderer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateTokenTreeHTML(t *testing.T) {
+	tree := &models.TokenTree{
+		Name:   "session-1",
+		Kind:   models.TokenNodeSession,
+		Tokens: 1000,
+		Children: []*models.TokenTree{
+			{
+				Name: "Task", Kind: models.TokenNodeTool, Tokens: 700, SelfTokens: 100,
+				Children: []*models.TokenTree{
+					{Name: "Explore", Kind: models.TokenNodeAgent, Tokens: 600, SelfTokens: 600},
+				},
+			},
+			{Name: "<text>", Kind: models.TokenNodeText, Tokens: 300, SelfTokens: 300},
+		},
+	}
+
+	outputFile := filepath.Join(t.TempDir(), "tokens.html")
+	require.NoError(t, GenerateTokenTreeHTML(tree, outputFile))
+
+	content, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+
+	html := string(content)
+	assert.Contains(t, html, "1000 tokens")
+	assert.Contains(t, html, "Explore (agent): 600 tokens, 60.0%")
+	assert.Contains(t, html, "flex: 300 1 0")
+	assert.Contains(t, html, "&lt;text&gt;")
+}
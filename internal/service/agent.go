@@ -1,6 +1,8 @@
 package service
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -41,9 +43,88 @@ func (s *AgentService) ListAgents(projectPath string, includeGlobal bool) ([]mod
 		}
 	}
 
+	// Resolve the effective model, falling back to the global default
+	defaultModel := s.loadDefaultModel()
+	for i := range agents {
+		model := agents[i].Model
+		if model == "" || model == "inherit" {
+			model = defaultModel
+		}
+		agents[i].EffectiveModel = model
+	}
+
 	return agents, nil
 }
 
+// GetAgent returns the agent definition named name, with its full frontmatter
+// and Markdown body. Scope ("global" or "project") picks between definitions
+// of the same name; when empty, a name defined in both scopes is an error.
+// Returns nil if no agent has that name.
+func (s *AgentService) GetAgent(name, projectPath, scope string) (*models.AgentDetail, error) {
+	if scope != "" && scope != "global" && scope != "project" {
+		return nil, fmt.Errorf("invalid scope %q (must be global or project)", scope)
+	}
+
+	agents, err := s.ListAgents(projectPath, scope != "project")
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []models.AgentDefinition
+	for _, agent := range agents {
+		if scope != "" && agent.Scope != scope {
+			continue
+		}
+		if agent.Name == name || strings.TrimSuffix(filepath.Base(agent.FilePath), ".md") == name {
+			matches = append(matches, agent)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, nil
+	case 1:
+	default:
+		return nil, fmt.Errorf("agent %q is defined in both global and project scope; use --scope to choose", name)
+	}
+
+	content, err := os.ReadFile(matches[0].FilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	frontmatter, body := splitFrontmatter(string(content))
+	detail := &models.AgentDetail{
+		AgentDefinition: matches[0],
+		Frontmatter:     make(map[string]interface{}),
+		Body:            strings.TrimSpace(body),
+		Raw:             string(content),
+	}
+	if err := yaml.Unmarshal([]byte(frontmatter), &detail.Frontmatter); err != nil {
+		return nil, fmt.Errorf("failed to parse frontmatter of %s: %w", matches[0].FilePath, err)
+	}
+
+	return detail, nil
+}
+
+// loadDefaultModel reads the default model from ~/.claude/settings.json.
+// Returns an empty string if the file is missing, malformed, or has no model set.
+func (s *AgentService) loadDefaultModel() string {
+	data, err := os.ReadFile(filepath.Join(s.projectService.GetClaudeDir(), "settings.json"))
+	if err != nil {
+		return ""
+	}
+
+	var settings struct {
+		Model string `json:"model"`
+	}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return ""
+	}
+
+	return settings.Model
+}
+
 // loadAgentsFromDir loads agent definitions from a directory.
 func (s *AgentService) loadAgentsFromDir(dir string, scope string) ([]models.AgentDefinition, error) {
 	entries, err := os.ReadDir(dir)
@@ -105,31 +186,25 @@ func parseAgentFile(path string) (*models.AgentDefinition, error) {
 
 // extractFrontmatter extracts YAML frontmatter from markdown content.
 func extractFrontmatter(content string) (string, error) {
+	frontmatter, _ := splitFrontmatter(content)
+	return frontmatter, nil
+}
+
+// splitFrontmatter splits markdown content into its YAML frontmatter and the
+// body that follows. Content without frontmatter is returned whole as the body.
+func splitFrontmatter(content string) (string, string) {
 	lines := strings.Split(content, "\n")
 
 	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
-		return "", nil
+		return "", content
 	}
 
-	var frontmatterLines []string
-	inFrontmatter := false
-
-	for i, line := range lines {
-		trimmed := strings.TrimSpace(line)
-
-		if i == 0 && trimmed == "---" {
-			inFrontmatter = true
-			continue
-		}
-
-		if inFrontmatter && trimmed == "---" {
-			break
-		}
-
-		if inFrontmatter {
-			frontmatterLines = append(frontmatterLines, line)
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			return strings.Join(lines[1:i], "\n"), strings.Join(lines[i+1:], "\n")
 		}
 	}
 
-	return strings.Join(frontmatterLines, "\n"), nil
+	// Unterminated frontmatter runs to the end of the file
+	return strings.Join(lines[1:], "\n"), ""
 }
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"sort"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// excludeAgents drops everything recorded by the given subagents before stats
+// are computed: their entries, and the Task calls that spawned them along
+// with the nested conversation. Entries are copied rather than modified. With
+// no IDs it returns entries unchanged and a nil report; otherwise the report
+// lists the IDs and how many entries went.
+func excludeAgents(entries []*models.ProcessedEntry, agentIDs []string) ([]*models.ProcessedEntry, *models.AgentExclusion) {
+	if len(agentIDs) == 0 {
+		return entries, nil
+	}
+
+	excluded := make(map[string]bool, len(agentIDs))
+	for _, id := range agentIDs {
+		excluded[id] = true
+	}
+
+	report := &models.AgentExclusion{}
+	for id := range excluded {
+		report.AgentIDs = append(report.AgentIDs, id)
+	}
+	sort.Strings(report.AgentIDs)
+
+	return dropAgentEntries(entries, excluded, report), report
+}
+
+func dropAgentEntries(entries []*models.ProcessedEntry, excluded map[string]bool, report *models.AgentExclusion) []*models.ProcessedEntry {
+	kept := make([]*models.ProcessedEntry, 0, len(entries))
+	for _, e := range entries {
+		if e.AgentID != "" && excluded[e.AgentID] {
+			report.EntriesDropped += countEntryTree([]*models.ProcessedEntry{e})
+			continue
+		}
+
+		changed := false
+		calls := make([]models.ToolCall, 0, len(e.ToolCalls))
+		for _, tc := range e.ToolCalls {
+			if len(tc.TaskEntries) == 0 {
+				calls = append(calls, tc)
+				continue
+			}
+			// A Task call's subagent is identified by its first entry
+			if agentID := tc.TaskEntries[0].AgentID; agentID != "" && excluded[agentID] {
+				report.EntriesDropped += countEntryTree(tc.TaskEntries)
+				changed = true
+				continue
+			}
+			before := report.EntriesDropped
+			tc.TaskEntries = dropAgentEntries(tc.TaskEntries, excluded, report)
+			changed = changed || report.EntriesDropped != before
+			calls = append(calls, tc)
+		}
+
+		if changed {
+			copied := *e
+			copied.ToolCalls = calls
+			e = &copied
+		}
+		kept = append(kept, e)
+	}
+	return kept
+}
+
+// countEntryTree counts entries including those nested in Task calls.
+func countEntryTree(entries []*models.ProcessedEntry) int {
+	n := len(entries)
+	for _, e := range entries {
+		for _, tc := range e.ToolCalls {
+			n += countEntryTree(tc.TaskEntries)
+		}
+	}
+	return n
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"sort"
+
+	"github.com/brads3290/cclogviewer/internal/constants"
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// computeAgentTokens groups token usage by the thread that spent it, keyed by
+// AgentID: the main thread (empty AgentID) first, then subagents by total
+// tokens, largest first. Subagent entries nested under Task calls are
+// included. Returns nil when no entry used tokens.
+func computeAgentTokens(entries []*models.ProcessedEntry) []models.AgentTokenUsage {
+	usage := make(map[string]*models.AgentTokenUsage)
+	agentTypes := make(map[string]string)
+	sessionTotal := 0
+
+	var walk func(entries []*models.ProcessedEntry)
+	walk = func(entries []*models.ProcessedEntry) {
+		for _, e := range entries {
+			u, ok := usage[e.AgentID]
+			if !ok {
+				u = &models.AgentTokenUsage{AgentID: e.AgentID}
+				usage[e.AgentID] = u
+			}
+			if e.Role == constants.RoleAssistant && !e.IsToolResult {
+				u.Messages++
+			}
+			u.InputTokens += e.InputTokens
+			u.OutputTokens += e.OutputTokens
+			u.CacheRead += e.CacheReadTokens
+			u.CacheCreation += e.CacheCreationTokens
+
+			for _, tc := range e.ToolCalls {
+				if tc.Name == constants.TaskToolName && len(tc.TaskEntries) > 0 {
+					if agentID := tc.TaskEntries[0].AgentID; agentID != "" {
+						agentTypes[agentID] = subagentName(tc)
+					}
+				}
+				walk(tc.TaskEntries)
+			}
+		}
+	}
+	walk(entries)
+
+	result := make([]models.AgentTokenUsage, 0, len(usage))
+	for id, u := range usage {
+		u.AgentType = agentTypes[id]
+		u.TotalTokens = u.InputTokens + u.OutputTokens + u.CacheRead + u.CacheCreation
+		sessionTotal += u.TotalTokens
+		result = append(result, *u)
+	}
+	if sessionTotal == 0 {
+		return nil
+	}
+
+	for i := range result {
+		result[i].Percent = float64(result[i].TotalTokens) * 100 / float64(sessionTotal)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if (result[i].AgentID == "") != (result[j].AgentID == "") {
+			return result[i].AgentID == ""
+		}
+		if result[i].TotalTokens != result[j].TotalTokens {
+			return result[i].TotalTokens > result[j].TotalTokens
+		}
+		return result[i].AgentID < result[j].AgentID
+	})
+
+	return result
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"sort"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// ProjectAgentTypes returns, per project, the distinct subagent types used in
+// sessions from the last days days, ordered by how many sessions used them.
+// If projectName is empty, all projects are scanned. sessionLimit bounds the
+// sessions parsed per project, newest first (0 for no limit). Projects whose
+// sessions never delegated to a subagent are omitted.
+func (s *SessionService) ProjectAgentTypes(projectName string, days, sessionLimit int) (map[string][]models.AgentTypeUsage, error) {
+	projectsToSearch, err := s.projectService.ResolveProjects(projectName)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]models.AgentTypeUsage)
+	for _, project := range projectsToSearch {
+		sessions, _, err := s.listProjectSessions(&project, days, true, false, sessionLimit)
+		if err != nil {
+			continue
+		}
+
+		counts := make(map[string]int)
+		for _, session := range sessions {
+			for _, agentType := range session.AgentTypesUsed {
+				counts[agentType]++
+			}
+		}
+		if len(counts) == 0 {
+			continue
+		}
+
+		usages := make([]models.AgentTypeUsage, 0, len(counts))
+		for agentType, count := range counts {
+			usages = append(usages, models.AgentTypeUsage{AgentType: agentType, Sessions: count})
+		}
+		sort.Slice(usages, func(i, j int) bool {
+			if usages[i].Sessions != usages[j].Sessions {
+				return usages[i].Sessions > usages[j].Sessions
+			}
+			return usages[i].AgentType < usages[j].AgentType
+		})
+
+		result[project.Name] = usages
+	}
+
+	return result, nil
+}
@@ -0,0 +1,28 @@
+package service
+
+import "github.com/brads3290/cclogviewer/internal/models"
+
+// branchSwitches lists the points in the main conversation where the recorded
+// git branch changed. Entries without a branch are skipped, so a missing
+// value is not reported as a switch. Returns nil if the branch never changed.
+func branchSwitches(entries []*models.ProcessedEntry) []models.BranchSwitch {
+	var switches []models.BranchSwitch
+
+	current := ""
+	for _, e := range entries {
+		if e.IsSidechain || e.GitBranch == "" {
+			continue
+		}
+		if current != "" && e.GitBranch != current {
+			switches = append(switches, models.BranchSwitch{
+				From:      current,
+				To:        e.GitBranch,
+				Timestamp: e.RawTimestamp,
+				UUID:      e.UUID,
+			})
+		}
+		current = e.GitBranch
+	}
+
+	return switches
+}
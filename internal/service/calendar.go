@@ -0,0 +1,100 @@
+package service
+
+import (
+	"time"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// calendarDateFormat is the key format for activity calendar days.
+const calendarDateFormat = "2006-01-02"
+
+// ActivityCalendar returns per-day session, message, and token counts keyed by date.
+// Every day in the range is present, with zero values for days without sessions.
+// The range covers the last days days, or from the first session when days is 0.
+// An empty projectName includes all projects. Token counts include subagents.
+func (s *SessionService) ActivityCalendar(projectName string, days int) (map[string]models.DayActivity, models.ScanReport, error) {
+	var report models.ScanReport
+
+	projectsToSearch, err := s.projectService.ResolveProjects(projectName)
+	if err != nil {
+		return nil, report, err
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	start := today
+	if days > 0 {
+		start = today.AddDate(0, 0, -(days - 1))
+	}
+
+	calendar := make(map[string]models.DayActivity)
+
+	var sessions []models.SessionInfo
+	var keys []string
+	for _, project := range projectsToSearch {
+		projectSessions, projectReport, err := s.listProjectSessions(&project, days, false, false, 0)
+		report.Merge(projectReport)
+		if err != nil {
+			continue
+		}
+
+		for _, session := range projectSessions {
+			if session.StartTime.IsZero() {
+				continue
+			}
+
+			local := session.StartTime.Local()
+			day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.Local)
+			if days > 0 && day.Before(start) {
+				continue
+			}
+			if days == 0 && day.Before(start) {
+				start = day
+			}
+
+			key := day.Format(calendarDateFormat)
+			activity := calendar[key]
+			activity.Date = key
+			activity.Sessions++
+			activity.Messages += session.MessageCount
+			calendar[key] = activity
+
+			sessions = append(sessions, session)
+			keys = append(keys, key)
+		}
+	}
+
+	tokens := make([]int, len(sessions))
+	report.Merge(s.processSessions(sessions, func(i int, processed []*models.ProcessedEntry) {
+		tokens[i] = totalTokens(processed)
+	}))
+	for i, key := range keys {
+		activity := calendar[key]
+		activity.Tokens += tokens[i]
+		calendar[key] = activity
+	}
+
+	// Fill in empty days so the calendar has no gaps
+	for day := start; !day.After(today); day = day.AddDate(0, 0, 1) {
+		key := day.Format(calendarDateFormat)
+		if _, ok := calendar[key]; !ok {
+			calendar[key] = models.DayActivity{Date: key}
+		}
+	}
+
+	return calendar, report, nil
+}
+
+// totalTokens sums input, output, and cache tokens over entries, including
+// subagent entries nested under Task calls.
+func totalTokens(entries []*models.ProcessedEntry) int {
+	total := 0
+	for _, e := range entries {
+		total += e.InputTokens + e.OutputTokens + e.CacheReadTokens + e.CacheCreationTokens
+		for _, tc := range e.ToolCalls {
+			total += totalTokens(tc.TaskEntries)
+		}
+	}
+	return total
+}
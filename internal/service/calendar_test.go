@@ -0,0 +1,31 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActivityCalendar_UnknownProject(t *testing.T) {
+	claudeDir := t.TempDir()
+	writeSession(t, claudeDir, "-home-u-app", "11111111-1111-1111-1111-111111111111",
+		userLine("u1", "2024-01-01T10:00:00Z", "hello"))
+	services := NewServices(claudeDir)
+
+	_, _, err := services.Session.ActivityCalendar("nosuch", 3)
+	assert.ErrorContains(t, err, "project not found")
+}
+
+func TestTotalTokens_IncludesSubagents(t *testing.T) {
+	subagent := []*models.ProcessedEntry{
+		{TokenMetrics: models.TokenMetrics{InputTokens: 100, OutputTokens: 10}},
+		{TokenMetrics: models.TokenMetrics{CacheReadTokens: 5, CacheCreationTokens: 1}},
+	}
+	entries := []*models.ProcessedEntry{
+		{TokenMetrics: models.TokenMetrics{InputTokens: 20, OutputTokens: 2}, ToolCalls: []models.ToolCall{{Name: "Task", TaskEntries: subagent}}},
+		{TokenMetrics: models.TokenMetrics{InputTokens: 3}},
+	}
+
+	assert.Equal(t, 141, totalTokens(entries))
+}
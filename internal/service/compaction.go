@@ -0,0 +1,49 @@
+package service
+
+import (
+	"github.com/brads3290/cclogviewer/internal/constants"
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// computeCompactions returns each compaction boundary with the context size around it.
+// Pre-compaction size comes from the boundary metadata, falling back to the last
+// assistant turn before it; post-compaction size is the first assistant turn after it.
+func computeCompactions(entries []*models.ProcessedEntry) []models.CompactionEvent {
+	var events []models.CompactionEvent
+	lastContext := 0
+	pending := -1 // index of the event still waiting for its post-compaction size
+
+	for _, e := range entries {
+		if e.IsCompaction {
+			pre := e.PreCompactionTokens
+			if pre == 0 {
+				pre = lastContext
+			}
+			events = append(events, models.CompactionEvent{
+				UUID:      e.UUID,
+				Timestamp: e.RawTimestamp,
+				Trigger:   e.CompactionTrigger,
+				PreTokens: pre,
+			})
+			pending = len(events) - 1
+			continue
+		}
+
+		if e.Role != constants.RoleAssistant || e.IsSidechain {
+			continue
+		}
+
+		size := e.InputTokens + e.CacheReadTokens + e.CacheCreationTokens
+		if size == 0 {
+			continue
+		}
+
+		lastContext = size
+		if pending >= 0 {
+			events[pending].PostTokens = size
+			pending = -1
+		}
+	}
+
+	return events
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// Metric names used in baseline comparisons.
+const (
+	MetricTotalTokens     = "total_tokens"
+	MetricOutputTokens    = "output_tokens"
+	MetricToolCalls       = "tool_calls"
+	MetricFailedToolCalls = "failed_tool_calls"
+	MetricErrors          = "errors"
+	MetricDurationMinutes = "duration_minutes"
+)
+
+// comparisonMetrics lists the compared metrics in report order.
+var comparisonMetrics = []string{
+	MetricTotalTokens,
+	MetricOutputTokens,
+	MetricToolCalls,
+	MetricFailedToolCalls,
+	MetricErrors,
+	MetricDurationMinutes,
+}
+
+// DefaultComparisonTolerances is the allowed increase over the baseline, in
+// percent, for each metric. Metrics are "lower is better", so decreases always pass.
+var DefaultComparisonTolerances = map[string]float64{
+	MetricTotalTokens:     20,
+	MetricOutputTokens:    20,
+	MetricToolCalls:       25,
+	MetricFailedToolCalls: 0,
+	MetricErrors:          0,
+	MetricDurationMinutes: 50,
+}
+
+// CompareToBaseline computes stats for a session and a baseline session and
+// reports per-metric deltas. tolerances overrides DefaultComparisonTolerances
+// for the metrics it contains; unknown metric names are rejected.
+func (s *SessionService) CompareToBaseline(sessionID, baselineID, projectName string, tolerances map[string]float64) (*models.StatsComparison, error) {
+	for metric := range tolerances {
+		if _, ok := DefaultComparisonTolerances[metric]; !ok {
+			return nil, fmt.Errorf("unknown metric %q", metric)
+		}
+	}
+
+	current, err := s.GetSessionStats(sessionID, "", projectName, true, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, fmt.Errorf("session not found: %s", sessionID)
+	}
+
+	baseline, err := s.GetSessionStats(baselineID, "", projectName, true, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+	if baseline == nil {
+		return nil, fmt.Errorf("baseline session not found: %s", baselineID)
+	}
+
+	currentValues := comparisonValues(current)
+	baselineValues := comparisonValues(baseline)
+
+	comparison := &models.StatsComparison{
+		SessionID:  sessionID,
+		BaselineID: baselineID,
+		Project:    current.Project,
+		Passed:     true,
+	}
+
+	for _, metric := range comparisonMetrics {
+		tolerance, ok := tolerances[metric]
+		if !ok {
+			tolerance = DefaultComparisonTolerances[metric]
+		}
+
+		m := compareMetric(metric, currentValues[metric], baselineValues[metric], tolerance)
+		if !m.Passed {
+			comparison.Passed = false
+		}
+		comparison.Metrics = append(comparison.Metrics, m)
+	}
+
+	return comparison, nil
+}
+
+// comparisonValues extracts the compared metrics from session stats.
+func comparisonValues(stats *models.SessionStats) map[string]float64 {
+	values := make(map[string]float64, len(comparisonMetrics))
+	if summary := stats.Summary; summary != nil {
+		if t := summary.Tokens; t != nil {
+			values[MetricTotalTokens] = float64(t.TotalInput + t.TotalOutput + t.CacheRead + t.CacheCreation)
+			values[MetricOutputTokens] = float64(t.TotalOutput)
+		}
+		if tc := summary.ToolCalls; tc != nil {
+			values[MetricToolCalls] = float64(tc.Total)
+			values[MetricFailedToolCalls] = float64(tc.Failed)
+		}
+		values[MetricDurationMinutes] = float64(summary.DurationMinutes)
+	}
+	if stats.Errors != nil {
+		values[MetricErrors] = float64(stats.Errors.TotalErrors)
+	}
+	return values
+}
+
+// compareMetric checks that current does not exceed baseline by more than tolerance percent.
+func compareMetric(metric string, current, baseline, tolerance float64) models.MetricComparison {
+	m := models.MetricComparison{
+		Metric:    metric,
+		Current:   current,
+		Baseline:  baseline,
+		Delta:     current - baseline,
+		Tolerance: tolerance,
+	}
+
+	if baseline != 0 {
+		m.DeltaPercent = m.Delta / baseline * 100
+	}
+	m.Passed = current <= baseline*(1+tolerance/100)
+
+	return m
+}
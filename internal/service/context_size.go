@@ -0,0 +1,132 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/brads3290/cclogviewer/internal/constants"
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// GetContextSizeHistory returns the context window size at each assistant turn of a session.
+func (s *SessionService) GetContextSizeHistory(sessionID, agentID, projectName string) (*models.ContextSizeHistory, error) {
+	processed, _, err := s.loadProcessedEntries(sessionID, agentID, projectName, false)
+	if err != nil {
+		return nil, err
+	}
+	if processed == nil {
+		return nil, nil
+	}
+
+	return s.computeContextSizeHistory(sessionID, agentID, processed), nil
+}
+
+// GetContextSizeHistoryFromFile returns the context size history from a JSONL file path.
+func (s *SessionService) GetContextSizeHistoryFromFile(filePath string) (*models.ContextSizeHistory, error) {
+	processed, err := s.loadProcessedEntriesFromFile(filePath, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.computeContextSizeHistory(fileLabel(filePath), "", processed), nil
+}
+
+// computeContextSizeHistory builds one point per main-thread assistant turn that reported usage,
+// or per turn of the agent when agentID is set, since an agent transcript is all sidechain entries.
+// The context size of a turn is the total prompt it was sent: input plus cache read and creation tokens.
+func (s *SessionService) computeContextSizeHistory(sessionID, agentID string, entries []*models.ProcessedEntry) *models.ContextSizeHistory {
+	history := &models.ContextSizeHistory{
+		SessionID: sessionID,
+		Points:    make([]models.ContextSizePoint, 0),
+	}
+
+	if agentID != "" {
+		history.AgentID = &agentID
+	}
+
+	maxIndex := -1
+	previous := 0
+
+	for _, e := range entries {
+		if e.Role != constants.RoleAssistant || (e.IsSidechain && agentID == "") {
+			continue
+		}
+
+		size := e.InputTokens + e.CacheReadTokens + e.CacheCreationTokens
+		if size == 0 {
+			continue
+		}
+
+		history.Points = append(history.Points, models.ContextSizePoint{
+			Step:          len(history.Points) + 1,
+			UUID:          e.UUID,
+			Timestamp:     e.RawTimestamp,
+			ContextTokens: size,
+			Delta:         size - previous,
+		})
+		previous = size
+
+		if size > history.MaxTokens {
+			history.MaxTokens = size
+			maxIndex = len(history.Points) - 1
+		}
+	}
+
+	if maxIndex >= 0 {
+		history.Points[maxIndex].IsMax = true
+		history.MaxStep = history.Points[maxIndex].Step
+	}
+	if n := len(history.Points); n > 0 {
+		history.FinalTokens = history.Points[n-1].ContextTokens
+	}
+
+	return history
+}
+
+// ContextBudget compares a session's current context size, the prompt sent on
+// its last main-thread assistant turn, against the context window of model.
+func (s *SessionService) ContextBudget(sessionID, projectName, model string) (*models.ContextBudget, error) {
+	window, ok := LookupContextWindow(model)
+	if !ok {
+		return nil, fmt.Errorf("unknown context window for model %q", model)
+	}
+
+	history, err := s.GetContextSizeHistory(sessionID, "", projectName)
+	if err != nil {
+		return nil, err
+	}
+	if history == nil {
+		return nil, nil
+	}
+
+	budget := &models.ContextBudget{
+		SessionID:       sessionID,
+		Model:           model,
+		ContextWindow:   window,
+		UsedTokens:      history.FinalTokens,
+		RemainingTokens: window - history.FinalTokens,
+		UsedPercent:     float64(history.FinalTokens) * 100 / float64(window),
+		WarnPercent:     constants.ContextBudgetWarnPercent,
+	}
+	if budget.RemainingTokens < 0 {
+		budget.RemainingTokens = 0
+	}
+	budget.Warning = budget.UsedPercent >= budget.WarnPercent
+
+	return budget, nil
+}
+
+// LookupContextWindow returns the context window for a model ID or alias. ok
+// is false when the model is not in constants.ModelContextWindows.
+func LookupContextWindow(model string) (tokens int, ok bool) {
+	model = strings.ToLower(strings.TrimSpace(model))
+	if model == "" {
+		return 0, false
+	}
+	for _, m := range constants.ModelContextWindows {
+		if strings.Contains(model, m.Match) {
+			return m.Tokens, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/brads3290/cclogviewer/internal/constants"
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeContextSizeHistory_Sidechains(t *testing.T) {
+	entries := []*models.ProcessedEntry{
+		{UUID: "a1", Role: constants.RoleAssistant, TokenMetrics: models.TokenMetrics{InputTokens: 100}},
+		{UUID: "s1", Role: constants.RoleAssistant, IsSidechain: true, AgentID: "agent-1", TokenMetrics: models.TokenMetrics{InputTokens: 50, CacheReadTokens: 10}},
+		{UUID: "s2", Role: constants.RoleAssistant, IsSidechain: true, AgentID: "agent-1", TokenMetrics: models.TokenMetrics{InputTokens: 80}},
+	}
+	services := NewServices(t.TempDir())
+
+	// The main thread ignores subagent turns
+	main := services.Session.computeContextSizeHistory("session-1", "", entries)
+	require.Len(t, main.Points, 1)
+	assert.Equal(t, "a1", main.Points[0].UUID)
+
+	// An agent transcript is made of sidechain entries and keeps them
+	agent := services.Session.computeContextSizeHistory("session-1", "agent-1", entries[1:])
+	require.Len(t, agent.Points, 2)
+	assert.Equal(t, 60, agent.Points[0].ContextTokens)
+	assert.Equal(t, 80, agent.FinalTokens)
+	assert.Equal(t, 20, agent.Points[1].Delta)
+}
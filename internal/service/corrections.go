@@ -0,0 +1,145 @@
+package service
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/brads3290/cclogviewer/internal/constants"
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// DefaultCorrectionPhrases are phrases that mark an assistant turn as correcting
+// its own earlier step. They are matched case-insensitively anywhere in the
+// turn's text, and only in the first text-bearing assistant turn after a
+// failed tool call, which keeps ordinary uses of "actually" from matching.
+var DefaultCorrectionPhrases = []string{
+	"actually",
+	"let me fix",
+	"let me correct",
+	"let me try again",
+	"let me try a different",
+	"i made a mistake",
+	"i made an error",
+	"my mistake",
+	"my apologies",
+	"i apologize",
+	"that was wrong",
+	"that didn't work",
+	"that failed",
+	"i see the issue",
+	"i see the problem",
+}
+
+// CorrectionDetector finds self-corrections following failed tool calls.
+type CorrectionDetector struct {
+	phrases []string
+	mu      sync.RWMutex
+}
+
+// DefaultCorrectionDetector is the detector used by ExtractCorrections.
+var DefaultCorrectionDetector = NewCorrectionDetector(DefaultCorrectionPhrases)
+
+// NewCorrectionDetector creates a detector matching the given phrases.
+func NewCorrectionDetector(phrases []string) *CorrectionDetector {
+	d := &CorrectionDetector{}
+	d.Configure(phrases)
+	return d
+}
+
+// Configure replaces the phrase set. Blank phrases are ignored.
+func (d *CorrectionDetector) Configure(phrases []string) {
+	var normalized []string
+	for _, p := range phrases {
+		if p = strings.ToLower(strings.TrimSpace(p)); p != "" {
+			normalized = append(normalized, p)
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.phrases = normalized
+}
+
+// Match returns the first configured phrase found in text, or "".
+func (d *CorrectionDetector) Match(text string) string {
+	lower := strings.ToLower(text)
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	for _, p := range d.phrases {
+		if strings.Contains(lower, p) {
+			return p
+		}
+	}
+	return ""
+}
+
+// ExtractCorrections returns the assistant self-corrections in a session,
+// including those inside subagent conversations.
+func (s *SessionService) ExtractCorrections(sessionID, projectName string) ([]models.Correction, error) {
+	processed, _, err := s.loadProcessedEntries(sessionID, "", projectName, false)
+	if err != nil {
+		return nil, err
+	}
+	if processed == nil {
+		return nil, nil
+	}
+
+	return DefaultCorrectionDetector.Detect(processed), nil
+}
+
+// ExtractCorrectionsFromFile returns self-corrections from a JSONL file path.
+func (s *SessionService) ExtractCorrectionsFromFile(filePath string) ([]models.Correction, error) {
+	processed, err := s.loadProcessedEntriesFromFile(filePath, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return DefaultCorrectionDetector.Detect(processed), nil
+}
+
+// Detect scans each conversation in log order. After a failed tool call, the
+// next assistant turn with text is checked for a correction phrase; either way
+// the failure is then considered answered.
+func (d *CorrectionDetector) Detect(entries []*models.ProcessedEntry) []models.Correction {
+	corrections := make([]models.Correction, 0)
+
+	var walk func(entries []*models.ProcessedEntry)
+	walk = func(entries []*models.ProcessedEntry) {
+		var failed *models.ProcessedEntry
+		var failedCall models.ToolCall
+
+		for _, e := range entries {
+			if e.Role == constants.RoleAssistant && failed != nil && strings.TrimSpace(e.Content) != "" {
+				if phrase := d.Match(e.Content); phrase != "" {
+					c := models.Correction{
+						BeforeUUID:      failed.UUID,
+						AfterUUID:       e.UUID,
+						Timestamp:       e.Timestamp,
+						Phrase:          phrase,
+						Text:            truncateString(e.Content, 500),
+						FailedTool:      failedCall.Name,
+						FailedToolUseID: failedCall.ID,
+						IsSidechain:     e.IsSidechain,
+						AgentID:         e.AgentID,
+					}
+					if failedCall.Result != nil {
+						c.Error = truncateString(failedCall.Result.Content, 500)
+					}
+					corrections = append(corrections, c)
+				}
+				failed = nil
+			}
+
+			for _, tc := range e.ToolCalls {
+				if tc.Result != nil && tc.Result.IsError {
+					failed, failedCall = e, tc
+				}
+				walk(tc.TaskEntries)
+			}
+		}
+	}
+	walk(entries)
+
+	return corrections
+}
@@ -0,0 +1,58 @@
+package service
+
+import "strings"
+
+// ModelRates is the price of a model in USD per million tokens.
+type ModelRates struct {
+	Input         float64
+	Output        float64
+	CacheRead     float64
+	CacheCreation float64
+}
+
+// Cost returns the USD cost of the given token counts.
+func (r ModelRates) Cost(input, output, cacheRead, cacheCreation int) float64 {
+	return (float64(input)*r.Input +
+		float64(output)*r.Output +
+		float64(cacheRead)*r.CacheRead +
+		float64(cacheCreation)*r.CacheCreation) / 1_000_000
+}
+
+// modelRates lists published prices by model ID fragment. More specific
+// fragments come first so "opus-4-5" is not priced as "opus-4".
+var modelRates = []struct {
+	match string
+	rates ModelRates
+}{
+	{"opus-4-5", ModelRates{Input: 5, Output: 25, CacheRead: 0.50, CacheCreation: 6.25}},
+	{"opus-4", ModelRates{Input: 15, Output: 75, CacheRead: 1.50, CacheCreation: 18.75}},
+	{"sonnet-4", ModelRates{Input: 3, Output: 15, CacheRead: 0.30, CacheCreation: 3.75}},
+	{"3-7-sonnet", ModelRates{Input: 3, Output: 15, CacheRead: 0.30, CacheCreation: 3.75}},
+	{"haiku-4-5", ModelRates{Input: 1, Output: 5, CacheRead: 0.10, CacheCreation: 1.25}},
+	{"3-5-haiku", ModelRates{Input: 0.80, Output: 4, CacheRead: 0.08, CacheCreation: 1}},
+}
+
+// modelAliases maps the short names accepted by Claude Code's --model flag to model IDs.
+var modelAliases = map[string]string{
+	"opus":   "claude-opus-4-5",
+	"sonnet": "claude-sonnet-4-5",
+	"haiku":  "claude-haiku-4-5",
+}
+
+// LookupModelRates returns the rates for a model ID or alias. ok is false when
+// the model has no known price, in which case callers should omit cost.
+func LookupModelRates(model string) (rates ModelRates, ok bool) {
+	model = strings.ToLower(strings.TrimSpace(model))
+	if id, alias := modelAliases[model]; alias {
+		model = id
+	}
+	if model == "" {
+		return ModelRates{}, false
+	}
+	for _, m := range modelRates {
+		if strings.Contains(model, m.match) {
+			return m.rates, true
+		}
+	}
+	return ModelRates{}, false
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/brads3290/cclogviewer/internal/parser"
+)
+
+// DefaultDiscoveryDepth is how many subdirectory levels below a project
+// directory recursive discovery searches for session files.
+const DefaultDiscoveryDepth = 3
+
+// sessionFile is a session log found in a project directory.
+type sessionFile struct {
+	ID   string
+	Path string
+	Info fs.FileInfo
+}
+
+// SetDiscoveryDepth sets how many subdirectory levels below a project
+// directory are searched for session files when listing or looking up
+// sessions. 0, the default, only reads the project directory itself.
+// subagents/ directories are never searched; their files belong to the
+// session that spawned them.
+func (s *SessionService) SetDiscoveryDepth(depth int) {
+	if depth < 0 {
+		depth = 0
+	}
+	s.discoveryDepth = depth
+}
+
+// sessionFiles returns the session files in projectDir and, down to the
+// configured discovery depth, its subdirectories. Files in shallower
+// directories come first, in name order within each directory; a session ID
+// found more than once keeps its shallowest file.
+func (s *SessionService) sessionFiles(projectDir string) ([]sessionFile, error) {
+	var files []sessionFile
+	seen := make(map[string]bool)
+
+	dirs := []string{projectDir}
+	for depth := 0; len(dirs) > 0 && depth <= s.discoveryDepth; depth++ {
+		var next []string
+		for _, dir := range dirs {
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				// Only the project directory itself must be readable
+				if depth == 0 {
+					return nil, err
+				}
+				continue
+			}
+
+			for _, entry := range entries {
+				path := filepath.Join(dir, entry.Name())
+				if entry.IsDir() {
+					if entry.Name() != "subagents" {
+						next = append(next, path)
+					}
+					continue
+				}
+
+				sessionID, ok := parser.SessionIDFromFilename(entry.Name())
+				if !ok || seen[sessionID] {
+					continue
+				}
+				info, err := entry.Info()
+				if err != nil {
+					continue
+				}
+				seen[sessionID] = true
+				files = append(files, sessionFile{ID: sessionID, Path: path, Info: info})
+			}
+		}
+		dirs = next
+	}
+
+	return files, nil
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// DumpEntries returns the processed entry tree of a session, including
+// sidechains, for inspecting what the processor produced. Returns nil if the
+// session is not found.
+func (s *SessionService) DumpEntries(sessionID, projectName string) ([]*models.ProcessedEntry, error) {
+	processed, _, err := s.loadProcessedEntries(sessionID, "", projectName, true)
+	if err != nil {
+		return nil, err
+	}
+	if processed == nil {
+		return nil, nil
+	}
+
+	return rootEntries(processed), nil
+}
+
+// DumpEntriesFromFile returns the processed entry tree of a JSONL file path.
+func (s *SessionService) DumpEntriesFromFile(filePath string) ([]*models.ProcessedEntry, error) {
+	processed, err := s.loadProcessedEntriesFromFile(filePath, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return rootEntries(processed), nil
+}
+
+// rootEntries drops entries that are also listed as another entry's child.
+// The processor returns sidechain entries both in order and nested under
+// their parent, so serializing every entry would repeat whole subtrees.
+func rootEntries(entries []*models.ProcessedEntry) []*models.ProcessedEntry {
+	nested := make(map[*models.ProcessedEntry]bool)
+	for _, e := range entries {
+		for _, child := range e.Children {
+			nested[child] = true
+		}
+	}
+
+	roots := make([]*models.ProcessedEntry, 0, len(entries))
+	for _, e := range entries {
+		if !nested[e] {
+			roots = append(roots, e)
+		}
+	}
+	return roots
+}
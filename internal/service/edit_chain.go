@@ -0,0 +1,108 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/brads3290/cclogviewer/internal/constants"
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// FileEditChain returns every Edit, MultiEdit, and Write change a session made
+// to filePath, in log order, so the file's evolution can be reviewed or
+// replayed. Changes made by subagents are included. filePath matches a call's
+// file_path exactly, or as a trailing path such as "internal/app.go".
+func (s *SessionService) FileEditChain(sessionID, projectName, filePath string) ([]models.EditOp, error) {
+	processed, _, err := s.loadProcessedEntries(sessionID, "", projectName, false)
+	if err != nil {
+		return nil, err
+	}
+	if processed == nil {
+		return nil, nil
+	}
+
+	return collectEditChain(processed, filePath), nil
+}
+
+// FileEditChainFromFile returns the edit chain for filePath from a JSONL file path.
+func (s *SessionService) FileEditChainFromFile(logPath, filePath string) ([]models.EditOp, error) {
+	processed, err := s.loadProcessedEntriesFromFile(logPath, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectEditChain(processed, filePath), nil
+}
+
+// collectEditChain flattens the file-modifying calls on filePath into ops.
+func collectEditChain(entries []*models.ProcessedEntry, filePath string) []models.EditOp {
+	ops := make([]models.EditOp, 0)
+
+	var walk func(entries []*models.ProcessedEntry)
+	walk = func(entries []*models.ProcessedEntry) {
+		for _, e := range entries {
+			for _, tc := range e.ToolCalls {
+				walk(tc.TaskEntries)
+
+				input, ok := tc.RawInput.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				path, _ := input["file_path"].(string)
+				if !editPathMatches(path, filePath) {
+					continue
+				}
+
+				op := models.EditOp{
+					UUID:        e.UUID,
+					ToolUseID:   tc.ID,
+					Timestamp:   e.RawTimestamp,
+					Tool:        tc.Name,
+					FilePath:    path,
+					Failed:      tc.Result != nil && tc.Result.IsError,
+					IsSidechain: e.IsSidechain,
+				}
+
+				switch tc.Name {
+				case constants.ToolNameEdit:
+					ops = append(ops, editOp(op, input))
+				case constants.ToolNameMultiEdit:
+					edits, _ := input["edits"].([]interface{})
+					for i, item := range edits {
+						edit, ok := item.(map[string]interface{})
+						if !ok {
+							continue
+						}
+						op.EditIndex = i
+						ops = append(ops, editOp(op, edit))
+					}
+				case constants.ToolNameWrite:
+					op.NewString, _ = input["content"].(string)
+					ops = append(ops, op)
+				}
+			}
+		}
+	}
+	walk(entries)
+
+	return ops
+}
+
+// editOp fills op's strings from an Edit input or a single MultiEdit edit.
+func editOp(op models.EditOp, edit map[string]interface{}) models.EditOp {
+	op.OldString, _ = edit["old_string"].(string)
+	op.NewString, _ = edit["new_string"].(string)
+	op.ReplaceAll, _ = edit["replace_all"].(bool)
+	return op
+}
+
+// editPathMatches reports whether a call's file_path is target, or ends with
+// target at a path separator.
+func editPathMatches(path, target string) bool {
+	if path == "" || target == "" {
+		return false
+	}
+	if path == target {
+		return true
+	}
+	return strings.HasSuffix(path, "/"+strings.TrimPrefix(target, "/"))
+}
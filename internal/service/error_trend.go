@@ -0,0 +1,118 @@
+package service
+
+import (
+	"time"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// DefaultTrendBucketDays is the bucket width of an error trend when none is given.
+const DefaultTrendBucketDays = 7
+
+// ErrorTrend buckets a project's sessions by start time and returns the error
+// rate of each bucket, oldest first. Buckets are bucketDays wide (a week by
+// default) and the last one ends today; the range covers the last days days,
+// or from the first session when days is 0. Buckets without sessions are
+// included with zero values. Sessions that cannot be read are left out of the
+// rates and listed in the report.
+func (s *SessionService) ErrorTrend(projectName string, days, bucketDays int) ([]models.TrendPoint, models.ScanReport, error) {
+	var report models.ScanReport
+
+	projects, err := s.projectService.ResolveProjects(projectName)
+	if err != nil {
+		return nil, report, err
+	}
+
+	if bucketDays <= 0 {
+		bucketDays = DefaultTrendBucketDays
+	}
+
+	var sessions []models.SessionInfo
+	for _, project := range projects {
+		projectSessions, projectReport, err := s.listProjectSessions(&project, days, false, false, 0)
+		report.Merge(projectReport)
+		if err != nil {
+			return nil, report, err
+		}
+		sessions = append(sessions, projectSessions...)
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	first := today
+	if days > 0 {
+		first = today.AddDate(0, 0, -(days - 1))
+	} else {
+		for _, session := range sessions {
+			if day := localDay(session.StartTime); !session.StartTime.IsZero() && day.Before(first) {
+				first = day
+			}
+		}
+	}
+
+	// Align buckets so the last one ends today
+	span := int(today.Sub(first).Hours()/24+0.5) + 1
+	buckets := (span + bucketDays - 1) / bucketDays
+	start := today.AddDate(0, 0, -(buckets*bucketDays - 1))
+
+	trend := make([]models.TrendPoint, buckets)
+	for i := range trend {
+		bucketStart := start.AddDate(0, 0, i*bucketDays)
+		trend[i].Start = bucketStart.Format(calendarDateFormat)
+		trend[i].End = bucketStart.AddDate(0, 0, bucketDays-1).Format(calendarDateFormat)
+	}
+
+	// Keep the sessions that fall in a bucket, with their bucket index
+	var bucketed []models.SessionInfo
+	var bucketOf []int
+	for _, session := range sessions {
+		if session.StartTime.IsZero() {
+			continue
+		}
+		day := localDay(session.StartTime)
+		if day.Before(first) {
+			continue
+		}
+		i := int(day.Sub(start).Hours()/24+0.5) / bucketDays
+		if i < 0 || i >= buckets {
+			continue
+		}
+		bucketed = append(bucketed, session)
+		bucketOf = append(bucketOf, i)
+	}
+
+	errorCounts := make([]int, len(bucketed))
+	loaded := make([]bool, len(bucketed))
+	report.Merge(s.processSessions(bucketed, func(i int, processed []*models.ProcessedEntry) {
+		errorCounts[i] = s.computeErrors(bucketed[i].SessionID, "", processed, 0).TotalErrors
+		loaded[i] = true
+	}))
+
+	for i, session := range bucketed {
+		if !loaded[i] {
+			continue
+		}
+		point := &trend[bucketOf[i]]
+		point.Sessions++
+		point.Messages += session.MessageCount
+		point.Errors += errorCounts[i]
+	}
+
+	for i := range trend {
+		point := &trend[i]
+		if point.Sessions > 0 {
+			point.ErrorsPerSession = float64(point.Errors) / float64(point.Sessions)
+		}
+		if point.Messages > 0 {
+			point.ErrorsPer1000Messages = float64(point.Errors) * 1000 / float64(point.Messages)
+		}
+	}
+
+	return trend, report, nil
+}
+
+// localDay returns midnight local time of the day t falls on.
+func localDay(t time.Time) time.Time {
+	local := t.Local()
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.Local)
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/brads3290/cclogviewer/internal/constants"
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/processor/tools/diff"
+	"github.com/brads3290/cclogviewer/internal/utils"
+)
+
+// fileEditSnippetLength is the maximum length of a file edit snippet.
+const fileEditSnippetLength = 500
+
+// FindFileEdits finds Edit, MultiEdit, and Write tool calls whose file_path contains
+// fileSubstring, newest first. An empty projectName searches all projects.
+func (s *SessionService) FindFileEdits(fileSubstring, projectName string, days int) ([]models.FileEditHit, error) {
+	projectsToSearch, err := s.projectService.ResolveProjects(projectName)
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]models.FileEditHit, 0)
+	for _, project := range projectsToSearch {
+		sessions, _, err := s.listProjectSessions(&project, days, false, false, 0)
+		if err != nil {
+			continue
+		}
+
+		for _, session := range sessions {
+			sessionHits, err := s.findFileEditsInSession(session.FilePath, session.SessionID, project.Name, fileSubstring)
+			if err != nil {
+				continue
+			}
+			hits = append(hits, sessionHits...)
+		}
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		if !hits[i].Timestamp.Equal(hits[j].Timestamp) {
+			return hits[i].Timestamp.After(hits[j].Timestamp)
+		}
+		return hits[i].UUID < hits[j].UUID
+	})
+
+	return hits, nil
+}
+
+// findFileEditsInSession scans a session file for file-modifying tool calls on matching paths.
+func (s *SessionService) findFileEditsInSession(filePath, sessionID, project, fileSubstring string) ([]models.FileEditHit, error) {
+	entries, err := s.readJSONLFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var hits []models.FileEditHit
+	for _, entry := range entries {
+		var msg map[string]interface{}
+		if err := json.Unmarshal(entry.Message, &msg); err != nil {
+			continue
+		}
+
+		content, ok := msg["content"].([]interface{})
+		if !ok {
+			continue
+		}
+
+		for _, item := range content {
+			m, ok := item.(map[string]interface{})
+			if !ok || m["type"] != "tool_use" {
+				continue
+			}
+
+			name, _ := m["name"].(string)
+			if name != constants.ToolNameEdit && name != constants.ToolNameMultiEdit && name != constants.ToolNameWrite {
+				continue
+			}
+
+			input, _ := m["input"].(map[string]interface{})
+			path, _ := input["file_path"].(string)
+			if path == "" || !strings.Contains(path, fileSubstring) {
+				continue
+			}
+
+			timestamp, _ := utils.ParseTimestamp(entry.Timestamp)
+
+			hits = append(hits, models.FileEditHit{
+				SessionID:   sessionID,
+				Project:     project,
+				UUID:        entry.UUID,
+				Timestamp:   timestamp,
+				Tool:        name,
+				FilePath:    path,
+				Snippet:     truncate(fileEditSnippet(name, input), fileEditSnippetLength),
+				IsSidechain: entry.IsSidechain,
+			})
+		}
+	}
+
+	return hits, nil
+}
+
+// fileEditSnippet renders a diff for edits, or the added content for writes.
+func fileEditSnippet(toolName string, input map[string]interface{}) string {
+	switch toolName {
+	case constants.ToolNameEdit:
+		oldStr, _ := input["old_string"].(string)
+		newStr, _ := input["new_string"].(string)
+		return diff.ComputeUnifiedDiff(oldStr, newStr, 0)
+	case constants.ToolNameMultiEdit:
+		edits, _ := input["edits"].([]interface{})
+		var parts []string
+		for _, e := range edits {
+			edit, ok := e.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			oldStr, _ := edit["old_string"].(string)
+			newStr, _ := edit["new_string"].(string)
+			parts = append(parts, diff.ComputeUnifiedDiff(oldStr, newStr, 0))
+		}
+		return strings.Join(parts, "...\n")
+	case constants.ToolNameWrite:
+		content, _ := input["content"].(string)
+		return diff.ComputeUnifiedDiff("", content, 0)
+	}
+	return ""
+}
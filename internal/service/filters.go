@@ -0,0 +1,88 @@
+package service
+
+import (
+	"github.com/brads3290/cclogviewer/internal/constants"
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// FilterTextOnlyLogs keeps only assistant entries with text content and no tool calls,
+// recording how many entries matched out of the total.
+func FilterTextOnlyLogs(logs *models.SessionLogs) {
+	filtered := make([]models.SessionLogEntry, 0)
+	for _, e := range logs.Entries {
+		if e.Role == constants.RoleAssistant && e.Content != "" && len(e.ToolCalls) == 0 {
+			filtered = append(filtered, e)
+		}
+	}
+
+	// Keep the session total if an earlier filter already recorded it
+	if logs.TotalEntries == 0 {
+		logs.TotalEntries = len(logs.Entries)
+	}
+	logs.FilteredEntries = len(filtered)
+	logs.Entries = filtered
+}
+
+// FilterLogsAfterUUID keeps only the log entries that follow the entry with
+// the given UUID, recording how many remain out of the total. The entry itself
+// is dropped, so a caller can pass the last UUID it processed. Returns an
+// error if no entry has the UUID.
+func FilterLogsAfterUUID(logs *models.SessionLogs, uuid string) error {
+	index := -1
+	for i, e := range logs.Entries {
+		if e.UUID == uuid {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		return errEntryNotFound(uuid)
+	}
+
+	filtered := append(make([]models.SessionLogEntry, 0), logs.Entries[index+1:]...)
+
+	if logs.TotalEntries == 0 {
+		logs.TotalEntries = len(logs.Entries)
+	}
+	logs.FilteredEntries = len(filtered)
+	logs.Entries = filtered
+	return nil
+}
+
+// FilterTextOnlyTimeline keeps only assistant text messages in a timeline and then
+// applies limit. The timeline should be built without a limit so no matches are lost.
+func FilterTextOnlyTimeline(timeline *models.SessionTimeline, limit int) {
+	filtered := make([]models.TimelineEntry, 0)
+	for _, e := range timeline.Timeline {
+		if e.Role == constants.RoleAssistant && e.Type == "message" && e.Summary != "" {
+			filtered = append(filtered, e)
+		}
+	}
+
+	timeline.FilteredEntries = len(filtered)
+	if limit > 0 && len(filtered) > limit {
+		filtered = filtered[:limit]
+	}
+
+	timeline.TotalCost = 0
+	for _, e := range filtered {
+		timeline.TotalCost += e.Cost
+	}
+
+	timeline.Timeline = filtered
+	timeline.ReturnedEntries = len(filtered)
+}
+
+// ExcludeEmptyAssistant removes empty assistant responses from a summary's
+// message counts and recomputes the user/assistant ratio and health score
+// without them. EmptyAssistantMessages still reports how many were found.
+func (s *SessionService) ExcludeEmptyAssistant(summary *models.SessionSummary) {
+	summary.AssistantMsgs -= summary.EmptyAssistantMessages
+	summary.MessageCount -= summary.EmptyAssistantMessages
+
+	summary.UserAssistantRatio = 0
+	if summary.AssistantMsgs > 0 {
+		summary.UserAssistantRatio = float64(summary.UserPrompts) / float64(summary.AssistantMsgs)
+	}
+	summary.HealthScore = s.healthScore(summary)
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// FindEntryByUUID locates the entries whose UUID starts with uuidPrefix
+// (case-insensitive), scanning every session of projectName, or of all
+// projects when projectName is empty. Every match is returned, so an
+// ambiguous prefix yields several locations. Sessions that cannot be read are
+// listed in the report.
+func (s *SessionService) FindEntryByUUID(uuidPrefix, projectName string) ([]models.EntryLocation, models.ScanReport, error) {
+	var report models.ScanReport
+
+	uuidPrefix = strings.ToLower(strings.TrimSpace(uuidPrefix))
+	if uuidPrefix == "" {
+		return nil, report, fmt.Errorf("UUID prefix is required")
+	}
+
+	projects, err := s.projectService.ResolveProjects(projectName)
+	if err != nil {
+		return nil, report, err
+	}
+
+	locations := make([]models.EntryLocation, 0)
+	for _, project := range projects {
+		files, err := s.sessionFiles(s.projectService.GetProjectDir(project.EncodedPath))
+		if err != nil {
+			continue
+		}
+
+		for _, file := range files {
+			report.SessionsScanned++
+			entries, err := s.readJSONLFile(file.Path)
+			if err != nil {
+				report.AddFailure(file.ID, project.Name, file.Path, err)
+				continue
+			}
+
+			for i, e := range entries {
+				if !strings.HasPrefix(strings.ToLower(e.UUID), uuidPrefix) {
+					continue
+				}
+				locations = append(locations, models.EntryLocation{
+					UUID:        e.UUID,
+					SessionID:   file.ID,
+					Project:     project.Name,
+					Index:       i,
+					Timestamp:   e.Timestamp,
+					Type:        e.Type,
+					IsSidechain: e.IsSidechain,
+					AgentID:     e.AgentID,
+				})
+			}
+		}
+	}
+
+	return locations, report, nil
+}
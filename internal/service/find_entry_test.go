@@ -0,0 +1,27 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindEntryByUUID(t *testing.T) {
+	claudeDir := t.TempDir()
+	writeSession(t, claudeDir, "-home-u-app", "11111111-1111-1111-1111-111111111111",
+		userLine("abc-1", "2024-01-01T10:00:00Z", "hello"),
+		assistantLine("abd-2", "2024-01-01T10:00:01Z", "hi"))
+	services := NewServices(claudeDir)
+
+	locations, report, err := services.Session.FindEntryByUUID("ABD", "")
+	require.NoError(t, err)
+	require.Len(t, locations, 1)
+	assert.Equal(t, "abd-2", locations[0].UUID)
+	assert.Equal(t, 1, locations[0].Index)
+	assert.Equal(t, 1, report.SessionsScanned)
+	assert.Empty(t, report.Failures)
+
+	_, _, err = services.Session.FindEntryByUUID("abd", "nosuch")
+	assert.ErrorContains(t, err, "project not found")
+}
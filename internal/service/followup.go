@@ -0,0 +1,73 @@
+package service
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// defaultFollowupPhrases are short replies that continue the current task rather
+// than give a new instruction. Kept deliberately small to avoid false positives.
+var defaultFollowupPhrases = []string{
+	"continue", "go on", "go ahead", "keep going", "proceed",
+	"yes", "y", "yep", "yeah", "ok", "okay", "sure",
+	"do it", "sounds good", "lgtm", "thanks", "thank you",
+}
+
+// DefaultFollowupMaxLength is the message length, in characters, at or below
+// which a user message always counts as a follow-up.
+const DefaultFollowupMaxLength = 3
+
+// FollowupDetector classifies user messages as follow-ups.
+type FollowupDetector struct {
+	phrases   map[string]bool
+	maxLength int
+	mu        sync.RWMutex
+}
+
+// DefaultFollowupDetector is the detector used when building timelines.
+var DefaultFollowupDetector = NewFollowupDetector(defaultFollowupPhrases, DefaultFollowupMaxLength)
+
+// NewFollowupDetector creates a detector matching the given phrases and any
+// message of at most maxLength characters.
+func NewFollowupDetector(phrases []string, maxLength int) *FollowupDetector {
+	d := &FollowupDetector{}
+	d.Configure(phrases, maxLength)
+	return d
+}
+
+// Configure replaces the phrase set and short-message threshold.
+func (d *FollowupDetector) Configure(phrases []string, maxLength int) {
+	set := make(map[string]bool, len(phrases))
+	for _, p := range phrases {
+		if n := normalizeFollowup(p); n != "" {
+			set[n] = true
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.phrases = set
+	d.maxLength = maxLength
+}
+
+// IsFollowup reports whether content is a short continuation rather than a new instruction.
+func (d *FollowupDetector) IsFollowup(content string) bool {
+	normalized := normalizeFollowup(content)
+	if normalized == "" {
+		return false
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return utf8.RuneCountInString(normalized) <= d.maxLength || d.phrases[normalized]
+}
+
+// normalizeFollowup lowercases, collapses whitespace, and strips surrounding punctuation.
+func normalizeFollowup(s string) string {
+	s = strings.Join(strings.Fields(strings.ToLower(s)), " ")
+	return strings.TrimFunc(s, func(r rune) bool {
+		return unicode.IsPunct(r) || unicode.IsSpace(r)
+	})
+}
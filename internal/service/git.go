@@ -0,0 +1,41 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/brads3290/cclogviewer/internal/git"
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// gitWindowSlack extends a session's time window when looking for its commits,
+// so a commit made just after the last logged entry is still included.
+const gitWindowSlack = time.Minute
+
+// SessionCommits returns the git commits made on the session's branch in its
+// working directory between the session's first and last entries. An error is
+// returned if the session has no working directory, the directory is not a git
+// repository, or git is not installed; callers can show the session without
+// commits in that case.
+func (s *SessionService) SessionCommits(sessionID, projectName string) ([]models.GitCommit, error) {
+	filePath, project, err := s.findSessionFile(sessionID, projectName)
+	if err != nil {
+		return nil, err
+	}
+	if filePath == "" {
+		return nil, fmt.Errorf("session not found: %s", sessionID)
+	}
+
+	info, err := s.getSessionInfo(filePath, sessionID, project, false)
+	if err != nil {
+		return nil, err
+	}
+	if info.CWD == "" {
+		return nil, fmt.Errorf("session %s has no working directory", sessionID)
+	}
+	if info.StartTime.IsZero() {
+		return nil, fmt.Errorf("session %s has no timestamps", sessionID)
+	}
+
+	return git.Log(info.CWD, info.GitBranch, info.StartTime, info.EndTime.Add(gitWindowSlack))
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// Signal levels at which a health component costs its full weight.
+const (
+	healthErrorRateCeiling     = 0.10 // One message in ten is an error
+	healthFailureRateCeiling   = 0.25 // One tool call in four fails
+	healthRetryRateCeiling     = 0.25 // One tool call in four repeats the one before
+	healthInterruptionsCeiling = 3
+)
+
+// SetHealthWeights sets the weights used for session health scores.
+func (s *SessionService) SetHealthWeights(weights models.HealthWeights) {
+	s.healthWeights = weights
+}
+
+// ComputeHealthScore rates how smoothly a session went from 0 to 100. Each
+// signal (error rate, tool failure rate, retry rate, and interruptions) takes
+// off up to its weight, reaching the full weight at its ceiling. Returns 0 if
+// stats has no summary.
+func (s *SessionService) ComputeHealthScore(stats *models.SessionStats) int {
+	if stats == nil || stats.Summary == nil {
+		return 0
+	}
+	return s.healthScore(stats.Summary)
+}
+
+func (s *SessionService) healthScore(summary *models.SessionSummary) int {
+	w := s.healthWeights
+
+	penalty := 0.0
+	if summary.MessageCount > 0 {
+		penalty += w.Errors * healthShare(float64(summary.ErrorCount)/float64(summary.MessageCount), healthErrorRateCeiling)
+	}
+	if tc := summary.ToolCalls; tc != nil && tc.Total > 0 {
+		penalty += w.ToolFailures * healthShare(float64(tc.Failed)/float64(tc.Total), healthFailureRateCeiling)
+		penalty += w.Retries * healthShare(float64(summary.RetriedToolCalls)/float64(tc.Total), healthRetryRateCeiling)
+	}
+	penalty += w.Interruptions * healthShare(float64(summary.Interruptions), healthInterruptionsCeiling)
+
+	score := int(math.Round(100 - penalty))
+	return max(0, min(100, score))
+}
+
+// healthShare is value as a fraction of ceiling, capped at 1.
+func healthShare(value, ceiling float64) float64 {
+	return math.Min(value/ceiling, 1)
+}
+
+// ParseHealthWeights parses weight overrides such as
+// "errors=40,interruptions=10" on top of the default weights. Keys are
+// errors, tool_failures, retries, and interruptions.
+func ParseHealthWeights(spec string) (models.HealthWeights, error) {
+	weights := models.DefaultHealthWeights()
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return weights, fmt.Errorf("invalid health weight %q: expected name=weight", pair)
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || weight < 0 {
+			return weights, fmt.Errorf("invalid health weight %q: weight must be a non-negative number", pair)
+		}
+
+		switch strings.TrimSpace(key) {
+		case "errors":
+			weights.Errors = weight
+		case "tool_failures":
+			weights.ToolFailures = weight
+		case "retries":
+			weights.Retries = weight
+		case "interruptions":
+			weights.Interruptions = weight
+		default:
+			return weights, fmt.Errorf("unknown health weight %q: use errors, tool_failures, retries, or interruptions", key)
+		}
+	}
+	return weights, nil
+}
+
+// isRetry reports whether call repeats prev: the same tool after prev failed,
+// or the same tool with identical input.
+func isRetry(prev, call *models.ToolCall) bool {
+	if prev == nil || prev.Name != call.Name {
+		return false
+	}
+	if prev.Result != nil && prev.Result.IsError {
+		return true
+	}
+	return prev.RawInput != nil && reflect.DeepEqual(prev.RawInput, call.RawInput)
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/brads3290/cclogviewer/internal/constants"
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// DefaultHeavyReadBytes is the Read result size at or above which a read is
+// reported as heavy.
+const DefaultHeavyReadBytes = 50 * 1024
+
+// binarySniffBytes is how much of a result is inspected when deciding whether
+// it looks binary.
+const binarySniffBytes = 8 * 1024
+
+// SetHeavyReadBytes sets the Read result size at or above which a read counts
+// as heavy in summaries and heavy-read reports. Values <= 0 restore
+// DefaultHeavyReadBytes.
+func (s *SessionService) SetHeavyReadBytes(n int) {
+	if n <= 0 {
+		n = DefaultHeavyReadBytes
+	}
+	s.heavyReadBytes = n
+}
+
+// HeavyReads returns a session's Read calls whose result was at least
+// minBytes long or looked binary, in log order. Reads made by subagents are
+// included. minBytes <= 0 uses the configured threshold.
+func (s *SessionService) HeavyReads(sessionID, projectName string, minBytes int) ([]models.HeavyRead, error) {
+	processed, _, err := s.loadProcessedEntries(sessionID, "", projectName, false)
+	if err != nil {
+		return nil, err
+	}
+	if processed == nil {
+		return nil, nil
+	}
+
+	return collectHeavyReads(processed, s.heavyReadThreshold(minBytes)), nil
+}
+
+// HeavyReadsFromFile returns the heavy Read calls from a JSONL file path.
+func (s *SessionService) HeavyReadsFromFile(filePath string, minBytes int) ([]models.HeavyRead, error) {
+	processed, err := s.loadProcessedEntriesFromFile(filePath, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectHeavyReads(processed, s.heavyReadThreshold(minBytes)), nil
+}
+
+// heavyReadThreshold returns minBytes, or the configured threshold when
+// minBytes is not positive.
+func (s *SessionService) heavyReadThreshold(minBytes int) int {
+	if minBytes > 0 {
+		return minBytes
+	}
+	if s.heavyReadBytes > 0 {
+		return s.heavyReadBytes
+	}
+	return DefaultHeavyReadBytes
+}
+
+// collectHeavyReads finds Read results at or above minBytes, or that look
+// binary, in log order.
+func collectHeavyReads(entries []*models.ProcessedEntry, minBytes int) []models.HeavyRead {
+	reads := make([]models.HeavyRead, 0)
+
+	var walk func(entries []*models.ProcessedEntry)
+	walk = func(entries []*models.ProcessedEntry) {
+		for _, e := range entries {
+			for _, tc := range e.ToolCalls {
+				if tc.Name == constants.ToolNameRead && tc.Result != nil {
+					size := len(tc.Result.Content)
+					binary := looksBinary(tc.Result.Content)
+					if size >= minBytes || binary {
+						path := ""
+						if input, ok := tc.RawInput.(map[string]interface{}); ok {
+							path, _ = input["file_path"].(string)
+						}
+						reads = append(reads, models.HeavyRead{
+							UUID:        tc.Result.UUID,
+							ToolUseID:   tc.ID,
+							FilePath:    path,
+							Timestamp:   tc.Result.RawTimestamp,
+							Bytes:       size,
+							Binary:      binary,
+							IsSidechain: e.IsSidechain,
+						})
+					}
+				}
+				walk(tc.TaskEntries)
+			}
+		}
+	}
+	walk(entries)
+
+	return reads
+}
+
+// looksBinary reports whether content appears to be binary data rendered as
+// text: it contains a NUL byte, or more than 10% of its leading characters are
+// invalid UTF-8 or control characters other than whitespace and the escape
+// that starts terminal color codes.
+func looksBinary(content string) bool {
+	if len(content) > binarySniffBytes {
+		// Cut at a rune boundary so a split character is not counted as invalid
+		n := binarySniffBytes
+		for n > 0 && !utf8.RuneStart(content[n]) {
+			n--
+		}
+		content = content[:n]
+	}
+	if content == "" {
+		return false
+	}
+	if strings.IndexByte(content, 0) >= 0 {
+		return true
+	}
+
+	total, odd := 0, 0
+	for i := 0; i < len(content); {
+		r, size := utf8.DecodeRuneInString(content[i:])
+		i += size
+		total++
+		if (r == utf8.RuneError && size == 1) || (unicode.IsControl(r) && !unicode.IsSpace(r) && r != '\x1b') {
+			odd++
+		}
+	}
+
+	return odd*10 > total
+}
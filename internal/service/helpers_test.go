@@ -0,0 +1,33 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeSession writes a session file of JSONL lines under claudeDir's
+// projects/<project> directory and returns its path.
+func writeSession(t *testing.T, claudeDir, project, sessionID string, lines ...string) string {
+	t.Helper()
+
+	dir := filepath.Join(claudeDir, "projects", project)
+	require.NoError(t, os.MkdirAll(dir, 0755))
+
+	path := filepath.Join(dir, sessionID+".jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644))
+	return path
+}
+
+// userLine returns a user message entry with plain text content.
+func userLine(uuid, timestamp, text string) string {
+	return `{"uuid":"` + uuid + `","type":"user","timestamp":"` + timestamp + `","message":{"role":"user","content":"` + text + `"}}`
+}
+
+// assistantLine returns an assistant message entry with one text block.
+func assistantLine(uuid, timestamp, text string) string {
+	return `{"uuid":"` + uuid + `","type":"assistant","timestamp":"` + timestamp + `","message":{"role":"assistant","content":[{"type":"text","text":"` + text + `"}]}}`
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// ExtractHooks returns the hook executions recorded in a session, including those
+// fired inside subagent conversations. Sessions without hook traces yield an empty list.
+func (s *SessionService) ExtractHooks(sessionID, projectName string) ([]models.HookEvent, error) {
+	processed, _, err := s.loadProcessedEntries(sessionID, "", projectName, false)
+	if err != nil {
+		return nil, err
+	}
+	if processed == nil {
+		return nil, nil
+	}
+
+	return collectHookEvents(processed), nil
+}
+
+// ExtractHooksFromFile returns hook executions from a JSONL file path.
+func (s *SessionService) ExtractHooksFromFile(filePath string) ([]models.HookEvent, error) {
+	processed, err := s.loadProcessedEntriesFromFile(filePath, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectHookEvents(processed), nil
+}
+
+// collectHookEvents gathers hook events in log order and fills in the tool name
+// from the matching tool call when the trace only records a tool_use ID.
+func collectHookEvents(entries []*models.ProcessedEntry) []models.HookEvent {
+	hooks := make([]models.HookEvent, 0)
+	toolNames := make(map[string]string)
+
+	var walk func(entries []*models.ProcessedEntry)
+	walk = func(entries []*models.ProcessedEntry) {
+		for _, e := range entries {
+			if e.Hook != nil {
+				hooks = append(hooks, *e.Hook)
+			}
+			for _, tc := range e.ToolCalls {
+				if tc.ID != "" {
+					toolNames[tc.ID] = tc.Name
+				}
+				walk(tc.TaskEntries)
+			}
+		}
+	}
+	walk(entries)
+
+	for i := range hooks {
+		if hooks[i].Tool == "" && hooks[i].ToolUseID != "" {
+			hooks[i].Tool = toolNames[hooks[i].ToolUseID]
+		}
+	}
+
+	return hooks
+}
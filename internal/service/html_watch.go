@@ -0,0 +1,94 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/brads3290/cclogviewer/internal/browser"
+	"github.com/brads3290/cclogviewer/internal/constants"
+	"github.com/brads3290/cclogviewer/internal/processor"
+	"github.com/brads3290/cclogviewer/internal/renderer"
+)
+
+// SessionFilePath returns the JSONL file of a session.
+func (s *SessionService) SessionFilePath(sessionID, projectName string) (string, error) {
+	filePath, _, err := s.findSessionFile(sessionID, projectName)
+	if err != nil {
+		return "", err
+	}
+	if filePath == "" {
+		return "", fmt.Errorf("session not found: %s", sessionID)
+	}
+	return filePath, nil
+}
+
+// FollowHTML renders inputPath to a self-reloading HTML page, then renders it
+// again each time the session file changes, until done is closed. onRender is
+// called after every render with its result or error; only the first render's
+// error stops the follow. Changes to subagent files show up once the main
+// session file is next written.
+// If outputPath is empty, a temporary file is created and auto-opened in the browser.
+func (s *SessionService) FollowHTML(inputPath, outputPath string, openBrowser bool, config WatchConfig, done <-chan struct{}, onRender func(*HTMLGenerationResult, error)) error {
+	if _, err := os.Stat(inputPath); os.IsNotExist(err) {
+		return fmt.Errorf("file not found: %s", inputPath)
+	}
+
+	autoOpen := false
+	if outputPath == "" {
+		baseName := strings.TrimSuffix(filepath.Base(inputPath), filepath.Ext(inputPath))
+		if len(baseName) > 8 {
+			baseName = baseName[:8]
+		}
+		timestamp := time.Now().Format(constants.TempFileTimestampFormat)
+		outputPath = filepath.Join(os.TempDir(), fmt.Sprintf(constants.TempFileNameFormat, baseName, timestamp))
+		autoOpen = true
+	}
+
+	interval := config.Interval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+	refresh := int(interval.Round(time.Second) / time.Second)
+
+	render := func() (*HTMLGenerationResult, error) {
+		entries, err := s.readJSONLFile(inputPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		if err := renderer.GenerateLiveHTML(processor.ProcessEntries(entries), outputPath, refresh); err != nil {
+			return nil, fmt.Errorf("failed to generate HTML: %w", err)
+		}
+		return &HTMLGenerationResult{OutputPath: outputPath, SessionID: fileLabel(inputPath)}, nil
+	}
+
+	result, err := render()
+	if err != nil {
+		return err
+	}
+	if openBrowser || autoOpen {
+		result.OpenedBrowser = browser.OpenInBrowser(outputPath) == nil
+	}
+	onRender(result, nil)
+
+	watcher, err := newSessionWatcher("", filepath.Dir(inputPath), config)
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	target := filepath.Clean(inputPath)
+	for {
+		select {
+		case <-done:
+			return nil
+		case event := <-watcher.Events():
+			if filepath.Clean(event.FilePath) != target || event.Op == SessionRemoved {
+				continue
+			}
+			onRender(render())
+		}
+	}
+}
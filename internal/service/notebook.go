@@ -0,0 +1,73 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/brads3290/cclogviewer/internal/constants"
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/renderer"
+)
+
+// GenerateSessionNotebook exports a session as a Jupyter notebook (.ipynb).
+// If outputPath is empty, the notebook is written to a temporary file.
+func (s *SessionService) GenerateSessionNotebook(sessionID, projectName, outputPath string) (*HTMLGenerationResult, error) {
+	processed, project, err := s.loadProcessedEntries(sessionID, "", projectName, false)
+	if err != nil {
+		return nil, err
+	}
+	if processed == nil {
+		return nil, fmt.Errorf("session not found: %s", sessionID)
+	}
+
+	if outputPath == "" {
+		outputPath = tempNotebookPath(sessionID)
+	}
+
+	if err := renderer.GenerateNotebook(processed, outputPath); err != nil {
+		return nil, fmt.Errorf("failed to generate notebook: %w", err)
+	}
+
+	result := &HTMLGenerationResult{
+		OutputPath: outputPath,
+		SessionID:  sessionID,
+		Project:    project,
+	}
+	result.recordOutput(models.OutputTypeNotebook)
+	return result, nil
+}
+
+// GenerateNotebookFromFile exports a JSONL file path as a Jupyter notebook.
+// If outputPath is empty, the notebook is written to a temporary file.
+func (s *SessionService) GenerateNotebookFromFile(inputPath, outputPath string) (*HTMLGenerationResult, error) {
+	processed, err := s.loadProcessedEntriesFromFile(inputPath, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if outputPath == "" {
+		baseName := filepath.Base(inputPath)
+		outputPath = tempNotebookPath(strings.TrimSuffix(baseName, filepath.Ext(baseName)))
+	}
+
+	if err := renderer.GenerateNotebook(processed, outputPath); err != nil {
+		return nil, fmt.Errorf("failed to generate notebook: %w", err)
+	}
+
+	result := &HTMLGenerationResult{OutputPath: outputPath}
+	result.recordOutput(models.OutputTypeNotebook)
+	return result, nil
+}
+
+// tempNotebookPath returns a timestamped notebook path in the temp directory,
+// named after the first 8 characters of name.
+func tempNotebookPath(name string) string {
+	if len(name) > 8 {
+		name = name[:8]
+	}
+	timestamp := time.Now().Format(constants.TempFileTimestampFormat)
+	return filepath.Join(os.TempDir(), fmt.Sprintf(constants.TempNotebookNameFormat, name, timestamp))
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// Orderings for results gathered across projects. Both are independent of
+// directory iteration order, so output is stable across machines.
+const (
+	// SortByProject groups results by project name, newest session first within each (the default)
+	SortByProject = "project"
+	// SortByTime orders results newest session first regardless of project
+	SortByTime = "time"
+)
+
+// checkResultSort reports an error for an unknown ordering. Empty means SortByProject.
+func checkResultSort(sortBy string) error {
+	switch sortBy {
+	case "", SortByProject, SortByTime:
+		return nil
+	}
+	return fmt.Errorf("invalid sort %q (expected %s or %s)", sortBy, SortByProject, SortByTime)
+}
+
+// eachSession calls fn for every session of projects from the last days days,
+// in the order given by sortBy, until fn returns false. With SortByProject,
+// projects are listed one at a time so stopping early skips the rest.
+func (s *SessionService) eachSession(projects []models.Project, days int, sortBy string, report *models.ScanReport, fn func(models.SessionInfo) bool) {
+	projects = append([]models.Project(nil), projects...)
+	sort.SliceStable(projects, func(i, j int) bool {
+		return projects[i].Name < projects[j].Name
+	})
+
+	var all []models.SessionInfo
+	for _, project := range projects {
+		sessions, projectReport, err := s.listProjectSessions(&project, days, false, false, 0)
+		report.Merge(projectReport)
+		if err != nil {
+			continue
+		}
+		if sortBy == SortByTime {
+			all = append(all, sessions...)
+			continue
+		}
+		for _, session := range sessions {
+			if !fn(session) {
+				return
+			}
+		}
+	}
+
+	sort.SliceStable(all, func(i, j int) bool {
+		if !all[i].StartTime.Equal(all[j].StartTime) {
+			return all[i].StartTime.After(all[j].StartTime)
+		}
+		return all[i].SessionID < all[j].SessionID
+	})
+	for _, session := range all {
+		if !fn(session) {
+			return
+		}
+	}
+}
+
+// sortAgentUsage orders agent usage results as sortBy describes.
+func sortAgentUsage(results []AgentUsageInfo, sortBy string) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if sortBy != SortByTime && results[i].Project != results[j].Project {
+			return results[i].Project < results[j].Project
+		}
+		if !results[i].Timestamp.Equal(results[j].Timestamp) {
+			return results[i].Timestamp.After(results[j].Timestamp)
+		}
+		return results[i].SessionID < results[j].SessionID
+	})
+}
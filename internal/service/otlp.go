@@ -0,0 +1,186 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/utils"
+)
+
+// otlpScopeName is the instrumentation scope reported on exported spans.
+const otlpScopeName = "cclogviewer"
+
+// GetSessionTrace exports a session's tool calls as OTLP/JSON spans under a
+// single session-root span. Tool calls made by a subagent are parented to the
+// Task call that spawned it.
+func (s *SessionService) GetSessionTrace(sessionID, agentID, projectName string, includeSidechains bool) (*models.OTLPTrace, error) {
+	processed, _, err := s.loadProcessedEntries(sessionID, agentID, projectName, includeSidechains)
+	if err != nil {
+		return nil, err
+	}
+	if processed == nil {
+		return nil, nil
+	}
+
+	return buildTrace(sessionID, processed), nil
+}
+
+// buildTrace converts processed entries into an OTLP trace. Span and trace IDs
+// are derived from the session and tool_use IDs, so exporting the same
+// session twice yields the same IDs. A tool call without a result timestamp
+// gets an instantaneous span.
+func buildTrace(sessionID string, entries []*models.ProcessedEntry) *models.OTLPTrace {
+	traceID := otlpID(sessionID, 16)
+	rootID := otlpID(sessionID+"/session", 8)
+
+	var first, last time.Time
+	observe := func(t time.Time) {
+		if first.IsZero() || t.Before(first) {
+			first = t
+		}
+		if t.After(last) {
+			last = t
+		}
+	}
+
+	spans := []models.OTLPSpan{}
+	var walk func(entries []*models.ProcessedEntry, parentID string)
+	walk = func(entries []*models.ProcessedEntry, parentID string) {
+		for _, e := range entries {
+			start, err := utils.ParseTimestamp(e.RawTimestamp)
+			if err != nil {
+				continue
+			}
+			observe(start)
+
+			for _, tc := range e.ToolCalls {
+				end := start
+				if tc.Result != nil {
+					if t, err := utils.ParseTimestamp(tc.Result.RawTimestamp); err == nil && t.After(start) {
+						end = t
+					}
+				}
+				observe(end)
+
+				spanID := otlpID(sessionID+"/"+tc.ID, 8)
+				span := models.OTLPSpan{
+					TraceID:           traceID,
+					SpanID:            spanID,
+					ParentSpanID:      parentID,
+					Name:              tc.Name,
+					Kind:              models.OTLPSpanKindInternal,
+					StartTimeUnixNano: otlpTime(start),
+					EndTimeUnixNano:   otlpTime(end),
+					Attributes: []models.OTLPAttribute{
+						otlpString("tool.name", tc.Name),
+						otlpString("tool.use_id", tc.ID),
+						otlpString("tool.status", toolCallStatus(tc)),
+						otlpString("entry.uuid", e.UUID),
+						otlpInt("tool.output_bytes", toolOutputBytes(tc)),
+					},
+					Status: models.OTLPStatus{Code: models.OTLPStatusOK},
+				}
+				if e.IsSidechain {
+					span.Attributes = append(span.Attributes, otlpBool("tool.sidechain", true))
+					if e.AgentID != "" {
+						span.Attributes = append(span.Attributes, otlpString("agent.id", e.AgentID))
+					}
+				}
+				if tc.CWD != "" {
+					span.Attributes = append(span.Attributes, otlpString("cwd", tc.CWD))
+				}
+				switch {
+				case tc.Result == nil:
+					span.Status.Code = models.OTLPStatusUnset
+				case tc.Result.IsError || tc.IsInterrupted:
+					span.Status = models.OTLPStatus{Code: models.OTLPStatusError, Message: truncateString(tc.Result.Content, 200)}
+				}
+
+				spans = append(spans, span)
+				walk(tc.TaskEntries, spanID)
+			}
+		}
+	}
+	walk(entries, rootID)
+
+	root := models.OTLPSpan{
+		TraceID:           traceID,
+		SpanID:            rootID,
+		Name:              "session " + sessionID,
+		Kind:              models.OTLPSpanKindInternal,
+		StartTimeUnixNano: otlpTime(first),
+		EndTimeUnixNano:   otlpTime(last),
+		Attributes: []models.OTLPAttribute{
+			otlpString("session.id", sessionID),
+			otlpInt("session.tool_calls", len(spans)),
+		},
+		Status: models.OTLPStatus{Code: models.OTLPStatusUnset},
+	}
+
+	return &models.OTLPTrace{
+		ResourceSpans: []models.OTLPResourceSpans{{
+			Resource: models.OTLPResource{
+				Attributes: []models.OTLPAttribute{
+					otlpString("service.name", otlpScopeName),
+					otlpString("session.id", sessionID),
+				},
+			},
+			ScopeSpans: []models.OTLPScopeSpans{{
+				Scope: models.OTLPScope{Name: otlpScopeName},
+				Spans: append([]models.OTLPSpan{root}, spans...),
+			}},
+		}},
+	}
+}
+
+// toolCallStatus reports a tool call's outcome as success, failed,
+// interrupted, or pending when no result was logged.
+func toolCallStatus(tc models.ToolCall) string {
+	switch {
+	case tc.IsInterrupted:
+		return "interrupted"
+	case tc.Result == nil:
+		return "pending"
+	case tc.Result.IsError:
+		return "failed"
+	}
+	return "success"
+}
+
+// toolOutputBytes is the size of a tool call's result content.
+func toolOutputBytes(tc models.ToolCall) int {
+	if tc.Result == nil {
+		return 0
+	}
+	return len(tc.Result.Content)
+}
+
+// otlpID derives a stable hex ID of n bytes from key.
+func otlpID(key string, n int) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:n])
+}
+
+// otlpTime encodes t as Unix nanoseconds, or "0" for the zero time.
+func otlpTime(t time.Time) string {
+	if t.IsZero() {
+		return "0"
+	}
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+func otlpString(key, value string) models.OTLPAttribute {
+	return models.OTLPAttribute{Key: key, Value: models.OTLPAnyValue{StringValue: &value}}
+}
+
+func otlpBool(key string, value bool) models.OTLPAttribute {
+	return models.OTLPAttribute{Key: key, Value: models.OTLPAnyValue{BoolValue: &value}}
+}
+
+func otlpInt(key string, value int) models.OTLPAttribute {
+	s := strconv.Itoa(value)
+	return models.OTLPAttribute{Key: key, Value: models.OTLPAnyValue{IntValue: &s}}
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// traceEntries is a main thread that runs a Task whose subagent makes a
+// failing Bash call, then issues a Read that never got a result.
+func traceEntries() []*models.ProcessedEntry {
+	subagent := []*models.ProcessedEntry{{
+		UUID:         "s1",
+		RawTimestamp: "2024-01-01T10:00:02Z",
+		IsSidechain:  true,
+		AgentID:      "agent-1",
+		ToolCalls: []models.ToolCall{{
+			ID:     "t2",
+			Name:   "Bash",
+			Result: &models.ProcessedEntry{RawTimestamp: "2024-01-01T10:00:03Z", Content: "boom", IsError: true},
+		}},
+	}}
+
+	return []*models.ProcessedEntry{
+		{
+			UUID:         "a1",
+			RawTimestamp: "2024-01-01T10:00:00Z",
+			ToolCalls: []models.ToolCall{{
+				ID:          "t1",
+				Name:        "Task",
+				Result:      &models.ProcessedEntry{RawTimestamp: "2024-01-01T10:00:10Z", Content: "done"},
+				TaskEntries: subagent,
+			}},
+		},
+		{
+			UUID:         "a2",
+			RawTimestamp: "2024-01-01T10:00:20Z",
+			ToolCalls:    []models.ToolCall{{ID: "t3", Name: "Read"}},
+		},
+	}
+}
+
+func TestBuildTrace(t *testing.T) {
+	trace := buildTrace("session-1", traceEntries())
+
+	require.Len(t, trace.ResourceSpans, 1)
+	require.Len(t, trace.ResourceSpans[0].ScopeSpans, 1)
+	spans := trace.ResourceSpans[0].ScopeSpans[0].Spans
+	require.Len(t, spans, 4)
+
+	byName := make(map[string]models.OTLPSpan)
+	for _, span := range spans {
+		byName[span.Name] = span
+		assert.Equal(t, spans[0].TraceID, span.TraceID)
+	}
+	root := spans[0]
+	task, bash, read := byName["Task"], byName["Bash"], byName["Read"]
+
+	// Task hangs off the session root and the subagent's call off the Task
+	assert.Equal(t, "session session-1", root.Name)
+	assert.Empty(t, root.ParentSpanID)
+	assert.Equal(t, root.SpanID, task.ParentSpanID)
+	assert.Equal(t, task.SpanID, bash.ParentSpanID)
+	assert.Equal(t, root.SpanID, read.ParentSpanID)
+
+	nanos := func(raw string) string {
+		ts, err := time.Parse(time.RFC3339, raw)
+		require.NoError(t, err)
+		return strconv.FormatInt(ts.UnixNano(), 10)
+	}
+	assert.Equal(t, nanos("2024-01-01T10:00:00Z"), root.StartTimeUnixNano)
+	assert.Equal(t, nanos("2024-01-01T10:00:20Z"), root.EndTimeUnixNano)
+	assert.Equal(t, nanos("2024-01-01T10:00:10Z"), task.EndTimeUnixNano)
+
+	assert.Equal(t, models.OTLPStatusOK, task.Status.Code)
+	assert.Equal(t, models.OTLPStatusError, bash.Status.Code)
+	assert.Equal(t, "boom", bash.Status.Message)
+
+	// A call without a result is an instantaneous span with unset status
+	assert.Equal(t, read.StartTimeUnixNano, read.EndTimeUnixNano)
+	assert.Equal(t, models.OTLPStatusUnset, read.Status.Code)
+
+	// Exporting again yields the same IDs
+	again := buildTrace("session-1", traceEntries()).ResourceSpans[0].ScopeSpans[0].Spans
+	for i := range spans {
+		assert.Equal(t, spans[i].SpanID, again[i].SpanID)
+		assert.Equal(t, spans[i].TraceID, again[i].TraceID)
+	}
+	other := buildTrace("session-2", traceEntries()).ResourceSpans[0].ScopeSpans[0].Spans
+	assert.NotEqual(t, spans[0].TraceID, other[0].TraceID)
+}
+
+func TestBuildTrace_NoTimestamps(t *testing.T) {
+	entries := []*models.ProcessedEntry{{
+		UUID:      "a1",
+		ToolCalls: []models.ToolCall{{ID: "t1", Name: "Read"}},
+	}}
+
+	spans := buildTrace("session-1", entries).ResourceSpans[0].ScopeSpans[0].Spans
+
+	// Entries without a timestamp produce no spans, and the root has zero times
+	require.Len(t, spans, 1)
+	assert.Equal(t, "0", spans[0].StartTimeUnixNano)
+	assert.Equal(t, "0", spans[0].EndTimeUnixNano)
+}
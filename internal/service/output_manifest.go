@@ -0,0 +1,24 @@
+package service
+
+import (
+	"os"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// RecordOutputFile adds a written file to m with its size on disk. A file
+// that cannot be stat'ed is recorded with size 0.
+func RecordOutputFile(m *models.OutputManifest, path, fileType string) {
+	var size int64
+	if info, err := os.Stat(path); err == nil {
+		size = info.Size()
+	}
+	m.AddFile(path, fileType, size)
+}
+
+// NewOutputManifest returns a manifest of one written file.
+func NewOutputManifest(path, fileType string) *models.OutputManifest {
+	m := &models.OutputManifest{}
+	RecordOutputFile(m, path, fileType)
+	return m
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"sort"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// LargestOutputs returns the topN tool results in a session ranked by content
+// size in bytes, including results inside subagent conversations. topN <= 0
+// returns every result.
+func (s *SessionService) LargestOutputs(sessionID, projectName string, topN int) ([]models.OutputSizeHit, error) {
+	processed, _, err := s.loadProcessedEntries(sessionID, "", projectName, false)
+	if err != nil {
+		return nil, err
+	}
+	if processed == nil {
+		return nil, nil
+	}
+
+	return rankOutputSizes(collectOutputSizes(processed), topN), nil
+}
+
+// LargestOutputsFromFile returns the largest tool results from a JSONL file path.
+func (s *SessionService) LargestOutputsFromFile(filePath string, topN int) ([]models.OutputSizeHit, error) {
+	processed, err := s.loadProcessedEntriesFromFile(filePath, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return rankOutputSizes(collectOutputSizes(processed), topN), nil
+}
+
+// collectOutputSizes measures every tool result in log order.
+func collectOutputSizes(entries []*models.ProcessedEntry) []models.OutputSizeHit {
+	hits := make([]models.OutputSizeHit, 0)
+
+	var walk func(entries []*models.ProcessedEntry)
+	walk = func(entries []*models.ProcessedEntry) {
+		for _, e := range entries {
+			for _, tc := range e.ToolCalls {
+				if tc.Result != nil {
+					hits = append(hits, models.OutputSizeHit{
+						UUID:        tc.Result.UUID,
+						ToolUseID:   tc.ID,
+						Tool:        tc.Name,
+						Timestamp:   tc.Result.RawTimestamp,
+						Bytes:       len(tc.Result.Content),
+						IsSidechain: e.IsSidechain,
+					})
+				}
+				walk(tc.TaskEntries)
+			}
+		}
+	}
+	walk(entries)
+
+	return hits
+}
+
+// rankOutputSizes sorts hits largest first, ties by tool_use ID, and keeps
+// the first topN.
+func rankOutputSizes(hits []models.OutputSizeHit, topN int) []models.OutputSizeHit {
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Bytes != hits[j].Bytes {
+			return hits[i].Bytes > hits[j].Bytes
+		}
+		return hits[i].ToolUseID < hits[j].ToolUseID
+	})
+
+	if topN > 0 && len(hits) > topN {
+		hits = hits[:topN]
+	}
+	return hits
+}
+
+// totalToolOutputBytes sums the content size of every tool result.
+func totalToolOutputBytes(entries []*models.ProcessedEntry) int {
+	total := 0
+	for _, hit := range collectOutputSizes(entries) {
+		total += hit.Bytes
+	}
+	return total
+}
@@ -0,0 +1,23 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRankOutputSizes_TiesByToolUseID(t *testing.T) {
+	hits := []models.OutputSizeHit{
+		{ToolUseID: "t3", Bytes: 10},
+		{ToolUseID: "t1", Bytes: 10},
+		{ToolUseID: "t2", Bytes: 20},
+	}
+
+	ranked := rankOutputSizes(hits, 2)
+
+	require.Len(t, ranked, 2)
+	assert.Equal(t, "t2", ranked[0].ToolUseID)
+	assert.Equal(t, "t1", ranked[1].ToolUseID)
+}
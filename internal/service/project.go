@@ -1,18 +1,21 @@
 package service
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
-	"regexp"
 	"sort"
 	"strings"
 
 	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/parser"
 )
 
 // ProjectService handles project discovery and management.
 type ProjectService struct {
 	claudeDir string
+	// encodedNames makes project lookups treat names as encoded directory names
+	encodedNames bool
 }
 
 // NewProjectService creates a new ProjectService.
@@ -38,25 +41,12 @@ func (s *ProjectService) ListProjects(sortBy string) ([]models.Project, error) {
 			continue
 		}
 
-		encodedPath := entry.Name()
-		decodedPath := decodeProjectPath(encodedPath)
-		projectName := filepath.Base(decodedPath)
-
 		info, err := entry.Info()
 		if err != nil {
 			continue
 		}
 
-		// Count session files
-		sessionCount := countSessionFiles(filepath.Join(projectsDir, encodedPath))
-
-		projects = append(projects, models.Project{
-			Name:         projectName,
-			Path:         decodedPath,
-			EncodedPath:  encodedPath,
-			SessionCount: sessionCount,
-			LastModified: info.ModTime(),
-		})
+		projects = append(projects, s.newProject(entry.Name(), info))
 	}
 
 	// Sort projects
@@ -66,7 +56,12 @@ func (s *ProjectService) ListProjects(sortBy string) ([]models.Project, error) {
 }
 
 // FindProjectByName finds a project by name or partial path match.
+// When encoded names are enabled, name must be the exact encoded directory name.
 func (s *ProjectService) FindProjectByName(name string) (*models.Project, error) {
+	if s.encodedNames {
+		return s.FindProjectByEncodedPath(name)
+	}
+
 	projects, err := s.ListProjects("")
 	if err != nil {
 		return nil, err
@@ -91,6 +86,57 @@ func (s *ProjectService) FindProjectByName(name string) (*models.Project, error)
 	return nil, nil
 }
 
+// ResolveProjects returns the project matching name, or every project when
+// name is empty. A name that matches no project is an error.
+func (s *ProjectService) ResolveProjects(name string) ([]models.Project, error) {
+	if name == "" {
+		return s.ListProjects("")
+	}
+
+	project, err := s.FindProjectByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, fmt.Errorf("project not found: %s", name)
+	}
+	return []models.Project{*project}, nil
+}
+
+// FindProjectByEncodedPath finds a project by its encoded directory name
+// (e.g. "-Users-me-app") without any fuzzy matching.
+func (s *ProjectService) FindProjectByEncodedPath(encoded string) (*models.Project, error) {
+	if encoded == "" || encoded != filepath.Base(encoded) || encoded == "." || encoded == ".." {
+		return nil, fmt.Errorf("invalid encoded project path: %q", encoded)
+	}
+
+	info, err := os.Stat(s.GetProjectDir(encoded))
+	if err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("project not found: no directory %s in %s", encoded, filepath.Join(s.claudeDir, "projects"))
+	}
+
+	project := s.newProject(encoded, info)
+	return &project, nil
+}
+
+// SetEncodedNames controls whether project lookups by name expect encoded directory names.
+func (s *ProjectService) SetEncodedNames(enabled bool) {
+	s.encodedNames = enabled
+}
+
+// newProject builds project metadata for an encoded project directory.
+func (s *ProjectService) newProject(encodedPath string, info os.FileInfo) models.Project {
+	decodedPath := decodeProjectPath(encodedPath)
+
+	return models.Project{
+		Name:         filepath.Base(decodedPath),
+		Path:         decodedPath,
+		EncodedPath:  encodedPath,
+		SessionCount: countSessionFiles(s.GetProjectDir(encodedPath)),
+		LastModified: info.ModTime(),
+	}
+}
+
 // GetProjectDir returns the project directory path.
 func (s *ProjectService) GetProjectDir(encodedPath string) string {
 	return filepath.Join(s.claudeDir, "projects", encodedPath)
@@ -135,14 +181,12 @@ func countSessionFiles(projectDir string) int {
 	}
 
 	count := 0
-	uuidPattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\.jsonl$`)
-
 	for _, entry := range entries {
 		if entry.IsDir() {
 			continue
 		}
 		// Count only main session files (UUID.jsonl), not agent-*.jsonl
-		if uuidPattern.MatchString(entry.Name()) {
+		if _, ok := parser.SessionIDFromFilename(entry.Name()); ok {
 			count++
 		}
 	}
@@ -159,11 +203,17 @@ func sortProjects(projects []models.Project, sortBy string) {
 		})
 	case "session_count":
 		sort.Slice(projects, func(i, j int) bool {
-			return projects[i].SessionCount > projects[j].SessionCount
+			if projects[i].SessionCount != projects[j].SessionCount {
+				return projects[i].SessionCount > projects[j].SessionCount
+			}
+			return projects[i].Name < projects[j].Name
 		})
 	default: // "last_modified" or empty
 		sort.Slice(projects, func(i, j int) bool {
-			return projects[i].LastModified.After(projects[j].LastModified)
+			if !projects[i].LastModified.Equal(projects[j].LastModified) {
+				return projects[i].LastModified.After(projects[j].LastModified)
+			}
+			return projects[i].Name < projects[j].Name
 		})
 	}
 }
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// ProjectSummaries computes the summary of every session in a project, newest
+// first, bounded by days and limit (0 means no bound). Sessions are summarized
+// in parallel; unreadable sessions are listed in the report.
+func (s *SessionService) ProjectSummaries(projectName string, days, limit int) ([]models.SessionSummary, models.ScanReport, error) {
+	var report models.ScanReport
+
+	projects, err := s.projectService.ResolveProjects(projectName)
+	if err != nil {
+		return nil, report, err
+	}
+
+	var sessions []models.SessionInfo
+	for _, project := range projects {
+		projectSessions, projectReport, err := s.listProjectSessions(&project, days, false, false, limit)
+		report.Merge(projectReport)
+		if err != nil {
+			return nil, report, err
+		}
+		sessions = append(sessions, projectSessions...)
+	}
+
+	summaries := make([]*models.SessionSummary, len(sessions))
+	report.Merge(s.processSessions(sessions, func(i int, processed []*models.ProcessedEntry) {
+		summaries[i] = s.computeSummary(sessions[i].SessionID, "", sessions[i].Project, processed)
+	}))
+
+	result := make([]models.SessionSummary, 0, len(sessions))
+	for _, summary := range summaries {
+		if summary != nil {
+			result = append(result, *summary)
+		}
+	}
+	return result, report, nil
+}
+
+// processSessions loads the processed entries of each session, subagents
+// included, on up to DefaultMaxConcurrency workers and passes them to fn with
+// the session's index. Empty sessions are skipped and unreadable ones are
+// recorded in the returned report. fn runs concurrently, so it must only write
+// results for its own index.
+func (s *SessionService) processSessions(sessions []models.SessionInfo, fn func(i int, processed []*models.ProcessedEntry)) models.ScanReport {
+	var report models.ScanReport
+	var mu sync.Mutex
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < DefaultMaxConcurrency && w < len(sessions); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				processed, err := s.loadProcessedEntriesFromFile(sessions[i].FilePath, true)
+				if err != nil {
+					mu.Lock()
+					report.AddFailure(sessions[i].SessionID, sessions[i].Project, sessions[i].FilePath, err)
+					mu.Unlock()
+					continue
+				}
+				if len(processed) == 0 {
+					continue
+				}
+				fn(i, processed)
+			}
+		}()
+	}
+	for i := range sessions {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return report
+}
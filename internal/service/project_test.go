@@ -0,0 +1,69 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodedNames_CrossProject(t *testing.T) {
+	claudeDir := t.TempDir()
+	// Both projects decode to the name "app", so neither can be found again by name
+	writeSession(t, claudeDir, "-home-u-app", "11111111-1111-1111-1111-111111111111",
+		userLine("u1", "2024-01-01T10:00:00Z", "Fix the login bug please"),
+		assistantLine("a1", "2024-01-01T10:00:05Z", "Looking at the login handler"))
+	writeSession(t, claudeDir, "-home-v-app", "22222222-2222-2222-2222-222222222222",
+		userLine("u2", "2024-01-02T10:00:00Z", "Fix the login bug please"),
+		assistantLine("a2", "2024-01-02T10:00:05Z", "Looking at the login handler"))
+
+	services := NewServices(claudeDir)
+	services.Project.SetEncodedNames(true)
+
+	t.Run("calendar", func(t *testing.T) {
+		calendar, _, err := services.Session.ActivityCalendar("", 0)
+		require.NoError(t, err)
+		sessions := 0
+		for _, day := range calendar {
+			sessions += day.Sessions
+		}
+		assert.Equal(t, 2, sessions)
+	})
+
+	t.Run("search", func(t *testing.T) {
+		results, err := services.Search.Search(SearchCriteria{Query: "login handler"})
+		require.NoError(t, err)
+		assert.Equal(t, 2, results.TotalMatches)
+	})
+
+	t.Run("duplicate prompts", func(t *testing.T) {
+		clusters, err := services.Search.FindDuplicatePrompts("", 0, 0)
+		require.NoError(t, err)
+		require.Len(t, clusters, 1)
+		assert.Equal(t, 2, clusters[0].Count)
+	})
+
+	t.Run("error trend", func(t *testing.T) {
+		points, report, err := services.Session.ErrorTrend("-home-v-app", 0, 0)
+		require.NoError(t, err)
+		sessions := 0
+		for _, p := range points {
+			sessions += p.Sessions
+		}
+		assert.Equal(t, 1, sessions)
+		assert.Equal(t, 1, report.SessionsScanned)
+
+		_, _, err = services.Session.ErrorTrend("-home-w-app", 0, 0)
+		assert.Error(t, err)
+	})
+
+	t.Run("tool names", func(t *testing.T) {
+		names, report, err := services.Session.ListToolNames("-home-u-app", 0)
+		require.NoError(t, err)
+		assert.NotNil(t, names)
+		assert.Equal(t, 1, report.SessionsScanned)
+
+		_, _, err = services.Session.ListToolNames("-home-w-app", 0)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/utils"
+)
+
+// DefaultPromptSimilarityThreshold is the minimum similarity for two prompts to be clustered.
+const DefaultPromptSimilarityThreshold = 0.8
+
+// FindDuplicatePrompts clusters the first user prompt of each session by word similarity
+// and returns the clusters that span more than one session, largest first.
+// An empty projectName searches all projects.
+func (s *SearchService) FindDuplicatePrompts(projectName string, days int, threshold float64) ([]models.PromptCluster, error) {
+	if threshold <= 0 || threshold > 1 {
+		threshold = DefaultPromptSimilarityThreshold
+	}
+
+	projectsToSearch, err := s.projectService.ResolveProjects(projectName)
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []models.SessionInfo
+	for _, project := range projectsToSearch {
+		projectSessions, _, err := s.sessionService.listProjectSessions(&project, days, false, false, 0)
+		if err != nil {
+			continue
+		}
+		sessions = append(sessions, projectSessions...)
+	}
+
+	// Oldest first so each cluster is represented by its earliest prompt
+	sort.SliceStable(sessions, func(i, j int) bool {
+		if !sessions[i].StartTime.Equal(sessions[j].StartTime) {
+			return sessions[i].StartTime.Before(sessions[j].StartTime)
+		}
+		return sessions[i].SessionID < sessions[j].SessionID
+	})
+
+	type cluster struct {
+		words map[string]bool
+		data  models.PromptCluster
+	}
+	var clusters []*cluster
+
+	for _, session := range sessions {
+		words := promptWords(session.FirstUserMessage)
+		if len(words) == 0 {
+			continue
+		}
+
+		var match *cluster
+		var best float64
+		for _, c := range clusters {
+			if sim := jaccardSimilarity(words, c.words); sim >= threshold && sim > best {
+				match, best = c, sim
+			}
+		}
+
+		if match == nil {
+			match = &cluster{
+				words: words,
+				data:  models.PromptCluster{Prompt: utils.NormalizeText(session.FirstUserMessage)},
+			}
+			clusters = append(clusters, match)
+			best = 1
+		}
+
+		match.data.Occurrences = append(match.data.Occurrences, models.PromptOccurrence{
+			SessionID:  session.SessionID,
+			Project:    session.Project,
+			StartTime:  session.StartTime,
+			Prompt:     session.FirstUserMessage,
+			Similarity: best,
+		})
+		match.data.Count++
+	}
+
+	result := make([]models.PromptCluster, 0)
+	for _, c := range clusters {
+		if c.data.Count > 1 {
+			result = append(result, c.data)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Prompt < result[j].Prompt
+	})
+
+	return result, nil
+}
+
+// promptWords returns the set of normalized words in a prompt, ignoring punctuation.
+func promptWords(text string) map[string]bool {
+	words := make(map[string]bool)
+	for _, w := range strings.FieldsFunc(strings.ToLower(utils.NormalizeText(text)), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		words[w] = true
+	}
+	return words
+}
+
+// jaccardSimilarity returns the size of the intersection over the size of the union of two word sets.
+func jaccardSimilarity(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+
+	intersection := 0
+	for w := range a {
+		if b[w] {
+			intersection++
+		}
+	}
+
+	union := len(a) + len(b) - intersection
+	return float64(intersection) / float64(union)
+}
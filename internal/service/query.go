@@ -0,0 +1,154 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// Selector picks values out of a session's tool calls. The grammar is:
+//
+//	selector := tool { "." field }
+//	tool     := tool name, e.g. "Edit", or "*" for every tool
+//	field    := object key | array index | "*" (every element or value)
+//
+// Each tool call is queried as an object with the keys id, name, uuid,
+// timestamp, input, result, is_error, and is_sidechain. For example,
+// "Edit.input.file_path" yields the path of every Edit call and
+// "MultiEdit.input.edits.*.old_string" yields each replaced string.
+type Selector struct {
+	Tool   string
+	Fields []string
+}
+
+// ParseSelector parses a dotted selector string.
+func ParseSelector(selector string) (*Selector, error) {
+	if strings.TrimSpace(selector) == "" {
+		return nil, fmt.Errorf("selector is empty")
+	}
+
+	parts := strings.Split(selector, ".")
+	for i, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("invalid selector %q: empty segment at position %d", selector, i+1)
+		}
+	}
+
+	return &Selector{Tool: parts[0], Fields: parts[1:]}, nil
+}
+
+// Query evaluates selector against every tool call in a session, including
+// subagent conversations, and returns the matching values in log order.
+func (s *SessionService) Query(sessionID, projectName, selector string) ([]interface{}, error) {
+	sel, err := ParseSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	processed, _, err := s.loadProcessedEntries(sessionID, "", projectName, false)
+	if err != nil {
+		return nil, err
+	}
+	if processed == nil {
+		return nil, nil
+	}
+
+	return sel.Eval(processed), nil
+}
+
+// QueryFromFile evaluates selector against a JSONL file path.
+func (s *SessionService) QueryFromFile(filePath, selector string) ([]interface{}, error) {
+	sel, err := ParseSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	processed, err := s.loadProcessedEntriesFromFile(filePath, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return sel.Eval(processed), nil
+}
+
+// Eval returns the values selected from the tool calls in entries.
+func (sel *Selector) Eval(entries []*models.ProcessedEntry) []interface{} {
+	values := make([]interface{}, 0)
+
+	var walk func(entries []*models.ProcessedEntry)
+	walk = func(entries []*models.ProcessedEntry) {
+		for _, e := range entries {
+			for _, tc := range e.ToolCalls {
+				if sel.Tool == "*" || sel.Tool == tc.Name {
+					values = selectFields(toolCallObject(e, tc), sel.Fields, values)
+				}
+				walk(tc.TaskEntries)
+			}
+		}
+	}
+	walk(entries)
+
+	return values
+}
+
+// toolCallObject exposes a tool call as a generic object for selection.
+func toolCallObject(e *models.ProcessedEntry, tc models.ToolCall) map[string]interface{} {
+	obj := map[string]interface{}{
+		"id":           tc.ID,
+		"name":         tc.Name,
+		"uuid":         e.UUID,
+		"timestamp":    e.RawTimestamp,
+		"input":        tc.RawInput,
+		"result":       nil,
+		"is_error":     false,
+		"is_sidechain": e.IsSidechain,
+	}
+	if tc.Result != nil {
+		obj["result"] = tc.Result.Content
+		obj["is_error"] = tc.Result.IsError
+	}
+	return obj
+}
+
+// selectFields follows fields from value and appends every match to out.
+// Paths that do not exist are skipped.
+func selectFields(value interface{}, fields []string, out []interface{}) []interface{} {
+	if len(fields) == 0 {
+		return append(out, value)
+	}
+
+	field, rest := fields[0], fields[1:]
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if field == "*" {
+			keys := make([]string, 0, len(v))
+			for k := range v {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				out = selectFields(v[k], rest, out)
+			}
+			return out
+		}
+		if child, ok := v[field]; ok {
+			return selectFields(child, rest, out)
+		}
+	case []interface{}:
+		if field == "*" {
+			for _, child := range v {
+				out = selectFields(child, rest, out)
+			}
+			return out
+		}
+		if i, err := strconv.Atoi(field); err == nil && i >= 0 && i < len(v) {
+			return selectFields(v[i], rest, out)
+		}
+	}
+
+	return out
+}
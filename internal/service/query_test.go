@@ -0,0 +1,78 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSelector(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		want     *Selector
+		wantErr  string
+	}{
+		{name: "tool only", selector: "Edit", want: &Selector{Tool: "Edit", Fields: []string{}}},
+		{name: "tool field", selector: "Edit.input.file_path", want: &Selector{Tool: "Edit", Fields: []string{"input", "file_path"}}},
+		{name: "wildcards", selector: "*.input.edits.*", want: &Selector{Tool: "*", Fields: []string{"input", "edits", "*"}}},
+		{name: "empty", selector: "  ", wantErr: "selector is empty"},
+		{name: "leading dot", selector: ".input", wantErr: "empty segment at position 1"},
+		{name: "double dot", selector: "Edit..file_path", wantErr: "empty segment at position 2"},
+		{name: "trailing dot", selector: "Edit.input.", wantErr: "empty segment at position 3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sel, err := ParseSelector(tt.selector)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, sel)
+		})
+	}
+}
+
+func TestQueryFromFile(t *testing.T) {
+	claudeDir := t.TempDir()
+	path := writeSession(t, claudeDir, "-home-u-app", "11111111-1111-1111-1111-111111111111",
+		userLine("u1", "2024-01-01T10:00:00Z", "Tidy the docs"),
+		`{"uuid":"a1","parentUuid":"u1","type":"assistant","timestamp":"2024-01-01T10:00:01Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t1","name":"Edit","input":{"file_path":"/a.md","old_string":"teh","new_string":"the"}}]}}`,
+		`{"uuid":"r1","parentUuid":"a1","type":"user","timestamp":"2024-01-01T10:00:02Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t1","content":"ok"}]}}`,
+		`{"uuid":"a2","parentUuid":"r1","type":"assistant","timestamp":"2024-01-01T10:00:03Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t2","name":"MultiEdit","input":{"file_path":"/b.md","edits":[{"old_string":"x","new_string":"y"},{"old_string":"p","new_string":"q"}]}}]}}`,
+		`{"uuid":"r2","parentUuid":"a2","type":"user","timestamp":"2024-01-01T10:00:04Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t2","content":"failed","is_error":true}]}}`,
+	)
+	services := NewServices(claudeDir)
+
+	tests := []struct {
+		selector string
+		want     []interface{}
+	}{
+		{"Edit.input.file_path", []interface{}{"/a.md"}},
+		{"*.input.file_path", []interface{}{"/a.md", "/b.md"}},
+		{"*.name", []interface{}{"Edit", "MultiEdit"}},
+		{"MultiEdit.input.edits.*.old_string", []interface{}{"x", "p"}},
+		{"MultiEdit.input.edits.1.new_string", []interface{}{"q"}},
+		{"MultiEdit.is_error", []interface{}{true}},
+		{"Edit.result", []interface{}{"ok"}},
+		{"Edit.input.*", []interface{}{"/a.md", "the", "teh"}},
+		{"MultiEdit.input.edits.5.old_string", []interface{}{}},
+		{"Edit.input.missing", []interface{}{}},
+		{"Bash.input.command", []interface{}{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			values, err := services.Session.QueryFromFile(path, tt.selector)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, values)
+		})
+	}
+
+	_, err := services.Session.QueryFromFile(path, "Edit..file_path")
+	assert.Error(t, err)
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"sort"
+	"time"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// RecentSessions returns the most recently modified sessions across all
+// projects, newest first, each tagged with its project. Session files are
+// ranked by modification time from a stat-only scan, and only as many are
+// parsed as needed to fill limit; empty sessions are skipped. limit <= 0
+// returns every session.
+func (s *SessionService) RecentSessions(limit int) ([]models.SessionInfo, error) {
+	projects, err := s.projectService.ListProjects("")
+	if err != nil {
+		return nil, err
+	}
+
+	type candidate struct {
+		file    sessionFile
+		project string
+	}
+
+	var candidates []candidate
+	for _, project := range projects {
+		files, err := s.sessionFiles(s.projectService.GetProjectDir(project.EncodedPath))
+		if err != nil {
+			continue
+		}
+		for _, file := range files {
+			candidates = append(candidates, candidate{file: file, project: project.Name})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		ti, tj := candidates[i].file.Info.ModTime(), candidates[j].file.Info.ModTime()
+		if !ti.Equal(tj) {
+			return ti.After(tj)
+		}
+		return candidates[i].file.ID < candidates[j].file.ID
+	})
+
+	sessions := make([]models.SessionInfo, 0)
+	for _, c := range candidates {
+		if limit > 0 && len(sessions) >= limit {
+			break
+		}
+
+		info, err := s.getSessionInfo(c.file.Path, c.file.ID, c.project, false)
+		if err != nil || info.Status == models.SessionStatusEmpty {
+			continue
+		}
+
+		modTime := c.file.Info.ModTime()
+		info.ModifiedTime = &modTime
+		// Sessions without timestamps fall back to the file's modification time
+		if info.StartTime.IsZero() {
+			info.StartTime = modTime
+			info.EndTime = modTime
+		}
+		if s.location != nil {
+			info.LocalStartTime = info.StartTime.In(s.location).Format(time.RFC3339)
+		}
+
+		sessions = append(sessions, *info)
+	}
+
+	return sessions, nil
+}
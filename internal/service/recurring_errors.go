@@ -0,0 +1,125 @@
+package service
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/utils"
+)
+
+// maxClusterReferences caps the example occurrences kept per error cluster.
+const maxClusterReferences = 5
+
+// maxSignatureLength bounds how much of a normalized message decides its cluster.
+const maxSignatureLength = 200
+
+// Volatile parts of error messages, masked so otherwise identical errors cluster
+var (
+	errorUUIDPattern   = regexp.MustCompile(`[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`)
+	errorHexPattern    = regexp.MustCompile(`\b(?:0x)?[0-9a-f]{8,}\b`)
+	errorPathPattern   = regexp.MustCompile(`[\w.~-]*/[^\s:'"()]+`)
+	errorNumberPattern = regexp.MustCompile(`\d+`)
+)
+
+// errorSignature reduces an error message to the form clusters are keyed on:
+// whitespace-normalized, lower-cased, with IDs, paths and numbers masked.
+func errorSignature(message string) string {
+	sig := strings.ToLower(utils.NormalizeText(message))
+	sig = errorUUIDPattern.ReplaceAllString(sig, "<uuid>")
+	sig = errorHexPattern.ReplaceAllString(sig, "<hex>")
+	sig = errorPathPattern.ReplaceAllString(sig, "<path>")
+	sig = errorNumberPattern.ReplaceAllString(sig, "<n>")
+	return utils.CutAtRune(sig, maxSignatureLength)
+}
+
+// RecurringErrors clusters the errors of every session in a project (all
+// projects when projectName is empty) from the last days days (0 means all),
+// most frequent first. Sessions are analyzed in parallel; unreadable sessions
+// are listed in the report.
+func (s *SearchService) RecurringErrors(projectName string, days int) ([]models.ErrorCluster, models.ScanReport, error) {
+	var report models.ScanReport
+
+	projects, err := s.projectService.ResolveProjects(projectName)
+	if err != nil {
+		return nil, report, err
+	}
+
+	var sessions []models.SessionInfo
+	for _, project := range projects {
+		projectSessions, projectReport, err := s.sessionService.listProjectSessions(&project, days, false, false, 0)
+		report.Merge(projectReport)
+		if err != nil {
+			continue
+		}
+		sessions = append(sessions, projectSessions...)
+	}
+
+	sessionErrors := make([]*models.SessionErrors, len(sessions))
+	report.Merge(s.sessionService.processSessions(sessions, func(i int, processed []*models.ProcessedEntry) {
+		sessionErrors[i] = s.sessionService.computeErrors(sessions[i].SessionID, "", processed, 0)
+	}))
+
+	return clusterErrors(sessions, sessionErrors), report, nil
+}
+
+// clusterErrors groups errors by type, tool and signature. sessionErrors is
+// indexed like sessions; nil entries are skipped.
+func clusterErrors(sessions []models.SessionInfo, sessionErrors []*models.SessionErrors) []models.ErrorCluster {
+	clusters := make(map[string]*models.ErrorCluster)
+	seenSessions := make(map[string]map[string]bool)
+	var order []string
+
+	for i, errs := range sessionErrors {
+		if errs == nil {
+			continue
+		}
+		for _, e := range errs.Errors {
+			sig := errorSignature(e.Message)
+			if sig == "" {
+				continue
+			}
+			key := e.Type + "\x00" + e.ToolName + "\x00" + sig
+
+			cluster, ok := clusters[key]
+			if !ok {
+				cluster = &models.ErrorCluster{
+					Signature: sig,
+					Type:      e.Type,
+					ToolName:  e.ToolName,
+					Example:   truncateString(utils.NormalizeText(e.Message), maxSignatureLength),
+				}
+				clusters[key] = cluster
+				seenSessions[key] = make(map[string]bool)
+				order = append(order, key)
+			}
+
+			cluster.Occurrences++
+			if !seenSessions[key][sessions[i].SessionID] {
+				seenSessions[key][sessions[i].SessionID] = true
+				cluster.Sessions++
+				if len(cluster.References) < maxClusterReferences {
+					cluster.References = append(cluster.References, models.ErrorReference{
+						SessionID: sessions[i].SessionID,
+						Project:   sessions[i].Project,
+						UUID:      e.UUID,
+						Timestamp: e.Timestamp,
+					})
+				}
+			}
+		}
+	}
+
+	result := make([]models.ErrorCluster, 0, len(order))
+	for _, key := range order {
+		result = append(result, *clusters[key])
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Occurrences != result[j].Occurrences {
+			return result[i].Occurrences > result[j].Occurrences
+		}
+		return result[i].Sessions > result[j].Sessions
+	})
+	return result
+}
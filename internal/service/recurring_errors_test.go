@@ -0,0 +1,54 @@
+package service
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorSignature(t *testing.T) {
+	assert.Equal(t,
+		errorSignature("ENOENT: open /tmp/a/b.txt at line 12"),
+		errorSignature("enoent:  open /var/x.txt at line 7"))
+
+	// A cut through a multi-byte character backs up to the rune boundary
+	sig := errorSignature("x" + strings.Repeat("é", maxSignatureLength))
+	assert.True(t, utf8.ValidString(sig))
+	assert.LessOrEqual(t, len(sig), maxSignatureLength)
+	assert.Equal(t, maxSignatureLength-1, len(sig))
+}
+
+func TestRecurringErrors_UnknownProject(t *testing.T) {
+	claudeDir := t.TempDir()
+	writeSession(t, claudeDir, "-home-u-app", "11111111-1111-1111-1111-111111111111",
+		userLine("u1", "2024-01-01T10:00:00Z", "hello"))
+	services := NewServices(claudeDir)
+
+	_, _, err := services.Search.RecurringErrors("nosuch", 0)
+	assert.ErrorContains(t, err, "project not found")
+}
+
+func TestProcessSessions_ReportsUnreadable(t *testing.T) {
+	claudeDir := t.TempDir()
+	path := writeSession(t, claudeDir, "-home-u-app", "11111111-1111-1111-1111-111111111111",
+		userLine("u1", "2024-01-01T10:00:00Z", "hello"))
+	services := NewServices(claudeDir)
+
+	sessions := []models.SessionInfo{
+		{SessionID: "11111111-1111-1111-1111-111111111111", Project: "app", FilePath: path},
+		{SessionID: "22222222-2222-2222-2222-222222222222", Project: "app", FilePath: filepath.Join(claudeDir, "missing.jsonl")},
+	}
+	var processed [2]bool
+	report := services.Session.processSessions(sessions, func(i int, _ []*models.ProcessedEntry) {
+		processed[i] = true
+	})
+
+	assert.Equal(t, [2]bool{true, false}, processed)
+	if assert.Len(t, report.Failures, 1) {
+		assert.Equal(t, "22222222-2222-2222-2222-222222222222", report.Failures[0].SessionID)
+	}
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/brads3290/cclogviewer/internal/constants"
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/utils"
+)
+
+// replayCommand is a single Bash tool call selected for a replay script.
+type replayCommand struct {
+	timestamp   string
+	description string
+	command     string
+	cwd         string
+	failed      bool
+	isSidechain bool
+}
+
+// GenerateReplayScript writes a commented shell script of the session's Bash
+// commands in execution order, including those run by subagents. Failed or
+// interrupted commands are left out unless includeFailed is set.
+func (s *SessionService) GenerateReplayScript(sessionID, projectName string, includeFailed bool, w io.Writer) error {
+	processed, _, err := s.loadProcessedEntries(sessionID, "", projectName, false)
+	if err != nil {
+		return err
+	}
+	if processed == nil {
+		return fmt.Errorf("session not found: %s", sessionID)
+	}
+
+	return writeReplayScript(w, sessionID, collectReplayCommands(processed), includeFailed)
+}
+
+// GenerateReplayScriptFromFile writes a replay script for a JSONL file path.
+func (s *SessionService) GenerateReplayScriptFromFile(filePath string, includeFailed bool, w io.Writer) error {
+	processed, err := s.loadProcessedEntriesFromFile(filePath, false)
+	if err != nil {
+		return err
+	}
+
+	return writeReplayScript(w, fileLabel(filePath), collectReplayCommands(processed), includeFailed)
+}
+
+// collectReplayCommands gathers Bash calls depth-first so subagent commands
+// appear where their Task call ran.
+func collectReplayCommands(entries []*models.ProcessedEntry) []replayCommand {
+	var commands []replayCommand
+
+	var walk func(entries []*models.ProcessedEntry)
+	walk = func(entries []*models.ProcessedEntry) {
+		for _, e := range entries {
+			for _, tc := range e.ToolCalls {
+				if tc.Name == constants.ToolNameBash {
+					input, _ := tc.RawInput.(map[string]interface{})
+					command := utils.ExtractString(input, "command")
+					if command != "" {
+						commands = append(commands, replayCommand{
+							timestamp:   e.RawTimestamp,
+							description: utils.ExtractString(input, "description"),
+							command:     command,
+							cwd:         tc.CWD,
+							failed:      tc.Result == nil || tc.Result.IsError || tc.IsInterrupted,
+							isSidechain: e.IsSidechain,
+						})
+					}
+				}
+				walk(tc.TaskEntries)
+			}
+		}
+	}
+	walk(entries)
+
+	return commands
+}
+
+// writeReplayScript renders the replay script with a review warning header.
+func writeReplayScript(w io.Writer, sessionID string, commands []replayCommand, includeFailed bool) error {
+	bw := bufio.NewWriter(w)
+
+	included, skipped := 0, 0
+	for _, c := range commands {
+		if c.failed && !includeFailed {
+			skipped++
+		} else {
+			included++
+		}
+	}
+
+	fmt.Fprintln(bw, "#!/usr/bin/env bash")
+	fmt.Fprintf(bw, "# Replay of Bash commands from session %s\n", sessionID)
+	fmt.Fprintln(bw, "#")
+	fmt.Fprintln(bw, "# WARNING: This script is generated for review. Commands are copied verbatim")
+	fmt.Fprintln(bw, "# from the session log and may modify or delete files. Read it fully before")
+	fmt.Fprintln(bw, "# running any part of it; re-running is entirely at your own risk.")
+	fmt.Fprintln(bw, "#")
+	if includeFailed {
+		fmt.Fprintf(bw, "# %d commands, including ones that failed in the original session\n", included)
+	} else {
+		fmt.Fprintf(bw, "# %d successful commands (%d failed or interrupted commands omitted)\n", included, skipped)
+	}
+
+	cwd := ""
+	step := 0
+	for _, c := range commands {
+		if c.failed && !includeFailed {
+			continue
+		}
+		step++
+
+		fmt.Fprintln(bw)
+		header := fmt.Sprintf("# [%d] %s", step, c.timestamp)
+		if c.description != "" {
+			header += " - " + strings.Join(strings.Fields(c.description), " ")
+		}
+		if c.isSidechain {
+			header += " (subagent)"
+		}
+		fmt.Fprintln(bw, header)
+		if c.failed {
+			fmt.Fprintln(bw, "# NOTE: this command failed or was interrupted in the original session")
+		}
+
+		if c.cwd != "" && c.cwd != cwd {
+			fmt.Fprintf(bw, "cd %s\n", shellQuote(c.cwd))
+			cwd = c.cwd
+		}
+		fmt.Fprintln(bw, strings.TrimRight(c.command, "\n"))
+	}
+
+	return bw.Flush()
+}
+
+// shellQuote single-quotes a string for POSIX shells.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+const (
+	// rootCauseMinFollowups is how many related failures must follow an error
+	// for it to count as the one that blocked progress.
+	rootCauseMinFollowups = 2
+
+	// rootCauseMaxGap is the most entries allowed between consecutive failures
+	// of one run; a longer gap means the session moved on.
+	rootCauseMaxGap = 10
+)
+
+// findRootCause picks the error that most likely sent a session sideways: the
+// earliest error that starts a run of related failures, as when the same
+// command is retried. Errors are related when they come from the same tool or
+// have the same error signature (the message with paths, numbers and IDs
+// masked). A run continues while each related failure is within
+// rootCauseMaxGap entries of the previous one, and must include at least
+// rootCauseMinFollowups failures after the first. Isolated errors never
+// qualify, so sessions that recover straight away have no root cause.
+// errors must be in entry order. Returns nil and "" when no error qualifies.
+func findRootCause(errors []models.SessionError) (*models.SessionError, string) {
+	signatures := make([]string, len(errors))
+	for i, e := range errors {
+		signatures[i] = errorSignature(e.Message)
+	}
+
+	related := func(a, b int) bool {
+		if errors[a].ToolName != "" && errors[a].ToolName == errors[b].ToolName {
+			return true
+		}
+		return signatures[a] == signatures[b]
+	}
+
+	for i := range errors {
+		followups, last := 0, i
+		for j := i + 1; j < len(errors); j++ {
+			if errors[j].EntryIndex-errors[last].EntryIndex > rootCauseMaxGap {
+				break
+			}
+			if related(i, j) {
+				followups++
+				last = j
+			}
+		}
+
+		if followups >= rootCauseMinFollowups {
+			cause := errors[i]
+			source := "error"
+			if cause.ToolName != "" {
+				source = cause.ToolName + " error"
+			}
+			reason := fmt.Sprintf("first %s followed by %d related failures over the next %d entries",
+				source, followups, errors[last].EntryIndex-cause.EntryIndex)
+			return &cause, reason
+		}
+	}
+
+	return nil, ""
+}
@@ -2,17 +2,22 @@ package service
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/brads3290/cclogviewer/internal/models"
-	"github.com/brads3290/cclogviewer/internal/parser"
+	"github.com/brads3290/cclogviewer/internal/utils"
 )
 
 // SearchService handles log searching.
 type SearchService struct {
 	projectService *ProjectService
 	sessionService *SessionService
+	// bookmarkDir, when set, is where cross-project searches record their progress
+	bookmarkDir string
 }
 
 // NewSearchService creates a new SearchService.
@@ -32,6 +37,24 @@ type SearchCriteria struct {
 	Days              int
 	IncludeSidechains bool
 	Limit             int
+	// Scope the search to one session; Project and Days are ignored when set
+	SessionID string
+	FilePath  string
+	// Resume skips sessions finished by an earlier, interrupted search with the same criteria
+	Resume bool
+	// Bookmark records which sessions are finished so the search can later be
+	// resumed; implied by Resume
+	Bookmark bool
+	// CountOnly counts every match, ignoring Limit, and leaves Results empty
+	CountOnly bool
+	// Sort orders sessions across projects: SortByProject (default) or SortByTime
+	Sort string
+	// MinContentLen skips entries whose text content is shorter than this many characters
+	MinContentLen int
+	// WholeWord requires Query to match at word boundaries rather than anywhere
+	WholeWord bool
+	// FullContent adds each match's untruncated text and tool input/output to its result
+	FullContent bool
 }
 
 // SearchResult represents a single search result.
@@ -44,18 +67,35 @@ type SearchResult struct {
 	ContentSnippet string    `json:"content_snippet"`
 	ToolName       string    `json:"tool_name,omitempty"`
 	IsSidechain    bool      `json:"is_sidechain,omitempty"`
+	// Set only for full-content searches
+	Content    string      `json:"content,omitempty"`
+	ToolInput  interface{} `json:"tool_input,omitempty"`  // Input of the matched tool call
+	ToolOutput string      `json:"tool_output,omitempty"` // Text of any tool results in the entry
 }
 
 // SearchResults represents search results.
 type SearchResults struct {
 	Results      []SearchResult `json:"results"`
 	TotalMatches int            `json:"total_matches"`
+	// Sessions skipped because a resumed search had already finished them
+	ResumedSessions int `json:"resumed_sessions,omitempty"`
+	// Why progress could not be bookmarked; the results are complete regardless
+	BookmarkWarning string `json:"bookmark_warning,omitempty"`
+	models.ScanReport
 }
 
 // Search searches across sessions by various criteria.
 func (s *SearchService) Search(criteria SearchCriteria) (*SearchResults, error) {
+	if criteria.FilePath != "" || criteria.SessionID != "" {
+		return s.searchSingleSession(criteria)
+	}
+
 	var projectsToSearch []models.Project
 
+	if err := checkResultSort(criteria.Sort); err != nil {
+		return nil, err
+	}
+
 	if criteria.Project != "" {
 		project, err := s.projectService.FindProjectByName(criteria.Project)
 		if err != nil {
@@ -72,50 +112,123 @@ func (s *SearchService) Search(criteria SearchCriteria) (*SearchResults, error)
 		projectsToSearch = projects
 	}
 
+	bookmark, err := s.openBookmark(criteria)
+	if err != nil {
+		return nil, err
+	}
+
 	var results []SearchResult
+	var report models.ScanReport
+	var bookmarkWarning string
+	resumed, counted := 0, 0
 	limit := criteria.Limit
 	if limit <= 0 {
 		limit = 50
 	}
 
-	for _, project := range projectsToSearch {
+	s.sessionService.eachSession(projectsToSearch, criteria.Days, criteria.Sort, &report, func(session models.SessionInfo) bool {
 		if len(results) >= limit {
-			break
+			return false
+		}
+		if bookmark.done(session.Project, session.SessionID) {
+			resumed++
+			return true
 		}
 
-		sessions, err := s.sessionService.ListSessions(project.Name, criteria.Days, false, 0)
+		sessionResults, err := s.searchInSession(session.FilePath, session.SessionID, session.Project, criteria)
 		if err != nil {
-			continue
+			report.AddFailure(session.SessionID, session.Project, session.FilePath, err)
+			return true
 		}
 
-		for _, session := range sessions {
-			if len(results) >= limit {
-				break
-			}
+		if criteria.CountOnly {
+			counted += len(sessionResults)
+		} else if len(results)+len(sessionResults) > limit {
+			// A session cut off by the limit is searched again on resume
+			results = append(results, sessionResults[:limit-len(results)]...)
+			return false
+		} else {
+			results = append(results, sessionResults...)
+		}
 
-			sessionResults, err := s.searchInSession(session.FilePath, session.SessionID, project.Name, criteria)
-			if err != nil {
-				continue
-			}
+		// A bookmark that cannot be written only costs the ability to resume
+		if err := bookmark.mark(session.Project, session.SessionID); err != nil {
+			bookmarkWarning = fmt.Sprintf("failed to save search bookmark: %v", err)
+			bookmark = nil
+		}
+		return true
+	})
 
-			for _, r := range sessionResults {
-				if len(results) >= limit {
-					break
-				}
-				results = append(results, r)
-			}
+	// Once every session is covered the bookmark has nothing left to resume
+	if len(results) < limit {
+		err = bookmark.clear()
+	} else {
+		err = bookmark.save()
+	}
+	if err != nil {
+		bookmarkWarning = fmt.Sprintf("failed to save search bookmark: %v", err)
+	}
+
+	if !criteria.CountOnly {
+		counted = len(results)
+	}
+
+	return &SearchResults{
+		Results:         results,
+		TotalMatches:    counted,
+		ResumedSessions: resumed,
+		BookmarkWarning: bookmarkWarning,
+		ScanReport:      report,
+	}, nil
+}
+
+// searchSingleSession searches only the session given by criteria.FilePath or
+// criteria.SessionID, without enumerating projects.
+func (s *SearchService) searchSingleSession(criteria SearchCriteria) (*SearchResults, error) {
+	filePath, sessionID, project := criteria.FilePath, fileLabel(criteria.FilePath), ""
+
+	if filePath == "" {
+		var err error
+		sessionID = criteria.SessionID
+		filePath, project, err = s.sessionService.findSessionFile(sessionID, "")
+		if err != nil {
+			return nil, err
+		}
+		if filePath == "" {
+			return nil, fmt.Errorf("session not found: %s", sessionID)
 		}
 	}
 
+	results, err := s.searchInSession(filePath, sessionID, project, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	if criteria.CountOnly {
+		return &SearchResults{
+			TotalMatches: len(results),
+			ScanReport:   models.ScanReport{SessionsScanned: 1},
+		}, nil
+	}
+
+	limit := criteria.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
 	return &SearchResults{
 		Results:      results,
 		TotalMatches: len(results),
+		ScanReport:   models.ScanReport{SessionsScanned: 1},
 	}, nil
 }
 
 // searchInSession searches within a single session.
 func (s *SearchService) searchInSession(filePath, sessionID, project string, criteria SearchCriteria) ([]SearchResult, error) {
-	entries, err := parser.ReadJSONLFile(filePath)
+	entries, err := s.sessionService.readJSONLFile(filePath)
 	if err != nil {
 		return nil, err
 	}
@@ -142,6 +255,9 @@ func (s *SearchService) searchInSession(filePath, sessionID, project string, cri
 
 		// Extract content
 		content := extractContent(msg)
+		if criteria.MinContentLen > 0 && utf8.RuneCountInString(strings.TrimSpace(content)) < criteria.MinContentLen {
+			continue
+		}
 
 		// Check tool name filter
 		toolName := ""
@@ -153,15 +269,13 @@ func (s *SearchService) searchInSession(filePath, sessionID, project string, cri
 		}
 
 		// Check query match
-		if criteria.Query != "" {
-			if !strings.Contains(strings.ToLower(content), strings.ToLower(criteria.Query)) {
-				continue
-			}
+		if criteria.Query != "" && !matchesQuery(content, criteria.Query, criteria.WholeWord) {
+			continue
 		}
 
-		timestamp, _ := time.Parse(time.RFC3339, entry.Timestamp)
+		timestamp, _ := utils.ParseTimestamp(entry.Timestamp)
 
-		results = append(results, SearchResult{
+		result := SearchResult{
 			SessionID:      sessionID,
 			Project:        project,
 			EntryUUID:      entry.UUID,
@@ -170,12 +284,53 @@ func (s *SearchService) searchInSession(filePath, sessionID, project string, cri
 			ContentSnippet: truncate(content, 200),
 			ToolName:       toolName,
 			IsSidechain:    entry.IsSidechain,
-		})
+		}
+		if criteria.FullContent {
+			result.Content = content
+			result.ToolInput = findToolInput(msg, toolName)
+			result.ToolOutput = extractToolResults(msg)
+		}
+		results = append(results, result)
 	}
 
 	return results, nil
 }
 
+// matchesQuery reports whether content contains query, ignoring case. With
+// wholeWord, the match must not be preceded or followed by a letter, digit, or
+// underscore in any script.
+func matchesQuery(content, query string, wholeWord bool) bool {
+	content = strings.ToLower(content)
+	query = strings.ToLower(query)
+	if !wholeWord {
+		return strings.Contains(content, query)
+	}
+
+	for offset := 0; offset < len(content); {
+		idx := strings.Index(content[offset:], query)
+		if idx < 0 {
+			return false
+		}
+		start := offset + idx
+		end := start + len(query)
+
+		before, _ := utf8.DecodeLastRuneInString(content[:start])
+		after, _ := utf8.DecodeRuneInString(content[end:])
+		if (start == 0 || !isWordRune(before)) && (end == len(content) || !isWordRune(after)) {
+			return true
+		}
+
+		_, size := utf8.DecodeRuneInString(content[start:])
+		offset = start + size
+	}
+	return false
+}
+
+// isWordRune reports whether r is part of a word for whole-word matching.
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)
+}
+
 // extractContent extracts text content from a message.
 func extractContent(msg map[string]interface{}) string {
 	content, ok := msg["content"]
@@ -229,6 +384,57 @@ func findToolName(msg map[string]interface{}, targetTool string) string {
 	return ""
 }
 
+// findToolInput returns the input of the first tool call named toolName, or
+// nil when toolName is empty or not called.
+func findToolInput(msg map[string]interface{}, toolName string) interface{} {
+	if toolName == "" {
+		return nil
+	}
+
+	content, ok := msg["content"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	for _, item := range content {
+		m, ok := item.(map[string]interface{})
+		if !ok || m["type"] != "tool_use" {
+			continue
+		}
+		if m["name"] == toolName {
+			return m["input"]
+		}
+	}
+
+	return nil
+}
+
+// extractToolResults joins the text of all tool_result blocks in a message.
+func extractToolResults(msg map[string]interface{}) string {
+	content, ok := msg["content"].([]interface{})
+	if !ok {
+		return ""
+	}
+
+	var parts []string
+	for _, item := range content {
+		m, ok := item.(map[string]interface{})
+		if !ok || m["type"] != "tool_result" {
+			continue
+		}
+		switch c := m["content"].(type) {
+		case string:
+			parts = append(parts, c)
+		case []interface{}:
+			if text := extractContent(map[string]interface{}{"content": c}); text != "" {
+				parts = append(parts, text)
+			}
+		}
+	}
+
+	return strings.Join(parts, "\n")
+}
+
 // truncate truncates a string to a maximum length.
 func truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
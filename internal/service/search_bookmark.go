@@ -0,0 +1,149 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// bookmarkInterval is how many sessions a search processes between bookmark writes.
+const bookmarkInterval = 20
+
+// searchBookmark records which sessions a cross-project search has finished,
+// so an interrupted search can be resumed with the same criteria.
+type searchBookmark struct {
+	CriteriaHash string          `json:"criteria_hash"`
+	LastProject  string          `json:"last_project"`
+	LastSession  string          `json:"last_session"`
+	Searched     map[string]bool `json:"searched"` // Keyed by "project/session"
+	UpdatedAt    time.Time       `json:"updated_at"`
+
+	path    string
+	pending int
+}
+
+// DefaultBookmarkDir returns the directory for search bookmarks under the
+// user cache directory, or "" if it cannot be determined.
+func DefaultBookmarkDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "cclogviewer", "search-bookmarks")
+}
+
+// SetBookmarkDir sets where cross-project searches record their progress.
+// An empty dir disables bookmarks, and with them SearchCriteria.Resume.
+func (s *SearchService) SetBookmarkDir(dir string) {
+	s.bookmarkDir = dir
+}
+
+// criteriaHash identifies a search by the criteria that decide which sessions
+// and entries match. Limit is left out so a resumed search may ask for more.
+func criteriaHash(criteria SearchCriteria) string {
+	key, _ := json.Marshal(struct {
+		Query             string `json:"query"`
+		ToolName          string `json:"tool"`
+		Role              string `json:"role"`
+		Project           string `json:"project"`
+		Days              int    `json:"days"`
+		IncludeSidechains bool   `json:"include_sidechains"`
+		MinContentLen     int    `json:"min_content_len,omitempty"`
+		WholeWord         bool   `json:"whole_word,omitempty"`
+	}{criteria.Query, criteria.ToolName, criteria.Role, criteria.Project, criteria.Days, criteria.IncludeSidechains,
+		criteria.MinContentLen, criteria.WholeWord})
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:])
+}
+
+// openBookmark returns the bookmark for criteria, loading previous progress
+// when resume is set. Returns nil unless the search asks for a bookmark and
+// bookmarks are enabled.
+func (s *SearchService) openBookmark(criteria SearchCriteria) (*searchBookmark, error) {
+	if !criteria.Bookmark && !criteria.Resume {
+		return nil, nil
+	}
+	if s.bookmarkDir == "" {
+		if criteria.Resume {
+			return nil, fmt.Errorf("cannot resume: search bookmarks are disabled")
+		}
+		return nil, nil
+	}
+
+	hash := criteriaHash(criteria)
+	b := &searchBookmark{
+		CriteriaHash: hash,
+		Searched:     make(map[string]bool),
+		path:         filepath.Join(s.bookmarkDir, hash+".json"),
+	}
+	if !criteria.Resume {
+		return b, nil
+	}
+
+	data, err := os.ReadFile(b.path)
+	if os.IsNotExist(err) {
+		return b, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read search bookmark: %w", err)
+	}
+	if err := json.Unmarshal(data, b); err != nil {
+		return nil, fmt.Errorf("failed to parse search bookmark %s: %w", b.path, err)
+	}
+	if b.Searched == nil {
+		b.Searched = make(map[string]bool)
+	}
+	return b, nil
+}
+
+// done reports whether a session was finished by an earlier run.
+func (b *searchBookmark) done(project, sessionID string) bool {
+	return b != nil && b.Searched[project+"/"+sessionID]
+}
+
+// mark records a finished session, saving every bookmarkInterval sessions.
+func (b *searchBookmark) mark(project, sessionID string) error {
+	if b == nil {
+		return nil
+	}
+	b.Searched[project+"/"+sessionID] = true
+	b.LastProject, b.LastSession = project, sessionID
+	b.pending++
+	if b.pending < bookmarkInterval {
+		return nil
+	}
+	return b.save()
+}
+
+// save writes the bookmark to disk.
+func (b *searchBookmark) save() error {
+	if b == nil {
+		return nil
+	}
+	b.pending = 0
+	b.UpdatedAt = time.Now()
+
+	if err := os.MkdirAll(filepath.Dir(b.path), 0755); err != nil {
+		return fmt.Errorf("failed to create bookmark directory: %w", err)
+	}
+	data, err := json.Marshal(b)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(b.path, data, 0644)
+}
+
+// clear removes the bookmark once a search has covered every session.
+func (b *searchBookmark) clear() error {
+	if b == nil {
+		return nil
+	}
+	if err := os.Remove(b.path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newBookmarkSearch returns a search service over two sessions that each
+// mention "needle", with bookmarks kept in bookmarkDir.
+func newBookmarkSearch(t *testing.T, bookmarkDir string) *SearchService {
+	t.Helper()
+	claudeDir := t.TempDir()
+	writeSession(t, claudeDir, "-home-u-app", "11111111-1111-1111-1111-111111111111",
+		userLine("u1", "2024-01-01T10:00:00Z", "find the needle"))
+	writeSession(t, claudeDir, "-home-u-app", "22222222-2222-2222-2222-222222222222",
+		userLine("u2", "2024-01-02T10:00:00Z", "another needle"))
+
+	services := NewServices(claudeDir)
+	services.Search.SetBookmarkDir(bookmarkDir)
+	return services.Search
+}
+
+func TestSearch_BookmarkOptIn(t *testing.T) {
+	bookmarkDir := filepath.Join(t.TempDir(), "bookmarks")
+	search := newBookmarkSearch(t, bookmarkDir)
+
+	results, err := search.Search(SearchCriteria{Query: "needle", Limit: 1})
+	require.NoError(t, err)
+	assert.Len(t, results.Results, 1)
+	assert.NoDirExists(t, bookmarkDir, "searches without Bookmark must not write")
+
+	results, err = search.Search(SearchCriteria{Query: "needle", Limit: 1, Bookmark: true})
+	require.NoError(t, err)
+	require.Len(t, results.Results, 1)
+	files, err := os.ReadDir(bookmarkDir)
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	resumed, err := search.Search(SearchCriteria{Query: "needle", Limit: 1, Resume: true})
+	require.NoError(t, err)
+	assert.Equal(t, 1, resumed.ResumedSessions)
+	require.Len(t, resumed.Results, 1)
+	assert.NotEqual(t, results.Results[0].SessionID, resumed.Results[0].SessionID)
+}
+
+func TestSearch_BookmarkWriteFailureWarns(t *testing.T) {
+	// A bookmark directory beneath a regular file can never be created
+	blocker := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(blocker, nil, 0644))
+	search := newBookmarkSearch(t, filepath.Join(blocker, "bookmarks"))
+
+	results, err := search.Search(SearchCriteria{Query: "needle", Limit: 1, Bookmark: true})
+	require.NoError(t, err)
+	assert.Len(t, results.Results, 1)
+	assert.Contains(t, results.BookmarkWarning, "failed to save search bookmark")
+}
@@ -6,10 +6,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"regexp"
 	"sort"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/brads3290/cclogviewer/internal/browser"
 	"github.com/brads3290/cclogviewer/internal/constants"
@@ -17,32 +18,119 @@ import (
 	"github.com/brads3290/cclogviewer/internal/parser"
 	"github.com/brads3290/cclogviewer/internal/processor"
 	"github.com/brads3290/cclogviewer/internal/renderer"
+	"github.com/brads3290/cclogviewer/internal/utils"
 )
 
 // SessionService handles session listing and retrieval.
 type SessionService struct {
 	projectService *ProjectService
+	// location, when set, adds local-time fields to timelines, context logs, and session lists
+	location *time.Location
+	// stripANSI removes terminal escape codes from tool output in logs and context logs
+	stripANSI bool
+	// noiseTools are left out of headline tool-call counts in summaries and tool stats
+	noiseTools map[string]bool
+	// healthWeights scores session health in summaries
+	healthWeights models.HealthWeights
+	// heavyReadBytes is the Read result size counted as a heavy read
+	heavyReadBytes int
+	// discoveryDepth is how many subdirectory levels are searched for session files
+	discoveryDepth int
+	// parserOptions caps how many subagent files are loaded with each session
+	parserOptions parser.Options
 }
 
 // NewSessionService creates a new SessionService.
 func NewSessionService(projectService *ProjectService) *SessionService {
-	return &SessionService{projectService: projectService}
+	return &SessionService{projectService: projectService, stripANSI: true, healthWeights: models.DefaultHealthWeights(), heavyReadBytes: DefaultHeavyReadBytes}
+}
+
+// SetStripANSI sets whether terminal escape codes are removed from tool output
+// in text results. Enabled by default; HTML output renders them as colors instead.
+func (s *SessionService) SetStripANSI(strip bool) {
+	s.stripANSI = strip
+}
+
+// SetNoiseTools sets tools, such as TodoWrite, whose calls do not represent
+// real work. Summaries and tool stats leave them out of headline counts and
+// report them separately. Empty by default.
+func (s *SessionService) SetNoiseTools(names []string) {
+	s.noiseTools = make(map[string]bool, len(names))
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			s.noiseTools[name] = true
+		}
+	}
+}
+
+// toolOutputText returns tool output prepared for text results.
+func (s *SessionService) toolOutputText(content string) string {
+	if s.stripANSI {
+		return utils.StripANSI(content)
+	}
+	return content
+}
+
+// SetLocation sets the time zone used for local_time fields. A nil location
+// leaves them unset.
+func (s *SessionService) SetLocation(loc *time.Location) {
+	s.location = loc
+}
+
+// SetSubagentLimits caps how many subagent files, and how many bytes of them,
+// are loaded with each session; the most recently modified are kept. 0 leaves
+// a limit off, the default.
+func (s *SessionService) SetSubagentLimits(maxFiles int, maxBytes int64) {
+	s.parserOptions = parser.Options{MaxSubagentFiles: maxFiles, MaxSubagentBytes: maxBytes}
+}
+
+// readJSONLFile parses a session file with the configured subagent limits.
+func (s *SessionService) readJSONLFile(filePath string) ([]models.LogEntry, error) {
+	return parser.ReadJSONLFileWithOptions(filePath, s.parserOptions)
+}
+
+// localTime converts an RFC3339 timestamp to the configured location.
+// Returns an empty string if no location is set or the timestamp is invalid.
+func (s *SessionService) localTime(raw string) string {
+	if s.location == nil {
+		return ""
+	}
+	t, err := utils.ParseTimestamp(raw)
+	if err != nil {
+		return ""
+	}
+	return t.In(s.location).Format(time.RFC3339)
 }
 
 // ListSessions returns sessions for a project with optional filtering.
-func (s *SessionService) ListSessions(projectName string, days int, includeAgentTypes bool, limit int) ([]models.SessionInfo, error) {
+// Sessions with no usable entries are skipped unless includeEmpty is set.
+func (s *SessionService) ListSessions(projectName string, days int, includeAgentTypes, includeEmpty bool, limit int) ([]models.SessionInfo, error) {
+	sessions, _, err := s.listSessions(projectName, days, includeAgentTypes, includeEmpty, limit)
+	return sessions, err
+}
+
+// listSessions is ListSessions that also reports how many session files were
+// read and which could not be parsed.
+func (s *SessionService) listSessions(projectName string, days int, includeAgentTypes, includeEmpty bool, limit int) ([]models.SessionInfo, models.ScanReport, error) {
 	project, err := s.projectService.FindProjectByName(projectName)
 	if err != nil {
-		return nil, err
+		return nil, models.ScanReport{}, err
 	}
 	if project == nil {
-		return nil, nil
+		return nil, models.ScanReport{}, nil
 	}
+	return s.listProjectSessions(project, days, includeAgentTypes, includeEmpty, limit)
+}
+
+// listProjectSessions is listSessions for an already resolved project, so
+// callers iterating over projects need not look each one up again by name.
+func (s *SessionService) listProjectSessions(project *models.Project, days int, includeAgentTypes, includeEmpty bool, limit int) ([]models.SessionInfo, models.ScanReport, error) {
+	var report models.ScanReport
 
 	projectDir := s.projectService.GetProjectDir(project.EncodedPath)
-	entries, err := os.ReadDir(projectDir)
+	files, err := s.sessionFiles(projectDir)
 	if err != nil {
-		return nil, err
+		return nil, report, err
 	}
 
 	// Calculate cutoff time
@@ -51,35 +139,35 @@ func (s *SessionService) ListSessions(projectName string, days int, includeAgent
 		cutoff = time.Now().AddDate(0, 0, -days)
 	}
 
-	uuidPattern := regexp.MustCompile(`^([0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})\.jsonl$`)
-
 	var sessions []models.SessionInfo
-	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
-		}
+	for _, file := range files {
+		sessionID := file.ID
+		filePath := file.Path
+		info := file.Info
 
-		matches := uuidPattern.FindStringSubmatch(entry.Name())
-		if len(matches) != 2 {
+		// Filter by time
+		if days > 0 && info.ModTime().Before(cutoff) {
 			continue
 		}
 
-		sessionID := matches[1]
-		filePath := filepath.Join(projectDir, entry.Name())
-
-		info, err := entry.Info()
+		report.SessionsScanned++
+		sessionInfo, err := s.getSessionInfo(filePath, sessionID, project.Name, includeAgentTypes)
 		if err != nil {
+			report.AddFailure(sessionID, project.Name, filePath, err)
 			continue
 		}
-
-		// Filter by time
-		if days > 0 && info.ModTime().Before(cutoff) {
+		if sessionInfo.Status == models.SessionStatusEmpty && !includeEmpty {
 			continue
 		}
 
-		sessionInfo, err := s.getSessionInfo(filePath, sessionID, project.Name, includeAgentTypes)
-		if err != nil {
-			continue
+		// Sessions without timestamps fall back to the file's modification time
+		if sessionInfo.StartTime.IsZero() {
+			sessionInfo.StartTime = info.ModTime()
+			sessionInfo.EndTime = info.ModTime()
+		}
+
+		if s.location != nil {
+			sessionInfo.LocalStartTime = sessionInfo.StartTime.In(s.location).Format(time.RFC3339)
 		}
 
 		sessions = append(sessions, *sessionInfo)
@@ -87,7 +175,10 @@ func (s *SessionService) ListSessions(projectName string, days int, includeAgent
 
 	// Sort by start time descending
 	sort.Slice(sessions, func(i, j int) bool {
-		return sessions[i].StartTime.After(sessions[j].StartTime)
+		if !sessions[i].StartTime.Equal(sessions[j].StartTime) {
+			return sessions[i].StartTime.After(sessions[j].StartTime)
+		}
+		return sessions[i].SessionID < sessions[j].SessionID
 	})
 
 	// Apply limit
@@ -95,11 +186,75 @@ func (s *SessionService) ListSessions(projectName string, days int, includeAgent
 		sessions = sessions[:limit]
 	}
 
-	return sessions, nil
+	return sessions, report, nil
+}
+
+// ListSessionsWithErrors returns only sessions whose processed entries contain at least one error.
+// Each session is fully processed, so sessions are checked newest first and the scan stops
+// once limit matching sessions have been found. Sessions that cannot be processed are listed
+// in the report, and agent types are extracted only for the sessions returned.
+func (s *SessionService) ListSessionsWithErrors(projectName string, days int, includeAgentTypes bool, limit int) ([]models.SessionInfo, models.ScanReport, error) {
+	sessions, report, err := s.listSessions(projectName, days, false, false, 0)
+	if err != nil {
+		return nil, report, err
+	}
+
+	var result []models.SessionInfo
+	for _, session := range sessions {
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+
+		processed, err := s.loadProcessedEntriesFromFile(session.FilePath, true)
+		if err != nil {
+			report.AddFailure(session.SessionID, session.Project, session.FilePath, err)
+			continue
+		}
+
+		errors := s.computeErrors(session.SessionID, "", processed, 0)
+		if errors.TotalErrors == 0 {
+			continue
+		}
+
+		session.ErrorCount = errors.TotalErrors
+		result = append(result, session)
+	}
+
+	if includeAgentTypes {
+		for i := range result {
+			entries, err := s.readJSONLFile(result[i].FilePath)
+			if err != nil {
+				report.AddFailure(result[i].SessionID, result[i].Project, result[i].FilePath, err)
+				continue
+			}
+			result[i].AgentTypeCounts = countAgentTypes(entries)
+			result[i].AgentTypesUsed = agentTypeNames(result[i].AgentTypeCounts)
+		}
+	}
+
+	return result, report, nil
+}
+
+// DefaultToolOutputLimit is the default maximum size, in bytes, of each tool
+// output in session logs.
+const DefaultToolOutputLimit = 2000
+
+// LogsOptions selects what session logs include.
+type LogsOptions struct {
+	// IncludeSidechains keeps subagent entries alongside the main conversation
+	IncludeSidechains bool
+	// IncludeToolOutput adds each tool call's output, truncated to
+	// ToolOutputLimit bytes; 0 (or any negative value) means no limit
+	IncludeToolOutput bool
+	ToolOutputLimit   int
+	// IncludeRawUsage attaches each message's original usage block
+	IncludeRawUsage bool
+	// IncludeHashes attaches each entry's content hash (see processor.ContentHash)
+	IncludeHashes bool
 }
 
 // GetSessionLogs retrieves full processed logs for a session.
-func (s *SessionService) GetSessionLogs(sessionID, projectName string, includeSidechains bool) (*models.SessionLogs, error) {
+func (s *SessionService) GetSessionLogs(sessionID, projectName string, opts LogsOptions) (*models.SessionLogs, error) {
 	filePath, project, err := s.findSessionFile(sessionID, projectName)
 	if err != nil {
 		return nil, err
@@ -109,7 +264,7 @@ func (s *SessionService) GetSessionLogs(sessionID, projectName string, includeSi
 	}
 
 	// Use existing parser
-	entries, err := parser.ReadJSONLFile(filePath)
+	entries, err := s.readJSONLFile(filePath)
 	if err != nil {
 		return nil, err
 	}
@@ -117,6 +272,11 @@ func (s *SessionService) GetSessionLogs(sessionID, projectName string, includeSi
 	// Use existing processor
 	processed := processor.ProcessEntries(entries)
 
+	var rawUsage map[string]json.RawMessage
+	if opts.IncludeRawUsage {
+		rawUsage = rawUsageByUUID(entries)
+	}
+
 	// Convert to session logs format
 	logs := &models.SessionLogs{
 		SessionID: sessionID,
@@ -124,29 +284,37 @@ func (s *SessionService) GetSessionLogs(sessionID, projectName string, includeSi
 		Entries:   make([]models.SessionLogEntry, 0),
 	}
 
+	s.appendLogEntries(logs, processed, rawUsage, opts)
+
+	return logs, nil
+}
+
+// appendLogEntries converts processed entries to log entries on logs and sets
+// its token stats. Sidechain entries are skipped unless opts.IncludeSidechains.
+func (s *SessionService) appendLogEntries(logs *models.SessionLogs, processed []*models.ProcessedEntry, rawUsage map[string]json.RawMessage, opts LogsOptions) {
 	var totalInput, totalOutput, cacheRead, cacheCreation int
 
 	for _, entry := range processed {
-		if !includeSidechains && entry.IsSidechain {
+		if !opts.IncludeSidechains && entry.IsSidechain {
 			continue
 		}
 
 		logEntry := models.SessionLogEntry{
-			UUID:        entry.UUID,
-			Timestamp:   entry.Timestamp,
-			Role:        entry.Role,
-			Content:     entry.Content,
-			IsSidechain: entry.IsSidechain,
-			AgentID:     entry.AgentID,
+			UUID:         entry.UUID,
+			Timestamp:    entry.Timestamp,
+			Role:         entry.Role,
+			Content:      entry.Content,
+			RawTimestamp: entry.RawTimestamp,
+			IsSidechain:  entry.IsSidechain,
+			AgentID:      entry.AgentID,
+			RawUsage:     rawUsage[entry.UUID],
+		}
+		if opts.IncludeHashes {
+			logEntry.ContentHash = processor.ContentHash(entry)
 		}
 
 		// Add tool calls
-		for _, tc := range entry.ToolCalls {
-			logEntry.ToolCalls = append(logEntry.ToolCalls, models.SessionToolCall{
-				Name:  tc.Name,
-				Input: tc.RawInput,
-			})
-		}
+		logEntry.ToolCalls = s.toSessionToolCalls(entry.ToolCalls, opts.IncludeToolOutput, opts.ToolOutputLimit)
 
 		logs.Entries = append(logs.Entries, logEntry)
 
@@ -163,22 +331,78 @@ func (s *SessionService) GetSessionLogs(sessionID, projectName string, includeSi
 		CacheRead:     cacheRead,
 		CacheCreation: cacheCreation,
 	}
+}
 
-	return logs, nil
+// rawUsageByUUID maps entry UUIDs to the usage block of their message, as logged.
+func rawUsageByUUID(entries []models.LogEntry) map[string]json.RawMessage {
+	usage := make(map[string]json.RawMessage)
+	for _, e := range entries {
+		var msg struct {
+			Usage json.RawMessage `json:"usage"`
+		}
+		if err := json.Unmarshal(e.Message, &msg); err == nil && len(msg.Usage) > 0 {
+			usage[e.UUID] = msg.Usage
+		}
+	}
+	return usage
+}
+
+// toSessionToolCalls converts tool calls for session logs, optionally attaching
+// each call's result content truncated to outputLimit bytes.
+func (s *SessionService) toSessionToolCalls(toolCalls []models.ToolCall, includeOutput bool, outputLimit int) []models.SessionToolCall {
+	var result []models.SessionToolCall
+	for _, tc := range toolCalls {
+		call := models.SessionToolCall{
+			Name:  tc.Name,
+			Input: tc.RawInput,
+		}
+
+		if includeOutput && tc.Result != nil {
+			call.Output = s.toolOutputText(tc.Result.Content)
+			if outputLimit > 0 && len(call.Output) > outputLimit {
+				call.Output = truncateString(call.Output, outputLimit)
+				call.OutputTruncated = true
+			}
+		}
+
+		result = append(result, call)
+	}
+	return result
 }
 
 // HTMLGenerationResult contains the result of HTML generation.
 type HTMLGenerationResult struct {
-	OutputPath    string `json:"output_path"`
-	SessionID     string `json:"session_id"`
-	Project       string `json:"project"`
-	OpenedBrowser bool   `json:"opened_browser"`
+	OutputPath    string       `json:"output_path"`
+	SessionID     string       `json:"session_id"`
+	SessionIDs    []string     `json:"session_ids,omitempty"`
+	Project       string       `json:"project"`
+	OpenedBrowser bool         `json:"opened_browser"`
+	Preview       *HTMLPreview `json:"preview,omitempty"`
+	// Files lists the generated file with its type and size
+	Files *models.OutputManifest `json:"files,omitempty"`
+}
+
+// recordOutput sets r.Files from the generated file at r.OutputPath.
+func (r *HTMLGenerationResult) recordOutput(fileType string) {
+	r.Files = NewOutputManifest(r.OutputPath, fileType)
+	r.Files.OpenedBrowser = r.OpenedBrowser
+}
+
+// HTMLPreview is a short text overview of the rendered session, so callers have
+// context without opening the generated file.
+type HTMLPreview struct {
+	FirstUserMessage string             `json:"first_user_message,omitempty"`
+	EntryCount       int                `json:"entry_count"`
+	Tokens           *models.TokenStats `json:"tokens"`
+	ToolCalls        int                `json:"tool_calls"`
+	ErrorCount       int                `json:"error_count"`
 }
 
 // GenerateSessionHTML generates an HTML file from a session's logs.
 // If outputPath is empty, a temporary file is created and auto-opened in the browser.
 // If openBrowser is true, the HTML file is opened in the default browser.
-func (s *SessionService) GenerateSessionHTML(sessionID, projectName, outputPath string, openBrowser bool) (*HTMLGenerationResult, error) {
+// If includePreview is true, the result carries a short text preview of the session.
+func (s *SessionService) GenerateSessionHTML(sessionID, projectName, outputPath string, openBrowser, includePreview bool) (*HTMLGenerationResult, error) {
 	// Find the session file
 	filePath, project, err := s.findSessionFile(sessionID, projectName)
 	if err != nil {
@@ -189,7 +413,7 @@ func (s *SessionService) GenerateSessionHTML(sessionID, projectName, outputPath
 	}
 
 	// Parse the JSONL file
-	entries, err := parser.ReadJSONLFile(filePath)
+	entries, err := s.readJSONLFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read session file: %w", err)
 	}
@@ -207,7 +431,7 @@ func (s *SessionService) GenerateSessionHTML(sessionID, projectName, outputPath
 	}
 
 	// Generate HTML
-	err = renderer.GenerateHTML(processed, outputPath, false)
+	err = renderer.GenerateHTMLWithHealth(processed, outputPath, s.computeSummary(sessionID, "", project, processed).HealthScore)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate HTML: %w", err)
 	}
@@ -219,6 +443,10 @@ func (s *SessionService) GenerateSessionHTML(sessionID, projectName, outputPath
 		OpenedBrowser: false,
 	}
 
+	if includePreview {
+		result.Preview = s.buildHTMLPreview(sessionID, project, processed)
+	}
+
 	// Open browser if requested or if output was auto-generated
 	if openBrowser || autoOpen {
 		if err := browser.OpenInBrowser(outputPath); err != nil {
@@ -229,20 +457,22 @@ func (s *SessionService) GenerateSessionHTML(sessionID, projectName, outputPath
 		}
 	}
 
+	result.recordOutput(models.OutputTypeHTML)
 	return result, nil
 }
 
 // GenerateHTMLFromFile generates an HTML file from a JSONL file path directly.
 // If outputPath is empty, a temporary file is created and auto-opened in the browser.
 // If openBrowser is true, the HTML file is opened in the default browser.
-func (s *SessionService) GenerateHTMLFromFile(inputPath, outputPath string, openBrowser bool) (*HTMLGenerationResult, error) {
+// If includePreview is true, the result carries a short text preview of the session.
+func (s *SessionService) GenerateHTMLFromFile(inputPath, outputPath string, openBrowser, includePreview bool) (*HTMLGenerationResult, error) {
 	// Verify the file exists
 	if _, err := os.Stat(inputPath); os.IsNotExist(err) {
 		return nil, fmt.Errorf("file not found: %s", inputPath)
 	}
 
 	// Parse the JSONL file
-	entries, err := parser.ReadJSONLFile(inputPath)
+	entries, err := s.readJSONLFile(inputPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
@@ -266,7 +496,7 @@ func (s *SessionService) GenerateHTMLFromFile(inputPath, outputPath string, open
 	}
 
 	// Generate HTML
-	err = renderer.GenerateHTML(processed, outputPath, false)
+	err = renderer.GenerateHTMLWithHealth(processed, outputPath, s.computeSummary(fileLabel(inputPath), "", inputPath, processed).HealthScore)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate HTML: %w", err)
 	}
@@ -278,6 +508,10 @@ func (s *SessionService) GenerateHTMLFromFile(inputPath, outputPath string, open
 		OpenedBrowser: false,
 	}
 
+	if includePreview {
+		result.Preview = s.buildHTMLPreview(fileLabel(inputPath), "", processed)
+	}
+
 	// Open browser if requested or if output was auto-generated
 	if openBrowser || autoOpen {
 		if err := browser.OpenInBrowser(outputPath); err != nil {
@@ -288,30 +522,116 @@ func (s *SessionService) GenerateHTMLFromFile(inputPath, outputPath string, open
 		}
 	}
 
+	result.recordOutput(models.OutputTypeHTML)
 	return result, nil
 }
 
-// FindSessionsByAgentType finds sessions that used a specific agent type.
-func (s *SessionService) FindSessionsByAgentType(agentType, projectName string, days int, limit int) ([]AgentUsageInfo, error) {
-	var projectsToSearch []models.Project
+// GenerateMultiSessionHTML renders several sessions into one HTML page with a
+// sidebar for switching between them.
+// If outputPath is empty, a temporary file is created and auto-opened in the browser.
+// If openBrowser is true, the HTML file is opened in the default browser.
+func (s *SessionService) GenerateMultiSessionHTML(sessionIDs []string, projectName, outputPath string, openBrowser bool) (*HTMLGenerationResult, error) {
+	if len(sessionIDs) == 0 {
+		return nil, fmt.Errorf("at least one session ID is required")
+	}
 
-	if projectName != "" {
-		project, err := s.projectService.FindProjectByName(projectName)
+	sessions := make(map[string][]*models.ProcessedEntry, len(sessionIDs))
+	for _, sessionID := range sessionIDs {
+		filePath, _, err := s.findSessionFile(sessionID, projectName)
 		if err != nil {
 			return nil, err
 		}
-		if project != nil {
-			projectsToSearch = append(projectsToSearch, *project)
+		if filePath == "" {
+			return nil, fmt.Errorf("session not found: %s", sessionID)
 		}
-	} else {
-		projects, err := s.projectService.ListProjects("")
+
+		entries, err := s.readJSONLFile(filePath)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("failed to read session file %s: %w", sessionID, err)
 		}
-		projectsToSearch = projects
+		sessions[sessionID] = processor.ProcessEntries(entries)
 	}
 
-	var results []AgentUsageInfo
+	// Determine output path
+	autoOpen := false
+	if outputPath == "" {
+		timestamp := time.Now().Format(constants.TempFileTimestampFormat)
+		outputPath = filepath.Join(os.TempDir(), fmt.Sprintf(constants.TempFileNameFormat, "multi", timestamp))
+		autoOpen = true
+	}
+
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+	err = renderer.GenerateMultiHTML(sessions, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate HTML: %w", err)
+	}
+
+	result := &HTMLGenerationResult{
+		OutputPath:    outputPath,
+		SessionIDs:    sessionIDs,
+		Project:       projectName,
+		OpenedBrowser: false,
+	}
+
+	// Open browser if requested or if output was auto-generated
+	if openBrowser || autoOpen {
+		result.OpenedBrowser = browser.OpenInBrowser(outputPath) == nil
+	}
+
+	result.recordOutput(models.OutputTypeHTML)
+	return result, nil
+}
+
+// buildHTMLPreview derives a preview from the session summary and the first user message.
+func (s *SessionService) buildHTMLPreview(sessionID, project string, entries []*models.ProcessedEntry) *HTMLPreview {
+	summary := s.computeSummary(sessionID, "", project, entries)
+
+	preview := &HTMLPreview{
+		EntryCount: len(entries),
+		Tokens:     summary.Tokens,
+		ErrorCount: summary.ErrorCount,
+	}
+	if summary.ToolCalls != nil {
+		preview.ToolCalls = summary.ToolCalls.Total
+	}
+
+	for _, e := range entries {
+		if e.Role == constants.RoleUser && !e.IsSidechain && e.Content != "" {
+			preview.FirstUserMessage = truncateString(e.Content, 200)
+			break
+		}
+	}
+
+	return preview
+}
+
+// DefaultMaxConcurrency is the number of projects scanned in parallel by
+// cross-project searches when no limit is given.
+const DefaultMaxConcurrency = 4
+
+// FindSessionsByAgentType finds sessions that used a specific agent type.
+// Projects are scanned by up to maxConcurrency workers; results are ordered by
+// sortBy (SortByProject or SortByTime) so output does not depend on scheduling
+// or directory order. If progress is non-nil it is
+// called after each project finishes. The returned report lists sessions that
+// could not be read.
+func (s *SessionService) FindSessionsByAgentType(agentType, projectName string, days, limit, maxConcurrency int, sortBy string, progress func(done, total int)) ([]AgentUsageInfo, models.ScanReport, error) {
+	var report models.ScanReport
+
+	if err := checkResultSort(sortBy); err != nil {
+		return nil, report, err
+	}
+
+	projectsToSearch, err := s.projectService.ResolveProjects(projectName)
+	if err != nil {
+		return nil, report, err
+	}
 
 	// When searching across multiple projects, limit sessions per project to avoid
 	// excessive file parsing. Use a reasonable limit that balances coverage vs performance.
@@ -325,33 +645,84 @@ func (s *SessionService) FindSessionsByAgentType(agentType, projectName string,
 		}
 	}
 
-	for _, project := range projectsToSearch {
-		sessions, err := s.ListSessions(project.Name, days, true, perProjectLimit)
-		if err != nil {
-			continue
-		}
+	if maxConcurrency <= 0 {
+		maxConcurrency = DefaultMaxConcurrency
+	}
+
+	perProject := make([][]AgentUsageInfo, len(projectsToSearch))
+	perProjectReports := make([]models.ScanReport, len(projectsToSearch))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	done := 0
+
+	for w := 0; w < maxConcurrency && w < len(projectsToSearch); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				perProject[i], perProjectReports[i] = s.findAgentTypeInProject(agentType, &projectsToSearch[i], days, perProjectLimit)
 
-		for _, session := range sessions {
-			for _, agentUsed := range session.AgentTypesUsed {
-				if strings.EqualFold(agentUsed, agentType) {
-					results = append(results, AgentUsageInfo{
-						SessionID:  session.SessionID,
-						Project:    project.Name,
-						Timestamp:  session.StartTime,
-						UsageCount: 1, // TODO: count actual usages
-					})
-					break
+				if progress != nil {
+					mu.Lock()
+					done++
+					progress(done, len(projectsToSearch))
+					mu.Unlock()
 				}
 			}
-		}
+		}()
+	}
+	for i := range projectsToSearch {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
 
-		if limit > 0 && len(results) >= limit {
-			results = results[:limit]
-			break
+	for _, projectReport := range perProjectReports {
+		report.Merge(projectReport)
+	}
+
+	var results []AgentUsageInfo
+	for _, projectResults := range perProject {
+		results = append(results, projectResults...)
+	}
+	sortAgentUsage(results, sortBy)
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results, report, nil
+}
+
+// findAgentTypeInProject returns the sessions in one project that invoked agentType,
+// with the number of Task invocations in each.
+func (s *SessionService) findAgentTypeInProject(agentType string, project *models.Project, days, sessionLimit int) ([]AgentUsageInfo, models.ScanReport) {
+	sessions, report, err := s.listProjectSessions(project, days, true, false, sessionLimit)
+	if err != nil {
+		return nil, report
+	}
+
+	var results []AgentUsageInfo
+	for _, session := range sessions {
+		count := 0
+		for agentUsed, n := range session.AgentTypeCounts {
+			if strings.EqualFold(agentUsed, agentType) {
+				count += n
+			}
 		}
+		if count == 0 {
+			continue
+		}
+
+		results = append(results, AgentUsageInfo{
+			SessionID:  session.SessionID,
+			Project:    project.Name,
+			Timestamp:  session.StartTime,
+			UsageCount: count,
+		})
 	}
 
-	return results, nil
+	return results, report
 }
 
 // AgentUsageInfo represents agent usage in a session.
@@ -364,27 +735,30 @@ type AgentUsageInfo struct {
 }
 
 // getSessionInfo extracts metadata from a session file.
+// Files without any parseable entries are reported with SessionStatusEmpty.
 func (s *SessionService) getSessionInfo(filePath, sessionID, projectName string, includeAgentTypes bool) (*models.SessionInfo, error) {
-	entries, err := parser.ReadJSONLFile(filePath)
+	entries, err := s.readJSONLFile(filePath)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(entries) == 0 {
-		return nil, nil
-	}
-
 	info := &models.SessionInfo{
 		SessionID:    sessionID,
 		Project:      projectName,
 		MessageCount: len(entries),
+		Status:       sessionStatus(entries),
 		FilePath:     filePath,
 	}
 
+	if len(entries) == 0 {
+		return info, nil
+	}
+
 	// Find min/max timestamps and collect metadata
+	seenCWDs := make(map[string]bool)
 	for _, entry := range entries {
 		if entry.Timestamp != "" {
-			if t, err := time.Parse(time.RFC3339, entry.Timestamp); err == nil {
+			if t, err := utils.ParseTimestamp(entry.Timestamp); err == nil {
 				// Track earliest timestamp as start time
 				if info.StartTime.IsZero() || t.Before(info.StartTime) {
 					info.StartTime = t
@@ -399,9 +773,18 @@ func (s *SessionService) getSessionInfo(filePath, sessionID, projectName string,
 		if info.CWD == "" && entry.CWD != "" {
 			info.CWD = entry.CWD
 		}
+		if entry.CWD != "" && !seenCWDs[entry.CWD] {
+			seenCWDs[entry.CWD] = true
+			info.CWDs = append(info.CWDs, entry.CWD)
+		}
 		if info.GitBranch == "" && entry.GitBranch != "" {
 			info.GitBranch = entry.GitBranch
 		}
+		// A resumed or continued session starts with entries carried over
+		// from the previous session, which keep that session's ID
+		if info.ContinuedFrom == "" && entry.SessionID != "" && entry.SessionID != sessionID {
+			info.ContinuedFrom = entry.SessionID
+		}
 	}
 
 	// Get first user message
@@ -414,12 +797,48 @@ func (s *SessionService) getSessionInfo(filePath, sessionID, projectName string,
 
 	// Extract agent types if requested
 	if includeAgentTypes {
-		info.AgentTypesUsed = extractAgentTypes(entries)
+		info.AgentTypeCounts = countAgentTypes(entries)
+		info.AgentTypesUsed = agentTypeNames(info.AgentTypeCounts)
 	}
 
 	return info, nil
 }
 
+// sessionStatus classifies a session by whether it contains a usable conversation.
+func sessionStatus(entries []models.LogEntry) string {
+	if len(entries) == 0 {
+		return models.SessionStatusEmpty
+	}
+
+	hasUser, hasAssistant := false, false
+	for _, entry := range entries {
+		switch entryRole(entry) {
+		case constants.RoleUser:
+			hasUser = true
+		case constants.RoleAssistant:
+			hasAssistant = true
+		}
+	}
+	if !hasUser || !hasAssistant {
+		return models.SessionStatusTruncated
+	}
+
+	return models.SessionStatusOK
+}
+
+// entryRole returns the message role of an entry, falling back to the entry
+// type when the message carries no role. Entries of type "message" keep the
+// role only inside the message, as processMessage handles them.
+func entryRole(entry models.LogEntry) string {
+	var msg struct {
+		Role string `json:"role"`
+	}
+	if err := json.Unmarshal(entry.Message, &msg); err == nil && msg.Role != "" {
+		return msg.Role
+	}
+	return entry.Type
+}
+
 // findSessionFile finds the session file path.
 func (s *SessionService) findSessionFile(sessionID, projectName string) (string, string, error) {
 	var projectsToSearch []models.Project
@@ -448,6 +867,21 @@ func (s *SessionService) findSessionFile(sessionID, projectName string) (string,
 		}
 	}
 
+	// Fall back to nested directories only after every project's top level missed
+	if s.discoveryDepth > 0 {
+		for _, project := range projectsToSearch {
+			files, err := s.sessionFiles(s.projectService.GetProjectDir(project.EncodedPath))
+			if err != nil {
+				continue
+			}
+			for _, file := range files {
+				if file.ID == sessionID {
+					return file.Path, project.Name, nil
+				}
+			}
+		}
+	}
+
 	return "", "", nil
 }
 
@@ -496,9 +930,9 @@ func extractFirstUserMessage(entry models.LogEntry) string {
 	return ""
 }
 
-// extractAgentTypes extracts subagent_type values from Task tool calls.
-func extractAgentTypes(entries []models.LogEntry) []string {
-	types := make(map[string]bool)
+// countAgentTypes counts Task tool invocations per subagent_type.
+func countAgentTypes(entries []models.LogEntry) map[string]int {
+	types := make(map[string]int)
 
 	for _, entry := range entries {
 		var msg map[string]interface{}
@@ -532,13 +966,18 @@ func extractAgentTypes(entries []models.LogEntry) []string {
 			}
 
 			if subagentType, ok := input["subagent_type"].(string); ok && subagentType != "" {
-				types[subagentType] = true
+				types[subagentType]++
 			}
 		}
 	}
 
-	result := make([]string, 0, len(types))
-	for t := range types {
+	return types
+}
+
+// agentTypeNames returns the sorted subagent types from countAgentTypes output.
+func agentTypeNames(counts map[string]int) []string {
+	result := make([]string, 0, len(counts))
+	for t := range counts {
 		result = append(result, t)
 	}
 	sort.Strings(result)
@@ -547,7 +986,7 @@ func extractAgentTypes(entries []models.LogEntry) []string {
 }
 
 // GetSessionSummary returns a lightweight summary of a session.
-func (s *SessionService) GetSessionSummary(sessionID, agentID, projectName string, includeSidechains bool) (*models.SessionSummary, error) {
+func (s *SessionService) GetSessionSummary(sessionID, agentID, projectName string, includeSidechains bool, excludeAgentIDs []string) (*models.SessionSummary, error) {
 	processed, project, err := s.loadProcessedEntries(sessionID, agentID, projectName, includeSidechains)
 	if err != nil {
 		return nil, err
@@ -556,11 +995,14 @@ func (s *SessionService) GetSessionSummary(sessionID, agentID, projectName strin
 		return nil, nil
 	}
 
-	return s.computeSummary(sessionID, agentID, project, processed), nil
+	processed, excluded := excludeAgents(processed, excludeAgentIDs)
+	summary := s.computeSummary(sessionID, agentID, project, processed)
+	summary.ExcludedAgents = excluded
+	return summary, nil
 }
 
 // GetToolUsageStats returns tool usage statistics for a session.
-func (s *SessionService) GetToolUsageStats(sessionID, agentID, projectName string, includeSidechains bool) (*models.ToolUsageStats, error) {
+func (s *SessionService) GetToolUsageStats(sessionID, agentID, projectName string, includeSidechains bool, excludeAgentIDs []string) (*models.ToolUsageStats, error) {
 	processed, _, err := s.loadProcessedEntries(sessionID, agentID, projectName, includeSidechains)
 	if err != nil {
 		return nil, err
@@ -569,7 +1011,10 @@ func (s *SessionService) GetToolUsageStats(sessionID, agentID, projectName strin
 		return nil, nil
 	}
 
-	return s.computeToolStats(sessionID, agentID, processed), nil
+	processed, excluded := excludeAgents(processed, excludeAgentIDs)
+	stats := s.computeToolStats(sessionID, agentID, processed)
+	stats.ExcludedAgents = excluded
+	return stats, nil
 }
 
 // GetSessionErrors returns errors found in a session.
@@ -586,7 +1031,10 @@ func (s *SessionService) GetSessionErrors(sessionID, agentID, projectName string
 }
 
 // GetSessionTimeline returns a condensed timeline of session events.
-func (s *SessionService) GetSessionTimeline(sessionID, agentID, projectName string, includeSidechains bool, limit int) (*models.SessionTimeline, error) {
+// When costModel names a model with known rates, each step carries its
+// estimated cost; otherwise cost is omitted. includeTokens adds each step's
+// input, output, and cache token breakdown.
+func (s *SessionService) GetSessionTimeline(sessionID, agentID, projectName string, includeSidechains bool, limit int, costModel string, includeTokens bool) (*models.SessionTimeline, error) {
 	processed, _, err := s.loadProcessedEntries(sessionID, agentID, projectName, includeSidechains)
 	if err != nil {
 		return nil, err
@@ -595,11 +1043,11 @@ func (s *SessionService) GetSessionTimeline(sessionID, agentID, projectName stri
 		return nil, nil
 	}
 
-	return s.computeTimeline(sessionID, agentID, processed, limit), nil
+	return s.computeTimeline(sessionID, agentID, processed, limit, costModel, includeTokens), nil
 }
 
 // GetSessionStats returns aggregated session statistics.
-func (s *SessionService) GetSessionStats(sessionID, agentID, projectName string, includeSidechains bool, errorsLimit int) (*models.SessionStats, error) {
+func (s *SessionService) GetSessionStats(sessionID, agentID, projectName string, includeSidechains bool, errorsLimit int, excludeAgentIDs []string) (*models.SessionStats, error) {
 	processed, project, err := s.loadProcessedEntries(sessionID, agentID, projectName, includeSidechains)
 	if err != nil {
 		return nil, err
@@ -608,6 +1056,8 @@ func (s *SessionService) GetSessionStats(sessionID, agentID, projectName string,
 		return nil, nil
 	}
 
+	processed, excluded := excludeAgents(processed, excludeAgentIDs)
+
 	stats := &models.SessionStats{
 		SessionID:   sessionID,
 		Project:     project,
@@ -619,8 +1069,11 @@ func (s *SessionService) GetSessionStats(sessionID, agentID, projectName string,
 	}
 
 	stats.Summary = s.computeSummary(sessionID, agentID, project, processed)
+	stats.Summary.ExcludedAgents = excluded
 	stats.ToolStats = s.computeToolStats(sessionID, agentID, processed)
+	stats.ToolStats.ExcludedAgents = excluded
 	stats.Errors = s.computeErrors(sessionID, agentID, processed, errorsLimit)
+	stats.TotalToolOutputBytes = totalToolOutputBytes(processed)
 
 	return stats, nil
 }
@@ -641,7 +1094,7 @@ func (s *SessionService) loadProcessedEntries(sessionID, agentID, projectName st
 		return nil, "", nil
 	}
 
-	entries, err := parser.ReadJSONLFile(filePath)
+	entries, err := s.readJSONLFile(filePath)
 	if err != nil {
 		return nil, "", err
 	}
@@ -773,6 +1226,20 @@ func readSingleJSONLFileForService(filename string) ([]models.LogEntry, error) {
 	return entries, scanner.Err()
 }
 
+// toolCallingResponses returns the message IDs of assistant responses that
+// made a tool call. Claude Code logs each content block of a response as its
+// own entry under the same message ID, so a response's text and thinking
+// entries belong to the tool-driven turn its tool_use entry ends.
+func toolCallingResponses(entries []*models.ProcessedEntry) map[string]bool {
+	ids := make(map[string]bool)
+	for _, e := range entries {
+		if e.Role == constants.RoleAssistant && e.MessageID != "" && len(e.ToolCalls) > 0 {
+			ids[e.MessageID] = true
+		}
+	}
+	return ids
+}
+
 // computeSummary computes a summary from processed entries.
 func (s *SessionService) computeSummary(sessionID, agentID, project string, entries []*models.ProcessedEntry) *models.SessionSummary {
 	summary := &models.SessionSummary{
@@ -786,20 +1253,40 @@ func (s *SessionService) computeSummary(sessionID, agentID, project string, entr
 
 	var (
 		totalInput, totalOutput, cacheRead, cacheCreation int
+		textOutput, toolOutput, thinkingTokens            int
+		thinkingEstimated                                 bool
 		totalToolCalls, successCalls, failedCalls         int
-		userMessages, assistantMessages                   int
+		noiseCalls                                        int
+		mutatingCalls, readOnlyCalls, unknownAccessCalls  int
+		userMessages, assistantMessages, emptyAssistant   int
+		userPrompts, userChars                            int
+		retriedCalls                                      int
+		prevCall                                          *models.ToolCall
+		assistantTexts, assistantChars                    int
 		errorCount                                        int
 		toolNames                                         = make(map[string]bool)
 		agentTypes                                        = make(map[string]bool)
 		minTime, maxTime                                  time.Time
 	)
+	toolResponses := toolCallingResponses(entries)
 
 	for _, e := range entries {
 		// Count messages
 		if e.Role == "user" {
 			userMessages++
+			if !e.IsToolResult && e.Content != "" {
+				userPrompts++
+				userChars += utf8.RuneCountInString(e.Content)
+			}
 		} else if e.Role == "assistant" {
 			assistantMessages++
+			if e.IsEmpty {
+				emptyAssistant++
+			}
+			if e.Content != "" {
+				assistantTexts++
+				assistantChars += utf8.RuneCountInString(e.Content)
+			}
 		}
 
 		// Count tokens
@@ -807,9 +1294,24 @@ func (s *SessionService) computeSummary(sessionID, agentID, project string, entr
 		totalOutput += e.OutputTokens
 		cacheRead += e.CacheReadTokens
 		cacheCreation += e.CacheCreationTokens
+		if e.Role == constants.RoleAssistant {
+			if len(e.ToolCalls) > 0 || toolResponses[e.MessageID] {
+				toolOutput += e.OutputTokens
+			} else {
+				textOutput += e.OutputTokens
+			}
+		}
+		thinkingTokens += e.ThinkingTokens
+		if e.ThinkingTokensEstimated {
+			thinkingEstimated = true
+		}
 
 		// Count tool calls
-		for _, tc := range e.ToolCalls {
+		for i, tc := range e.ToolCalls {
+			if s.noiseTools[tc.Name] {
+				noiseCalls++
+				continue
+			}
 			totalToolCalls++
 			toolNames[tc.Name] = true
 			if tc.Result != nil && tc.Result.IsError {
@@ -817,6 +1319,18 @@ func (s *SessionService) computeSummary(sessionID, agentID, project string, entr
 			} else {
 				successCalls++
 			}
+			switch toolAccess(tc.Name) {
+			case constants.ToolAccessWrite:
+				mutatingCalls++
+			case constants.ToolAccessRead:
+				readOnlyCalls++
+			default:
+				unknownAccessCalls++
+			}
+			if isRetry(prevCall, &e.ToolCalls[i]) {
+				retriedCalls++
+			}
+			prevCall = &e.ToolCalls[i]
 		}
 
 		// Count errors
@@ -831,7 +1345,7 @@ func (s *SessionService) computeSummary(sessionID, agentID, project string, entr
 
 		// Track timestamps for duration (use RawTimestamp which is RFC3339 format)
 		if e.RawTimestamp != "" {
-			if t, err := time.Parse(time.RFC3339, e.RawTimestamp); err == nil {
+			if t, err := utils.ParseTimestamp(e.RawTimestamp); err == nil {
 				if minTime.IsZero() || t.Before(minTime) {
 					minTime = t
 				}
@@ -844,7 +1358,18 @@ func (s *SessionService) computeSummary(sessionID, agentID, project string, entr
 
 	summary.MessageCount = len(entries)
 	summary.UserMessages = userMessages
+	summary.UserPrompts = userPrompts
 	summary.AssistantMsgs = assistantMessages
+	summary.EmptyAssistantMessages = emptyAssistant
+	if userPrompts > 0 {
+		summary.AvgUserMessageLength = float64(userChars) / float64(userPrompts)
+	}
+	if assistantTexts > 0 {
+		summary.AvgAssistantMessageLength = float64(assistantChars) / float64(assistantTexts)
+	}
+	if assistantMessages > 0 {
+		summary.UserAssistantRatio = float64(userPrompts) / float64(assistantMessages)
+	}
 
 	if !minTime.IsZero() {
 		summary.Date = minTime.Format("2006-01-02")
@@ -856,6 +1381,18 @@ func (s *SessionService) computeSummary(sessionID, agentID, project string, entr
 		TotalOutput:   totalOutput,
 		CacheRead:     cacheRead,
 		CacheCreation: cacheCreation,
+
+		TextOutputTokens: textOutput,
+		ToolOutputTokens: toolOutput,
+
+		ThinkingTokens:          thinkingTokens,
+		ThinkingTokensEstimated: thinkingEstimated,
+	}
+
+	// Burn rate over the session's wall-clock span; zero when the duration is unknown
+	if duration := maxTime.Sub(minTime); !minTime.IsZero() && duration > 0 {
+		totalTokens := totalInput + totalOutput + cacheRead + cacheCreation
+		summary.TokensPerMinute = float64(totalTokens) / duration.Minutes()
 	}
 
 	summary.ToolCalls = &models.ToolCallStats{
@@ -863,6 +1400,11 @@ func (s *SessionService) computeSummary(sessionID, agentID, project string, entr
 		UniqueTools: len(toolNames),
 		Success:     successCalls,
 		Failed:      failedCalls,
+		NoiseCalls:  noiseCalls,
+
+		Mutating:      mutatingCalls,
+		ReadOnly:      readOnlyCalls,
+		UnknownAccess: unknownAccessCalls,
 	}
 
 	agentList := make([]string, 0, len(agentTypes))
@@ -875,13 +1417,57 @@ func (s *SessionService) computeSummary(sessionID, agentID, project string, entr
 		Count:      len(agentTypes),
 		AgentTypes: agentList,
 	}
+	summary.AgentTokens = computeAgentTokens(entries)
 
 	summary.HasErrors = errorCount > 0
 	summary.ErrorCount = errorCount
 
+	summary.Compactions = computeCompactions(entries)
+	summary.CompactionCount = len(summary.Compactions)
+	summary.LongestToolFreeStretch = longestToolFreeStretch(entries)
+	summary.BranchSwitches = branchSwitches(entries)
+	summary.RetriedToolCalls = retriedCalls
+	summary.Interruptions = countSteering(entries).Interruptions
+	summary.HeavyReads = len(collectHeavyReads(entries, s.heavyReadThreshold(0)))
+	summary.HealthScore = s.healthScore(summary)
+
 	return summary
 }
 
+// setToolPercentages fills in each tool's share of the calls: headline tools
+// of the headline calls, and noise tools of all calls.
+func setToolPercentages(tools, noise []models.ToolUsageStat) {
+	headline := 0
+	for _, t := range tools {
+		headline += t.Count
+	}
+	all := headline
+	for _, t := range noise {
+		all += t.Count
+	}
+
+	for i := range tools {
+		tools[i].Percentage = float64(tools[i].Count) * 100 / float64(headline)
+	}
+	for i := range noise {
+		noise[i].Percentage = float64(noise[i].Count) * 100 / float64(all)
+	}
+}
+
+// sortToolUsage orders tools by call count, breaking ties by name so output is
+// deterministic across runs.
+func sortToolUsage(tools []models.ToolUsageStat) {
+	sort.Slice(tools, func(i, j int) bool {
+		if tools[i].Count != tools[j].Count {
+			return tools[i].Count > tools[j].Count
+		}
+		return tools[i].Name < tools[j].Name
+	})
+}
+
+// toolSignatureSeparator joins tool names in sequence signatures.
+const toolSignatureSeparator = ">"
+
 // computeToolStats computes tool usage statistics from processed entries.
 func (s *SessionService) computeToolStats(sessionID, agentID string, entries []*models.ProcessedEntry) *models.ToolUsageStats {
 	stats := &models.ToolUsageStats{
@@ -894,6 +1480,7 @@ func (s *SessionService) computeToolStats(sessionID, agentID string, entries []*
 
 	toolCounts := make(map[string]*models.ToolUsageStat)
 	var toolSequence []models.ToolSequenceEntry
+	var signature, collapsed []string
 	var firstTool, lastTool string
 	maxCount := 0
 	maxFailed := 0
@@ -903,17 +1490,21 @@ func (s *SessionService) computeToolStats(sessionID, agentID string, entries []*
 	for _, e := range entries {
 		for _, tc := range e.ToolCalls {
 			toolSequence = append(toolSequence, models.ToolSequenceEntry{
-				Name:      tc.Name,
-				ToolUseID: tc.ID,
+				Name:          tc.Name,
+				ToolUseID:     tc.ID,
+				UUID:          e.UUID,
+				ParallelGroup: tc.ParallelGroup,
+				GroupSize:     tc.ParallelGroupSize,
 			})
 
-			if firstTool == "" {
-				firstTool = tc.Name
+			signature = append(signature, tc.Name)
+			if len(collapsed) == 0 || collapsed[len(collapsed)-1] != tc.Name {
+				collapsed = append(collapsed, tc.Name)
 			}
-			lastTool = tc.Name
 
 			if _, exists := toolCounts[tc.Name]; !exists {
-				toolCounts[tc.Name] = &models.ToolUsageStat{Name: tc.Name}
+				access := toolAccess(tc.Name)
+				toolCounts[tc.Name] = &models.ToolUsageStat{Name: tc.Name, Access: access, Mutating: access == constants.ToolAccessWrite}
 			}
 
 			toolCounts[tc.Name].Count++
@@ -925,7 +1516,14 @@ func (s *SessionService) computeToolStats(sessionID, agentID string, entries []*
 				toolCounts[tc.Name].Success++
 			}
 
-			// Track patterns
+			// Track patterns, leaving out noise tools
+			if s.noiseTools[tc.Name] {
+				continue
+			}
+			if firstTool == "" {
+				firstTool = tc.Name
+			}
+			lastTool = tc.Name
 			if toolCounts[tc.Name].Count > maxCount {
 				maxCount = toolCounts[tc.Name].Count
 				mostUsed = tc.Name
@@ -937,17 +1535,25 @@ func (s *SessionService) computeToolStats(sessionID, agentID string, entries []*
 		}
 	}
 
-	// Convert map to sorted slice
+	// Convert map to sorted slices, with noise tools reported separately
 	tools := make([]models.ToolUsageStat, 0, len(toolCounts))
+	var noise []models.ToolUsageStat
 	for _, t := range toolCounts {
+		if s.noiseTools[t.Name] {
+			noise = append(noise, *t)
+			continue
+		}
 		tools = append(tools, *t)
 	}
-	sort.Slice(tools, func(i, j int) bool {
-		return tools[i].Count > tools[j].Count
-	})
+	sortToolUsage(tools)
+	sortToolUsage(noise)
+	setToolPercentages(tools, noise)
 
 	stats.Tools = tools
+	stats.NoiseTools = noise
 	stats.ToolSequence = toolSequence
+	stats.SequenceSignature = strings.Join(signature, toolSignatureSeparator)
+	stats.CollapsedSignature = strings.Join(collapsed, toolSignatureSeparator)
 	stats.Patterns = &models.ToolPatterns{
 		MostUsed:   mostUsed,
 		MostFailed: mostFailed,
@@ -1021,6 +1627,7 @@ func (s *SessionService) computeErrors(sessionID, agentID string, entries []*mod
 	}
 
 	result.TotalErrors = len(errors)
+	result.RootCause, result.RootCauseReason = findRootCause(errors)
 
 	// Apply limit
 	if limit > 0 && len(errors) > limit {
@@ -1062,12 +1669,17 @@ func (s *SessionService) entryToContextLog(e *models.ProcessedEntry, offset int)
 	log := models.ContextLog{
 		Offset:       offset,
 		Timestamp:    e.Timestamp,
+		LocalTime:    s.localTime(e.RawTimestamp),
 		Role:         e.Role,
 		Content:      truncateString(e.Content, 5000), // Larger limit for debugging context
 		IsToolResult: e.IsToolResult,
 		IsError:      e.IsError,
 	}
 
+	if e.IsToolResult {
+		log.Content = truncateString(s.toolOutputText(e.Content), 5000)
+	}
+
 	// If entry has tool calls, include tool details
 	if len(e.ToolCalls) > 0 {
 		tc := e.ToolCalls[0]
@@ -1077,7 +1689,7 @@ func (s *SessionService) entryToContextLog(e *models.ProcessedEntry, offset int)
 
 		// Include tool result content if available
 		if tc.Result != nil {
-			log.ToolOutput = truncateString(tc.Result.Content, 5000)
+			log.ToolOutput = truncateString(s.toolOutputText(tc.Result.Content), 5000)
 		}
 
 		// If there are multiple tool calls, indicate that
@@ -1090,7 +1702,7 @@ func (s *SessionService) entryToContextLog(e *models.ProcessedEntry, offset int)
 }
 
 // computeTimeline creates a condensed timeline from processed entries.
-func (s *SessionService) computeTimeline(sessionID, agentID string, entries []*models.ProcessedEntry, limit int) *models.SessionTimeline {
+func (s *SessionService) computeTimeline(sessionID, agentID string, entries []*models.ProcessedEntry, limit int, costModel string, includeTokens bool) *models.SessionTimeline {
 	timeline := &models.SessionTimeline{
 		SessionID:    sessionID,
 		TotalEntries: len(entries),
@@ -1100,6 +1712,29 @@ func (s *SessionService) computeTimeline(sessionID, agentID string, entries []*m
 		timeline.AgentID = &agentID
 	}
 
+	rates, priced := LookupModelRates(costModel)
+	if priced {
+		timeline.CostModel = costModel
+	}
+	stepCost := func(e *models.ProcessedEntry) float64 {
+		// Only assistant turns are billed; user token counts are estimates of prompt text
+		if !priced || e.Role != constants.RoleAssistant {
+			return 0
+		}
+		return rates.Cost(e.InputTokens, e.OutputTokens, e.CacheReadTokens, e.CacheCreationTokens)
+	}
+	tokenBreakdown := func(e *models.ProcessedEntry) *models.TokenBreakdown {
+		if !includeTokens {
+			return nil
+		}
+		return &models.TokenBreakdown{
+			Input:         e.InputTokens,
+			Output:        e.OutputTokens,
+			CacheRead:     e.CacheReadTokens,
+			CacheCreation: e.CacheCreationTokens,
+		}
+	}
+
 	var items []models.TimelineEntry
 	step := 0
 
@@ -1108,16 +1743,29 @@ func (s *SessionService) computeTimeline(sessionID, agentID string, entries []*m
 
 		// For tool calls, create separate timeline entries
 		if len(e.ToolCalls) > 0 {
-			for _, tc := range e.ToolCalls {
+			for i, tc := range e.ToolCalls {
 				item := models.TimelineEntry{
-					Step:      step,
-					Timestamp: e.Timestamp,
-					Role:      e.Role,
-					Type:      "tool_call",
-					Tool:      tc.Name,
-					ToolUseID: tc.ID,
-					Summary:   truncateString(extractToolSummary(tc), 150),
-					Tokens:    e.OutputTokens,
+					Step:         step,
+					Timestamp:    e.Timestamp,
+					Role:         e.Role,
+					Type:         "tool_call",
+					Tool:         tc.Name,
+					ToolUseID:    tc.ID,
+					Summary:      truncateString(extractToolSummary(tc), 150),
+					Tokens:       e.OutputTokens,
+					LocalTime:    s.localTime(e.RawTimestamp),
+					RawTimestamp: e.RawTimestamp,
+				}
+
+				if i == 0 {
+					item.Cost = stepCost(e)
+					item.TokenBreakdown = tokenBreakdown(e)
+				}
+
+				// Calls of one response may be spread over several entries
+				if tc.ParallelGroupSize > 1 {
+					item.Type = constants.EntryTypeParallelTools
+					item.ParallelGroup = tc.ParallelGroup
 				}
 
 				if tc.Result != nil {
@@ -1135,21 +1783,39 @@ func (s *SessionService) computeTimeline(sessionID, agentID string, entries []*m
 				items = append(items, item)
 				step++
 			}
+		} else if e.IsCompaction {
+			items = append(items, models.TimelineEntry{
+				Step:         step,
+				Timestamp:    e.Timestamp,
+				Type:         "compaction",
+				Summary:      "Context compacted",
+				LocalTime:    s.localTime(e.RawTimestamp),
+				RawTimestamp: e.RawTimestamp,
+			})
 		} else {
 			// Regular message
 			item := models.TimelineEntry{
-				Step:      step,
-				Timestamp: e.Timestamp,
-				Role:      e.Role,
-				Type:      "message",
-				Summary:   truncateString(e.Content, 150),
-				Tokens:    e.OutputTokens,
+				Step:           step,
+				Timestamp:      e.Timestamp,
+				Role:           e.Role,
+				Type:           "message",
+				Summary:        truncateString(e.Content, 150),
+				Tokens:         e.OutputTokens,
+				LocalTime:      s.localTime(e.RawTimestamp),
+				RawTimestamp:   e.RawTimestamp,
+				Cost:           stepCost(e),
+				TokenBreakdown: tokenBreakdown(e),
 			}
 
 			if e.IsSidechain && e.AgentID != "" {
 				item.Sidechain = e.AgentID
 			}
 
+			// Sidechain user turns are prompts from the parent agent, not the user
+			if e.Role == "user" && !e.IsSidechain && !e.IsCommandMessage && !e.IsCaveatMessage {
+				item.Followup = DefaultFollowupDetector.IsFollowup(e.Content)
+			}
+
 			items = append(items, item)
 		}
 
@@ -1164,6 +1830,10 @@ func (s *SessionService) computeTimeline(sessionID, agentID string, entries []*m
 		items = items[:limit]
 	}
 
+	for _, item := range items {
+		timeline.TotalCost += item.Cost
+	}
+
 	timeline.ReturnedEntries = len(items)
 	timeline.Timeline = items
 
@@ -1175,35 +1845,19 @@ func truncateString(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
 	}
-	return s[:maxLen] + "..."
+	return utils.CutAtRune(s, maxLen) + "..."
 }
 
-// extractToolSummary extracts a summary from a tool call.
+// extractToolSummary extracts a summary from a tool call using DefaultToolSummaries.
 func extractToolSummary(tc models.ToolCall) string {
-	// Try to get a meaningful summary from the raw input
-	if tc.RawInput != nil {
-		switch input := tc.RawInput.(type) {
-		case map[string]interface{}:
-			// Common fields to extract
-			for _, key := range []string{"command", "query", "url", "file_path", "pattern", "prompt"} {
-				if val, ok := input[key]; ok {
-					if str, ok := val.(string); ok {
-						return str
-					}
-				}
-			}
-		case string:
-			return input
-		}
-	}
-
-	return tc.Name
+	return DefaultToolSummaries.Summarize(tc)
 }
 
 // GetLogsAroundEntry retrieves logs surrounding a specific entry identified by UUID.
 // offset controls direction: negative = entries before target, positive = entries after target.
 // Examples: offset=-3 gets 3 entries before + target, offset=+3 gets target + 3 entries after.
-func (s *SessionService) GetLogsAroundEntry(sessionID, targetUUID, projectName string, offset int, includeSidechains bool) (*models.LogsAroundEntry, error) {
+// If includeHashes is true, each entry carries its content hash (see processor.ContentHash).
+func (s *SessionService) GetLogsAroundEntry(sessionID, targetUUID, projectName string, offset int, includeSidechains, includeHashes bool) (*models.LogsAroundEntry, error) {
 	processed, project, err := s.loadProcessedEntries(sessionID, "", projectName, includeSidechains)
 	if err != nil {
 		return nil, err
@@ -1212,17 +1866,9 @@ func (s *SessionService) GetLogsAroundEntry(sessionID, targetUUID, projectName s
 		return nil, nil
 	}
 
-	// Find the target entry by UUID
-	targetIndex := -1
-	for i, e := range processed {
-		if e.UUID == targetUUID {
-			targetIndex = i
-			break
-		}
-	}
-
-	if targetIndex == -1 {
-		return nil, fmt.Errorf("entry with UUID %s not found", targetUUID)
+	targetIndex, err := findEntryIndex(processed, targetUUID)
+	if err != nil {
+		return nil, err
 	}
 
 	// Default offset to -3 (before) if not specified
@@ -1264,9 +1910,36 @@ func (s *SessionService) GetLogsAroundEntry(sessionID, targetUUID, projectName s
 		}
 	}
 
+	if includeHashes {
+		hashContextLogs(result.Entries, processed, targetIndex)
+	}
+
 	return result, nil
 }
 
+// findEntryIndex returns the index of the entry with the given UUID, or an
+// error naming the UUID if no entry has it.
+func findEntryIndex(processed []*models.ProcessedEntry, uuid string) (int, error) {
+	for i, e := range processed {
+		if e.UUID == uuid {
+			return i, nil
+		}
+	}
+	return -1, errEntryNotFound(uuid)
+}
+
+// errEntryNotFound reports a UUID lookup that matched no entry.
+func errEntryNotFound(uuid string) error {
+	return fmt.Errorf("entry with UUID %s not found", uuid)
+}
+
+// hashContextLogs sets the content hash of each log around the entry at targetIndex.
+func hashContextLogs(logs []models.ContextLog, processed []*models.ProcessedEntry, targetIndex int) {
+	for i := range logs {
+		logs[i].ContentHash = processor.ContentHash(processed[targetIndex+logs[i].Offset])
+	}
+}
+
 // fileLabel returns a short label derived from the file path for use in response models.
 func fileLabel(filePath string) string {
 	base := filepath.Base(filePath)
@@ -1279,7 +1952,7 @@ func (s *SessionService) loadProcessedEntriesFromFile(filePath string, includeSi
 		return nil, fmt.Errorf("file not found: %s", filePath)
 	}
 
-	entries, err := parser.ReadJSONLFile(filePath)
+	entries, err := s.readJSONLFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
@@ -1300,14 +1973,19 @@ func (s *SessionService) loadProcessedEntriesFromFile(filePath string, includeSi
 }
 
 // GetSessionLogsFromFile retrieves full processed logs from a JSONL file path.
-func (s *SessionService) GetSessionLogsFromFile(filePath string, includeSidechains bool) (*models.SessionLogs, error) {
-	entries, err := parser.ReadJSONLFile(filePath)
+func (s *SessionService) GetSessionLogsFromFile(filePath string, opts LogsOptions) (*models.SessionLogs, error) {
+	entries, err := s.readJSONLFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
 	processed := processor.ProcessEntries(entries)
 
+	var rawUsage map[string]json.RawMessage
+	if opts.IncludeRawUsage {
+		rawUsage = rawUsageByUUID(entries)
+	}
+
 	label := fileLabel(filePath)
 	logs := &models.SessionLogs{
 		SessionID: label,
@@ -1315,43 +1993,7 @@ func (s *SessionService) GetSessionLogsFromFile(filePath string, includeSidechai
 		Entries:   make([]models.SessionLogEntry, 0),
 	}
 
-	var totalInput, totalOutput, cacheRead, cacheCreation int
-
-	for _, entry := range processed {
-		if !includeSidechains && entry.IsSidechain {
-			continue
-		}
-
-		logEntry := models.SessionLogEntry{
-			UUID:        entry.UUID,
-			Timestamp:   entry.Timestamp,
-			Role:        entry.Role,
-			Content:     entry.Content,
-			IsSidechain: entry.IsSidechain,
-			AgentID:     entry.AgentID,
-		}
-
-		for _, tc := range entry.ToolCalls {
-			logEntry.ToolCalls = append(logEntry.ToolCalls, models.SessionToolCall{
-				Name:  tc.Name,
-				Input: tc.RawInput,
-			})
-		}
-
-		logs.Entries = append(logs.Entries, logEntry)
-
-		totalInput += entry.InputTokens
-		totalOutput += entry.OutputTokens
-		cacheRead += entry.CacheReadTokens
-		cacheCreation += entry.CacheCreationTokens
-	}
-
-	logs.TokenStats = &models.SessionTokenStats{
-		TotalInput:    totalInput,
-		TotalOutput:   totalOutput,
-		CacheRead:     cacheRead,
-		CacheCreation: cacheCreation,
-	}
+	s.appendLogEntries(logs, processed, rawUsage, opts)
 
 	return logs, nil
 }
@@ -1367,6 +2009,11 @@ func (s *SessionService) GetSessionSummaryFromFile(filePath string, includeSidec
 	return s.computeSummary(label, "", filePath, processed), nil
 }
 
+// SummarizeEntries returns a summary of already-processed entries, labelled with sessionID.
+func (s *SessionService) SummarizeEntries(sessionID string, entries []*models.ProcessedEntry) *models.SessionSummary {
+	return s.computeSummary(sessionID, "", "", entries)
+}
+
 // GetToolUsageStatsFromFile returns tool usage statistics from a JSONL file path.
 func (s *SessionService) GetToolUsageStatsFromFile(filePath string, includeSidechains bool) (*models.ToolUsageStats, error) {
 	processed, err := s.loadProcessedEntriesFromFile(filePath, includeSidechains)
@@ -1390,14 +2037,14 @@ func (s *SessionService) GetSessionErrorsFromFile(filePath string, includeSidech
 }
 
 // GetSessionTimelineFromFile returns a condensed timeline from a JSONL file.
-func (s *SessionService) GetSessionTimelineFromFile(filePath string, includeSidechains bool, limit int) (*models.SessionTimeline, error) {
+func (s *SessionService) GetSessionTimelineFromFile(filePath string, includeSidechains bool, limit int, costModel string, includeTokens bool) (*models.SessionTimeline, error) {
 	processed, err := s.loadProcessedEntriesFromFile(filePath, includeSidechains)
 	if err != nil {
 		return nil, err
 	}
 
 	label := fileLabel(filePath)
-	return s.computeTimeline(label, "", processed, limit), nil
+	return s.computeTimeline(label, "", processed, limit, costModel, includeTokens), nil
 }
 
 // GetSessionStatsFromFile returns aggregated statistics from a JSONL file.
@@ -1417,28 +2064,75 @@ func (s *SessionService) GetSessionStatsFromFile(filePath string, includeSidecha
 	stats.Summary = s.computeSummary(label, "", filePath, processed)
 	stats.ToolStats = s.computeToolStats(label, "", processed)
 	stats.Errors = s.computeErrors(label, "", processed, errorsLimit)
+	stats.TotalToolOutputBytes = totalToolOutputBytes(processed)
+
+	return stats, nil
+}
+
+// GetStatsFromFiles computes stats over several JSONL files as if they were
+// one session, with entries in the order the paths are given; error entry
+// indexes count across all files. If perFile is true, each file's own summary
+// is added to Sources. Entries from excluded agents are dropped from every file.
+func (s *SessionService) GetStatsFromFiles(paths []string, includeSidechains bool, errorsLimit int, perFile bool, excludeAgentIDs []string) (*models.SessionStats, error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no files given")
+	}
+
+	var processed []*models.ProcessedEntry
+	var sources []models.SourceStats
+	var excluded *models.AgentExclusion
+	labels := make([]string, len(paths))
+	for i, path := range paths {
+		entries, err := s.loadProcessedEntriesFromFile(path, includeSidechains)
+		if err != nil {
+			return nil, err
+		}
+		entries, dropped := excludeAgents(entries, excludeAgentIDs)
+		if excluded == nil {
+			excluded = dropped
+		} else {
+			excluded.EntriesDropped += dropped.EntriesDropped
+		}
+		processed = append(processed, entries...)
+		labels[i] = fileLabel(path)
+
+		if perFile {
+			sources = append(sources, models.SourceStats{
+				Path:    path,
+				Summary: s.computeSummary(labels[i], "", path, entries),
+			})
+		}
+	}
+
+	label := strings.Join(labels, "+")
+	project := strings.Join(paths, ", ")
+	stats := &models.SessionStats{
+		SessionID:   label,
+		Project:     project,
+		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+		Sources:     sources,
+	}
+
+	stats.Summary = s.computeSummary(label, "", project, processed)
+	stats.Summary.ExcludedAgents = excluded
+	stats.ToolStats = s.computeToolStats(label, "", processed)
+	stats.ToolStats.ExcludedAgents = excluded
+	stats.Errors = s.computeErrors(label, "", processed, errorsLimit)
+	stats.TotalToolOutputBytes = totalToolOutputBytes(processed)
 
 	return stats, nil
 }
 
 // GetLogsAroundEntryFromFile returns logs around a specific entry from a JSONL file.
-func (s *SessionService) GetLogsAroundEntryFromFile(filePath, targetUUID string, offset int, includeSidechains bool) (*models.LogsAroundEntry, error) {
+func (s *SessionService) GetLogsAroundEntryFromFile(filePath, targetUUID string, offset int, includeSidechains, includeHashes bool) (*models.LogsAroundEntry, error) {
 	processed, err := s.loadProcessedEntriesFromFile(filePath, includeSidechains)
 	if err != nil {
 		return nil, err
 	}
 
-	// Find the target entry by UUID
-	targetIndex := -1
-	for i, e := range processed {
-		if e.UUID == targetUUID {
-			targetIndex = i
-			break
-		}
-	}
-
-	if targetIndex == -1 {
-		return nil, fmt.Errorf("entry with UUID %s not found", targetUUID)
+	targetIndex, err := findEntryIndex(processed, targetUUID)
+	if err != nil {
+		return nil, err
 	}
 
 	if offset == 0 {
@@ -1476,5 +2170,9 @@ func (s *SessionService) GetLogsAroundEntryFromFile(filePath, targetUUID string,
 		}
 	}
 
+	if includeHashes {
+		hashContextLogs(result.Entries, processed, targetIndex)
+	}
+
 	return result, nil
 }
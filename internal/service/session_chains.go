@@ -0,0 +1,56 @@
+package service
+
+import (
+	"sort"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// GroupSessionChains groups sessions linked by ContinuedFrom into chains,
+// each ordered oldest first. Chains are ordered by where their first member
+// appears in sessions, so a newest-first list stays newest first. A session
+// whose predecessor is not in sessions starts its own chain.
+func GroupSessionChains(sessions []models.SessionInfo) [][]models.SessionInfo {
+	byID := make(map[string]int, len(sessions))
+	for i, s := range sessions {
+		byID[s.SessionID] = i
+	}
+
+	// root follows ContinuedFrom links back to the earliest listed session,
+	// stopping if the links loop
+	root := func(i int) int {
+		seen := map[int]bool{i: true}
+		for {
+			prev, ok := byID[sessions[i].ContinuedFrom]
+			if !ok || seen[prev] {
+				return i
+			}
+			seen[prev] = true
+			i = prev
+		}
+	}
+
+	var chains [][]models.SessionInfo
+	chainOf := make(map[int]int)
+	for i, s := range sessions {
+		r := root(i)
+		c, ok := chainOf[r]
+		if !ok {
+			c = len(chains)
+			chainOf[r] = c
+			chains = append(chains, nil)
+		}
+		chains[c] = append(chains[c], s)
+	}
+
+	for _, chain := range chains {
+		sort.SliceStable(chain, func(i, j int) bool {
+			if !chain[i].StartTime.Equal(chain[j].StartTime) {
+				return chain[i].StartTime.Before(chain[j].StartTime)
+			}
+			return chain[i].SessionID < chain[j].SessionID
+		})
+	}
+
+	return chains
+}
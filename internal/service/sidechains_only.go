@@ -0,0 +1,188 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/brads3290/cclogviewer/internal/browser"
+	"github.com/brads3290/cclogviewer/internal/constants"
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/processor"
+	"github.com/brads3290/cclogviewer/internal/renderer"
+)
+
+// subagentTranscripts groups a session's sidechain entries by AgentID, in the
+// order each subagent first appears. Entries nested under Task calls are
+// included, as are root-level sidechains that matched no Task call. Main-thread
+// entries are left out.
+func subagentTranscripts(entries []*models.ProcessedEntry) []models.SubagentTranscript {
+	var transcripts []models.SubagentTranscript
+	index := make(map[string]int)
+	agentTypes := make(map[string]string)
+
+	var walk func(entries []*models.ProcessedEntry)
+	walk = func(entries []*models.ProcessedEntry) {
+		for _, e := range entries {
+			if e.IsSidechain {
+				i, ok := index[e.AgentID]
+				if !ok {
+					i = len(transcripts)
+					index[e.AgentID] = i
+					transcripts = append(transcripts, models.SubagentTranscript{AgentID: e.AgentID})
+				}
+				transcripts[i].Entries = append(transcripts[i].Entries, e)
+			}
+
+			for _, tc := range e.ToolCalls {
+				if tc.Name == constants.TaskToolName && len(tc.TaskEntries) > 0 {
+					if agentID := tc.TaskEntries[0].AgentID; agentID != "" {
+						agentTypes[agentID] = subagentName(tc)
+					}
+				}
+				walk(tc.TaskEntries)
+			}
+		}
+	}
+	walk(entries)
+
+	for i := range transcripts {
+		transcripts[i].AgentType = agentTypes[transcripts[i].AgentID]
+	}
+	return transcripts
+}
+
+// sidechainEntries flattens subagentTranscripts into one list, grouped by AgentID.
+func sidechainEntries(entries []*models.ProcessedEntry) []*models.ProcessedEntry {
+	var result []*models.ProcessedEntry
+	for _, t := range subagentTranscripts(entries) {
+		result = append(result, t.Entries...)
+	}
+	return result
+}
+
+// GetSidechainLogs retrieves only a session's subagent entries, grouped by
+// AgentID; the inverse of GetSessionLogs without opts.IncludeSidechains, which
+// is ignored here.
+func (s *SessionService) GetSidechainLogs(sessionID, projectName string, opts LogsOptions) (*models.SessionLogs, error) {
+	filePath, project, err := s.findSessionFile(sessionID, projectName)
+	if err != nil {
+		return nil, err
+	}
+	if filePath == "" {
+		return nil, nil
+	}
+
+	entries, err := s.readJSONLFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var rawUsage map[string]json.RawMessage
+	if opts.IncludeRawUsage {
+		rawUsage = rawUsageByUUID(entries)
+	}
+
+	logs := &models.SessionLogs{
+		SessionID: sessionID,
+		Project:   project,
+		Entries:   make([]models.SessionLogEntry, 0),
+	}
+	opts.IncludeSidechains = true
+	s.appendLogEntries(logs, sidechainEntries(processor.ProcessEntries(entries)), rawUsage, opts)
+
+	return logs, nil
+}
+
+// GetSidechainTimeline returns a condensed timeline of only a session's
+// subagent entries, grouped by AgentID.
+func (s *SessionService) GetSidechainTimeline(sessionID, projectName string, limit int, costModel string, includeTokens bool) (*models.SessionTimeline, error) {
+	processed, _, err := s.loadProcessedEntries(sessionID, "", projectName, true)
+	if err != nil {
+		return nil, err
+	}
+	if processed == nil {
+		return nil, nil
+	}
+
+	return s.computeTimeline(sessionID, "", sidechainEntries(processed), limit, costModel, includeTokens), nil
+}
+
+// GenerateSidechainHTML renders a session's subagent transcripts into one HTML
+// page, each under its subagent type header. Main-thread entries are left out.
+// If outputPath is empty, a temporary file is created and auto-opened in the browser.
+// If openBrowser is true, the HTML file is opened in the default browser.
+func (s *SessionService) GenerateSidechainHTML(sessionID, projectName, outputPath string, openBrowser bool) (*HTMLGenerationResult, error) {
+	processed, project, err := s.loadProcessedEntries(sessionID, "", projectName, true)
+	if err != nil {
+		return nil, err
+	}
+	if processed == nil {
+		return nil, fmt.Errorf("session not found: %s", sessionID)
+	}
+
+	result, err := s.writeSidechainHTML(processed, sessionID, outputPath, openBrowser)
+	if err != nil {
+		return nil, err
+	}
+	result.SessionID = sessionID
+	result.Project = project
+	return result, nil
+}
+
+// GenerateSidechainHTMLFromFile renders the subagent transcripts of a JSONL
+// file into one HTML page, as GenerateSidechainHTML does for a session.
+func (s *SessionService) GenerateSidechainHTMLFromFile(inputPath, outputPath string, openBrowser bool) (*HTMLGenerationResult, error) {
+	processed, err := s.loadProcessedEntriesFromFile(inputPath, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.writeSidechainHTML(processed, fileLabel(inputPath), outputPath, openBrowser)
+}
+
+// writeSidechainHTML renders the transcripts of processed to outputPath, or to
+// a temporary file named after label when outputPath is empty.
+func (s *SessionService) writeSidechainHTML(processed []*models.ProcessedEntry, label, outputPath string, openBrowser bool) (*HTMLGenerationResult, error) {
+	transcripts := subagentTranscripts(processed)
+	if len(transcripts) == 0 {
+		return nil, fmt.Errorf("no subagent conversations found")
+	}
+
+	autoOpen := false
+	if outputPath == "" {
+		if len(label) > 8 {
+			label = label[:8]
+		}
+		timestamp := time.Now().Format(constants.TempFileTimestampFormat)
+		outputPath = filepath.Join(os.TempDir(), fmt.Sprintf(constants.TempFileNameFormat, label, timestamp))
+		autoOpen = true
+	}
+
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+	err = renderer.GenerateSubagentHTML(transcripts, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate HTML: %w", err)
+	}
+
+	result := &HTMLGenerationResult{
+		OutputPath:    outputPath,
+		OpenedBrowser: false,
+	}
+
+	// Open browser if requested or if output was auto-generated
+	if openBrowser || autoOpen {
+		result.OpenedBrowser = browser.OpenInBrowser(outputPath) == nil
+	}
+
+	result.recordOutput(models.OutputTypeHTML)
+	return result, nil
+}
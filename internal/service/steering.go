@@ -0,0 +1,91 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/brads3290/cclogviewer/internal/constants"
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// SteeringStats counts interruptions, rejected tool calls, and short follow-ups
+// in each session of a project from the last days days (0 for all), newest
+// first. Only the main conversation is counted since subagents are not steered
+// by the user directly. Returns nil if the project is not found.
+func (s *SessionService) SteeringStats(projectName string, days int) (*models.SteeringStats, error) {
+	project, err := s.projectService.FindProjectByName(projectName)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, nil
+	}
+
+	sessions, report, err := s.listProjectSessions(project, days, false, false, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &models.SteeringStats{
+		Project:  project.Name,
+		Days:     days,
+		Sessions: make([]models.SessionSteering, 0, len(sessions)),
+	}
+
+	for _, session := range sessions {
+		processed, err := s.loadProcessedEntriesFromFile(session.FilePath, false)
+		if err != nil {
+			report.AddFailure(session.SessionID, project.Name, session.FilePath, err)
+			continue
+		}
+
+		counts := countSteering(processed)
+		stats.Sessions = append(stats.Sessions, models.SessionSteering{
+			SessionID:      session.SessionID,
+			StartTime:      session.StartTime,
+			SteeringCounts: counts,
+		})
+
+		stats.Total.UserMessages += counts.UserMessages
+		stats.Total.Interruptions += counts.Interruptions
+		stats.Total.RejectedToolCalls += counts.RejectedToolCalls
+		stats.Total.Followups += counts.Followups
+	}
+
+	stats.ScanReport = report
+	return stats, nil
+}
+
+// countSteering tallies steering events in the main conversation.
+func countSteering(entries []*models.ProcessedEntry) models.SteeringCounts {
+	var counts models.SteeringCounts
+
+	for _, e := range entries {
+		if e.IsSidechain {
+			continue
+		}
+
+		for _, tc := range e.ToolCalls {
+			if tc.Result != nil && strings.Contains(strings.ToLower(tc.Result.Content), constants.UserRejectionPattern) {
+				counts.RejectedToolCalls++
+			}
+		}
+
+		if e.Role != constants.RoleUser || e.IsToolResult || e.IsCommandMessage || e.IsCaveatMessage {
+			continue
+		}
+
+		// Interrupted tool uses also leave a marker message, so counting markers
+		// covers both interrupted text and interrupted tools once each
+		if strings.Contains(strings.ToLower(e.Content), constants.UserInterruptionPattern) {
+			counts.Interruptions++
+			continue
+		}
+
+		counts.UserMessages++
+		if DefaultFollowupDetector.IsFollowup(e.Content) {
+			counts.Followups++
+		}
+	}
+
+	return counts
+}
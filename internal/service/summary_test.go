@@ -0,0 +1,120 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/brads3290/cclogviewer/internal/constants"
+	"github.com/brads3290/cclogviewer/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// splitResponseSession is a session in which one response (msg_1) is logged as
+// a text entry followed by two tool_use entries, as Claude Code writes it,
+// then a text-only response (msg_2) closes the turn.
+var splitResponseSession = []string{
+	userLine("u1", "2024-01-01T10:00:00Z", "Read both config files and tell me what differs"),
+	`{"uuid":"a1","parentUuid":"u1","type":"assistant","timestamp":"2024-01-01T10:00:01Z","message":{"id":"msg_1","role":"assistant","content":[{"type":"text","text":"I will read the two files side by side."}],"usage":{"input_tokens":10,"output_tokens":40}}}`,
+	`{"uuid":"a2","parentUuid":"a1","type":"assistant","timestamp":"2024-01-01T10:00:01Z","message":{"id":"msg_1","role":"assistant","content":[{"type":"tool_use","id":"t1","name":"Read","input":{"file_path":"/a.json"}}],"usage":{"input_tokens":10,"output_tokens":40}}}`,
+	`{"uuid":"a3","parentUuid":"a2","type":"assistant","timestamp":"2024-01-01T10:00:01Z","message":{"id":"msg_1","role":"assistant","content":[{"type":"tool_use","id":"t2","name":"Read","input":{"file_path":"/b.json"}}],"usage":{"input_tokens":10,"output_tokens":40}}}`,
+	`{"uuid":"r1","parentUuid":"a3","type":"user","timestamp":"2024-01-01T10:00:02Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t1","content":"{\"port\": 1}"}]}}`,
+	`{"uuid":"r2","parentUuid":"r1","type":"user","timestamp":"2024-01-01T10:00:02Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t2","content":"{\"port\": 2}"}]}}`,
+	`{"uuid":"a4","parentUuid":"r2","type":"assistant","timestamp":"2024-01-01T10:00:03Z","message":{"id":"msg_2","role":"assistant","content":[{"type":"text","text":"Only the port differs: 1 versus 2."}],"usage":{"input_tokens":20,"output_tokens":12}}}`,
+}
+
+func TestComputeSummary_OutputSplit(t *testing.T) {
+	claudeDir := t.TempDir()
+	path := writeSession(t, claudeDir, "-home-u-app", "11111111-1111-1111-1111-111111111111", splitResponseSession...)
+	services := NewServices(claudeDir)
+
+	processed, err := services.Session.loadProcessedEntriesFromFile(path, false)
+	require.NoError(t, err)
+	var firstText, closingText, assistantOutput int
+	for _, e := range processed {
+		switch e.UUID {
+		case "a1":
+			firstText = e.OutputTokens
+		case "a4":
+			closingText = e.OutputTokens
+		}
+		if e.Role == "assistant" {
+			assistantOutput += e.OutputTokens
+		}
+	}
+	require.NotZero(t, firstText)
+
+	summary, err := services.Session.GetSessionSummaryFromFile(path, false)
+	require.NoError(t, err)
+
+	// The user prompt's estimate is not assistant output
+	assert.Equal(t, assistantOutput, summary.Tokens.TextOutputTokens+summary.Tokens.ToolOutputTokens)
+	// msg_1's text entry belongs to the tool-driven response it opens
+	assert.Equal(t, closingText, summary.Tokens.TextOutputTokens)
+}
+
+func TestComputeTimeline_SplitResponseParallel(t *testing.T) {
+	claudeDir := t.TempDir()
+	path := writeSession(t, claudeDir, "-home-u-app", "11111111-1111-1111-1111-111111111111", splitResponseSession...)
+	services := NewServices(claudeDir)
+
+	timeline, err := services.Session.GetSessionTimelineFromFile(path, false, 0, "", false)
+	require.NoError(t, err)
+
+	types := make(map[string]string)
+	groups := make(map[string]int)
+	for _, item := range timeline.Timeline {
+		if item.ToolUseID != "" {
+			types[item.ToolUseID] = item.Type
+			groups[item.ToolUseID] = item.ParallelGroup
+		}
+	}
+
+	// t1 and t2 were logged in separate entries of msg_1
+	assert.Equal(t, constants.EntryTypeParallelTools, types["t1"])
+	assert.Equal(t, constants.EntryTypeParallelTools, types["t2"])
+	assert.NotZero(t, groups["t1"])
+	assert.Equal(t, groups["t1"], groups["t2"])
+}
+
+func TestProjectSummaries(t *testing.T) {
+	claudeDir := t.TempDir()
+	writeSession(t, claudeDir, "-home-u-app", "11111111-1111-1111-1111-111111111111", splitResponseSession...)
+	services := NewServices(claudeDir)
+
+	summaries, report, err := services.Session.ProjectSummaries("app", 0, 0)
+	require.NoError(t, err)
+	require.Len(t, summaries, 1)
+	assert.Equal(t, "11111111-1111-1111-1111-111111111111", summaries[0].SessionID)
+	assert.Equal(t, 1, report.SessionsScanned)
+	assert.Empty(t, report.Failures)
+
+	_, _, err = services.Session.ProjectSummaries("nosuch", 0, 0)
+	assert.ErrorContains(t, err, "project not found")
+}
+
+func TestExcludeEmptyAssistant(t *testing.T) {
+	var entries []*models.ProcessedEntry
+	for i := 0; i < 8; i++ {
+		entries = append(entries,
+			&models.ProcessedEntry{Role: constants.RoleUser, Content: "next step"},
+			&models.ProcessedEntry{Role: constants.RoleAssistant, Content: "done"})
+	}
+	for i := 0; i < 3; i++ {
+		entries = append(entries, &models.ProcessedEntry{Role: constants.RoleAssistant, IsEmpty: true})
+	}
+	entries = append(entries, &models.ProcessedEntry{Role: constants.RoleUser, IsToolResult: true, IsError: true})
+
+	services := NewServices(t.TempDir())
+	summary := services.Session.computeSummary("session-1", "", "app", entries)
+	before := summary.HealthScore
+
+	services.Session.ExcludeEmptyAssistant(summary)
+
+	assert.Equal(t, 8, summary.AssistantMsgs)
+	assert.Equal(t, 17, summary.MessageCount)
+	assert.Equal(t, 3, summary.EmptyAssistantMessages)
+	// The ratio and health score follow the filtered counts
+	assert.Equal(t, 1.0, summary.UserAssistantRatio)
+	assert.Less(t, summary.HealthScore, before)
+}
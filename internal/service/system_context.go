@@ -0,0 +1,123 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/brads3290/cclogviewer/internal/constants"
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/processor/tools/diff"
+)
+
+// systemReminderPattern matches context blocks (e.g. CLAUDE.md contents) injected into user messages.
+var systemReminderPattern = regexp.MustCompile(`(?s)<system-reminder>\s*(.*?)\s*</system-reminder>`)
+
+// ExtractSystemContext returns the context injected ahead of the conversation:
+// system entries, meta user messages and <system-reminder> blocks recorded
+// before the first assistant reply, joined by blank lines. Returns "" when the
+// session carries no explicit system context, and an error when it is not found.
+func (s *SessionService) ExtractSystemContext(sessionID, projectName string) (string, error) {
+	filePath, _, err := s.findSessionFile(sessionID, projectName)
+	if err != nil {
+		return "", err
+	}
+	if filePath == "" {
+		return "", fmt.Errorf("session not found: %s", sessionID)
+	}
+	return s.ExtractSystemContextFromFile(filePath)
+}
+
+// ExtractSystemContextFromFile returns the system context from a JSONL file path.
+func (s *SessionService) ExtractSystemContextFromFile(filePath string) (string, error) {
+	entries, err := s.readJSONLFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+	return collectSystemContext(entries), nil
+}
+
+// DiffSystemContext compares the system context of two sessions line by line,
+// with the first session as the old side. Identical contexts yield no changes.
+func (s *SessionService) DiffSystemContext(oldSessionID, newSessionID, projectName string) ([]diff.DiffLine, error) {
+	oldContext, err := s.ExtractSystemContext(oldSessionID, projectName)
+	if err != nil {
+		return nil, err
+	}
+	newContext, err := s.ExtractSystemContext(newSessionID, projectName)
+	if err != nil {
+		return nil, err
+	}
+	return diff.ComputeLineDiff(oldContext, newContext), nil
+}
+
+// collectSystemContext gathers main-conversation context entries up to the
+// first assistant message. Compaction boundaries are skipped.
+func collectSystemContext(entries []models.LogEntry) string {
+	var parts []string
+	for _, entry := range entries {
+		if entry.IsSidechain {
+			continue
+		}
+		if entry.Type == constants.TypeAssistant {
+			break
+		}
+
+		switch {
+		case entry.Type == constants.TypeSystem:
+			if entry.CompactMetadata != nil {
+				continue
+			}
+			var text string
+			if err := json.Unmarshal(entry.Content, &text); err == nil && strings.TrimSpace(text) != "" {
+				parts = append(parts, strings.TrimSpace(text))
+			}
+		case entry.Type == constants.TypeUser:
+			text := userMessageText(entry)
+			if entry.IsMeta {
+				if strings.TrimSpace(text) != "" {
+					parts = append(parts, strings.TrimSpace(text))
+				}
+				continue
+			}
+			for _, m := range systemReminderPattern.FindAllStringSubmatch(text, -1) {
+				if m[1] != "" {
+					parts = append(parts, m[1])
+				}
+			}
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// userMessageText joins the text of a user message, whether stored as a
+// string or as content blocks.
+func userMessageText(entry models.LogEntry) string {
+	var msg struct {
+		Content json.RawMessage `json:"content"`
+	}
+	if err := json.Unmarshal(entry.Message, &msg); err != nil {
+		return ""
+	}
+
+	var text string
+	if err := json.Unmarshal(msg.Content, &text); err == nil {
+		return text
+	}
+
+	var blocks []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(msg.Content, &blocks); err != nil {
+		return ""
+	}
+	var texts []string
+	for _, b := range blocks {
+		if b.Type == constants.ContentTypeText {
+			texts = append(texts, b.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractSystemContext(t *testing.T) {
+	claudeDir := t.TempDir()
+	writeSession(t, claudeDir, "-home-u-app", "11111111-1111-1111-1111-111111111111",
+		userLine("u1", "2024-01-01T10:00:00Z", "<system-reminder>Use tabs.</system-reminder>Format the file"),
+		assistantLine("a1", "2024-01-01T10:00:01Z", "Done"))
+	writeSession(t, claudeDir, "-home-u-app", "22222222-2222-2222-2222-222222222222",
+		userLine("u2", "2024-01-01T11:00:00Z", "Format the file"),
+		assistantLine("a2", "2024-01-01T11:00:01Z", "Done"))
+	services := NewServices(claudeDir)
+
+	text, err := services.Session.ExtractSystemContext("11111111-1111-1111-1111-111111111111", "")
+	require.NoError(t, err)
+	assert.Equal(t, "Use tabs.", text)
+
+	// Found but empty is not an error
+	text, err = services.Session.ExtractSystemContext("22222222-2222-2222-2222-222222222222", "")
+	require.NoError(t, err)
+	assert.Empty(t, text)
+
+	_, err = services.Session.ExtractSystemContext("33333333-3333-3333-3333-333333333333", "")
+	assert.EqualError(t, err, "session not found: 33333333-3333-3333-3333-333333333333")
+
+	_, err = services.Session.ExtractSystemContextFromFile(t.TempDir() + "/missing.jsonl")
+	assert.Error(t, err)
+
+	_, err = services.Session.DiffSystemContext("11111111-1111-1111-1111-111111111111", "33333333-3333-3333-3333-333333333333", "")
+	assert.Error(t, err)
+}
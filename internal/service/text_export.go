@@ -0,0 +1,208 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/brads3290/cclogviewer/internal/constants"
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/processor"
+)
+
+// Strategies for dropping turns when a text export is over its token budget.
+const (
+	TruncateOldestFirst = "oldest-first" // Keep the most recent turns
+	TruncateMiddleOut   = "middle-out"   // Keep the opening and closing turns
+)
+
+// exportTextMaxChars caps each prompt and reply in a text export so one long
+// message cannot crowd out the rest of the session.
+const exportTextMaxChars = 1500
+
+// exportTurn is one user prompt with the tools and final reply that followed it.
+type exportTurn struct {
+	prompt     string
+	toolNames  []string
+	toolCounts map[string]int
+	conclusion string
+}
+
+// ExportSessionText renders the main conversation of a session as a compact
+// transcript: each user prompt, the tools used in response, and the last
+// assistant reply before the next prompt. Tool inputs and outputs are left out.
+// When maxTokens is positive, whole turns are dropped using strategy until the
+// estimate fits. Returns nil if the session is not found.
+func (s *SessionService) ExportSessionText(sessionID, projectName string, maxTokens int, strategy string) (*models.SessionTextExport, error) {
+	processed, _, err := s.loadProcessedEntries(sessionID, "", projectName, false)
+	if err != nil {
+		return nil, err
+	}
+	if processed == nil {
+		return nil, nil
+	}
+
+	return exportText(sessionID, processed, maxTokens, strategy)
+}
+
+// ExportSessionTextFromFile renders a text export for a JSONL file path.
+func (s *SessionService) ExportSessionTextFromFile(filePath string, maxTokens int, strategy string) (*models.SessionTextExport, error) {
+	processed, err := s.loadProcessedEntriesFromFile(filePath, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return exportText(fileLabel(filePath), processed, maxTokens, strategy)
+}
+
+func exportText(sessionID string, entries []*models.ProcessedEntry, maxTokens int, strategy string) (*models.SessionTextExport, error) {
+	switch strategy {
+	case "":
+		strategy = TruncateOldestFirst
+	case TruncateOldestFirst, TruncateMiddleOut:
+	default:
+		return nil, fmt.Errorf("invalid strategy %q: must be %s or %s", strategy, TruncateOldestFirst, TruncateMiddleOut)
+	}
+
+	turns := collectExportTurns(entries)
+	rendered := make([]string, len(turns))
+	for i, turn := range turns {
+		rendered[i] = renderExportTurn(i+1, turn)
+	}
+
+	export := &models.SessionTextExport{
+		SessionID:  sessionID,
+		TotalTurns: len(turns),
+	}
+	if maxTokens > 0 {
+		export.MaxTokens = maxTokens
+		export.Strategy = strategy
+	}
+
+	text, omitted := fitExportTurns(rendered, maxTokens, strategy)
+	export.Text = text
+	export.OmittedTurns = omitted
+	export.EstimatedTokens = processor.EstimateTokens(text)
+	return export, nil
+}
+
+// collectExportTurns groups the main conversation into turns. Assistant
+// activity before the first prompt gets a turn with an empty prompt.
+func collectExportTurns(entries []*models.ProcessedEntry) []*exportTurn {
+	var turns []*exportTurn
+	current := func() *exportTurn {
+		if len(turns) == 0 {
+			turns = append(turns, &exportTurn{toolCounts: make(map[string]int)})
+		}
+		return turns[len(turns)-1]
+	}
+
+	for _, e := range entries {
+		if e.IsSidechain {
+			continue
+		}
+
+		content := strings.TrimSpace(e.Content)
+		switch e.Role {
+		case constants.RoleUser:
+			if e.IsToolResult || e.IsCommandMessage || e.IsCaveatMessage || content == "" {
+				continue
+			}
+			turns = append(turns, &exportTurn{prompt: content, toolCounts: make(map[string]int)})
+		case constants.RoleAssistant:
+			turn := current()
+			for _, tc := range e.ToolCalls {
+				if turn.toolCounts[tc.Name] == 0 {
+					turn.toolNames = append(turn.toolNames, tc.Name)
+				}
+				turn.toolCounts[tc.Name]++
+			}
+			if content != "" {
+				turn.conclusion = content
+			}
+		}
+	}
+
+	return turns
+}
+
+func renderExportTurn(n int, turn *exportTurn) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%d] User: %s\n", n, truncateString(turn.prompt, exportTextMaxChars))
+	if len(turn.toolNames) > 0 {
+		tools := make([]string, len(turn.toolNames))
+		for i, name := range turn.toolNames {
+			tools[i] = name
+			if count := turn.toolCounts[name]; count > 1 {
+				tools[i] = fmt.Sprintf("%s x%d", name, count)
+			}
+		}
+		fmt.Fprintf(&b, "Tools: %s\n", strings.Join(tools, ", "))
+	}
+	if turn.conclusion != "" {
+		fmt.Fprintf(&b, "Assistant: %s\n", truncateString(turn.conclusion, exportTextMaxChars))
+	}
+	return b.String()
+}
+
+// fitExportTurns joins rendered turns, dropping whole turns until the token
+// estimate is within maxTokens. Dropped turns are replaced by a marker line.
+// If a single turn is still over budget, its text is cut short. Returns the
+// text and the number of turns dropped.
+func fitExportTurns(turns []string, maxTokens int, strategy string) (string, int) {
+	if maxTokens <= 0 || len(turns) == 0 {
+		return strings.Join(turns, "\n"), 0
+	}
+
+	tokens := make([]int, len(turns))
+	total := 0
+	for i, t := range turns {
+		tokens[i] = processor.EstimateTokens(t)
+		total += tokens[i]
+	}
+
+	// Keep turns[:head] and turns[len(turns)-tail:]. Oldest-first drops from
+	// the front of the tail; middle-out trims whichever side is longer.
+	head, tail := 0, len(turns)
+	if strategy == TruncateMiddleOut {
+		head, tail = (len(turns)+1)/2, len(turns)/2
+	}
+	marker := func(omitted int) string {
+		return fmt.Sprintf("[... %d turns omitted ...]\n", omitted)
+	}
+	markerTokens := processor.EstimateTokens(marker(len(turns)))
+
+	omitted := 0
+	for total+markerTokens*min(omitted, 1) > maxTokens && head+tail > 1 {
+		if head > tail {
+			head--
+			total -= tokens[head]
+		} else {
+			total -= tokens[len(turns)-tail]
+			tail--
+		}
+		omitted++
+	}
+
+	kept := append([]string{}, turns[:head]...)
+	if omitted > 0 {
+		kept = append(kept, marker(omitted))
+	}
+	kept = append(kept, turns[len(turns)-tail:]...)
+	text := strings.Join(kept, "\n")
+
+	// One turn left and still too long: cut it down in proportion to the overrun
+	cutShort := false
+	for estimate := processor.EstimateTokens(text); estimate > maxTokens && text != ""; estimate = processor.EstimateTokens(text) {
+		cut := len(text) * maxTokens / estimate
+		if cut >= len(text) {
+			cut = len(text) - 1
+		}
+		text = strings.TrimSuffix(truncateString(text, cut), "...")
+		cutShort = true
+	}
+	if cutShort {
+		text += "..."
+	}
+
+	return text, omitted
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/utils"
+)
+
+// TimeWindow limits output to entries logged between From and To, inclusive.
+// Each bound is optional and may be a clock time ("14:00", "14:00:30") on the
+// session's start date, an RFC3339 timestamp, or an offset from the session
+// start ("+30m").
+type TimeWindow struct {
+	From string
+	To   string
+	// Location interprets clock times; nil uses the log's own time zone.
+	Location *time.Location
+}
+
+// IsZero reports whether the window has no bounds.
+func (w TimeWindow) IsZero() bool {
+	return w.From == "" && w.To == ""
+}
+
+// resolve returns absolute bounds for a session that started at start.
+// A zero bound is open.
+func (w TimeWindow) resolve(start time.Time) (from, to time.Time, err error) {
+	if from, err = parseWindowBound(w.From, start, w.Location); err != nil {
+		return
+	}
+	to, err = parseWindowBound(w.To, start, w.Location)
+	return
+}
+
+// inWindow reports whether the RFC3339 timestamp raw falls within [from, to].
+// Entries without a parseable timestamp are outside every bounded window.
+func inWindow(from, to time.Time, raw string) bool {
+	t, err := utils.ParseTimestamp(raw)
+	if err != nil {
+		return false
+	}
+	return (from.IsZero() || !t.Before(from)) && (to.IsZero() || !t.After(to))
+}
+
+// parseWindowBound parses one TimeWindow bound relative to the session start.
+func parseWindowBound(value string, start time.Time, loc *time.Location) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if strings.HasPrefix(value, "+") {
+		d, err := time.ParseDuration(value[1:])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid time %q: %w", value, err)
+		}
+		return start.Add(d), nil
+	}
+
+	if t, err := utils.ParseTimestamp(value); err == nil {
+		return t, nil
+	}
+
+	if loc != nil {
+		start = start.In(loc)
+	}
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		if clock, err := time.Parse(layout, value); err == nil {
+			return time.Date(start.Year(), start.Month(), start.Day(),
+				clock.Hour(), clock.Minute(), clock.Second(), 0, start.Location()), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time %q: use HH:MM[:SS], an RFC3339 timestamp, or +duration from session start", value)
+}
+
+// earliestTimestamp returns the earliest parseable RFC3339 timestamp in raws.
+func earliestTimestamp(raws []string) time.Time {
+	var earliest time.Time
+	for _, raw := range raws {
+		if t, err := utils.ParseTimestamp(raw); err == nil && (earliest.IsZero() || t.Before(earliest)) {
+			earliest = t
+		}
+	}
+	return earliest
+}
+
+// FilterLogsByTime keeps only log entries within the window, recording how many
+// matched out of the session total.
+func FilterLogsByTime(logs *models.SessionLogs, window TimeWindow) error {
+	raws := make([]string, len(logs.Entries))
+	for i, e := range logs.Entries {
+		raws[i] = e.RawTimestamp
+	}
+	from, to, err := window.resolve(earliestTimestamp(raws))
+	if err != nil {
+		return err
+	}
+
+	filtered := make([]models.SessionLogEntry, 0)
+	for _, e := range logs.Entries {
+		if inWindow(from, to, e.RawTimestamp) {
+			filtered = append(filtered, e)
+		}
+	}
+
+	// Keep the session total if an earlier filter already recorded it
+	if logs.TotalEntries == 0 {
+		logs.TotalEntries = len(logs.Entries)
+	}
+	logs.FilteredEntries = len(filtered)
+	logs.Entries = filtered
+	return nil
+}
+
+// FilterTimelineByTime keeps only timeline entries within the window and then
+// applies limit. The timeline should be built without a limit so no matches are lost.
+func FilterTimelineByTime(timeline *models.SessionTimeline, window TimeWindow, limit int) error {
+	raws := make([]string, len(timeline.Timeline))
+	for i, e := range timeline.Timeline {
+		raws[i] = e.RawTimestamp
+	}
+	from, to, err := window.resolve(earliestTimestamp(raws))
+	if err != nil {
+		return err
+	}
+
+	filtered := make([]models.TimelineEntry, 0)
+	for _, e := range timeline.Timeline {
+		if inWindow(from, to, e.RawTimestamp) {
+			filtered = append(filtered, e)
+		}
+	}
+
+	timeline.FilteredEntries = len(filtered)
+	if limit > 0 && len(filtered) > limit {
+		filtered = filtered[:limit]
+	}
+
+	timeline.TotalCost = 0
+	for _, e := range filtered {
+		timeline.TotalCost += e.Cost
+	}
+
+	timeline.Timeline = filtered
+	timeline.ReturnedEntries = len(filtered)
+	return nil
+}
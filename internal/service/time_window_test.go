@@ -0,0 +1,135 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWindowBound(t *testing.T) {
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		value   string
+		loc     *time.Location
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "empty is open", value: "", want: time.Time{}},
+		{name: "whitespace is open", value: "  ", want: time.Time{}},
+		{name: "clock", value: "14:00", want: time.Date(2024, 1, 1, 14, 0, 0, 0, time.UTC)},
+		{name: "clock with seconds", value: "14:00:30", want: time.Date(2024, 1, 1, 14, 0, 30, 0, time.UTC)},
+		{name: "clock in location", value: "14:00", loc: berlin, want: time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC)},
+		{name: "rfc3339", value: "2024-01-02T08:15:00Z", want: time.Date(2024, 1, 2, 8, 15, 0, 0, time.UTC)},
+		{name: "duration", value: "+30m", want: time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC)},
+		{name: "bad duration", value: "+soon", wantErr: true},
+		{name: "garbage", value: "yesterday", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseWindowBound(tt.value, start, tt.loc)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "want %v, got %v", tt.want, got)
+		})
+	}
+}
+
+func TestTimeWindowResolve(t *testing.T) {
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	from, to, err := TimeWindow{From: "+5m", To: "10:30"}.resolve(start)
+	require.NoError(t, err)
+	assert.True(t, from.Equal(start.Add(5*time.Minute)))
+	assert.True(t, to.Equal(start.Add(30*time.Minute)))
+
+	from, to, err = TimeWindow{}.resolve(start)
+	require.NoError(t, err)
+	assert.True(t, from.IsZero())
+	assert.True(t, to.IsZero())
+
+	_, _, err = TimeWindow{To: "later"}.resolve(start)
+	assert.Error(t, err)
+}
+
+func TestFilterLogsByTime(t *testing.T) {
+	newLogs := func() *models.SessionLogs {
+		return &models.SessionLogs{Entries: []models.SessionLogEntry{
+			{UUID: "a", RawTimestamp: "2024-01-01T10:00:00Z"},
+			{UUID: "b", RawTimestamp: "2024-01-01T10:05:00Z"},
+			{UUID: "c", RawTimestamp: "2024-01-01T10:10:00Z"},
+			{UUID: "d", RawTimestamp: ""},
+		}}
+	}
+	uuids := func(logs *models.SessionLogs) []string {
+		var ids []string
+		for _, e := range logs.Entries {
+			ids = append(ids, e.UUID)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name   string
+		window TimeWindow
+		want   []string
+	}{
+		{name: "edges are inclusive", window: TimeWindow{From: "10:05", To: "10:10"}, want: []string{"b", "c"}},
+		{name: "open end", window: TimeWindow{From: "+5m"}, want: []string{"b", "c"}},
+		{name: "open start", window: TimeWindow{To: "2024-01-01T10:05:00Z"}, want: []string{"a", "b"}},
+		{name: "no match", window: TimeWindow{From: "11:00"}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs := newLogs()
+			require.NoError(t, FilterLogsByTime(logs, tt.window))
+			assert.Equal(t, tt.want, uuids(logs))
+			assert.Equal(t, 4, logs.TotalEntries)
+			assert.Equal(t, len(tt.want), logs.FilteredEntries)
+		})
+	}
+
+	t.Run("keeps an earlier total", func(t *testing.T) {
+		logs := newLogs()
+		logs.TotalEntries = 10
+		require.NoError(t, FilterLogsByTime(logs, TimeWindow{From: "10:05"}))
+		assert.Equal(t, 10, logs.TotalEntries)
+	})
+
+	t.Run("invalid bound", func(t *testing.T) {
+		assert.Error(t, FilterLogsByTime(newLogs(), TimeWindow{From: "soon"}))
+	})
+}
+
+func TestFilterTimelineByTime(t *testing.T) {
+	timeline := &models.SessionTimeline{
+		TotalEntries: 3,
+		Timeline: []models.TimelineEntry{
+			{Step: 1, RawTimestamp: "2024-01-01T10:00:00Z", Cost: 1},
+			{Step: 2, RawTimestamp: "2024-01-01T10:05:00Z", Cost: 2},
+			{Step: 3, RawTimestamp: "2024-01-01T10:10:00Z", Cost: 4},
+		},
+		TotalCost: 7,
+	}
+
+	require.NoError(t, FilterTimelineByTime(timeline, TimeWindow{From: "+5m"}, 1))
+
+	assert.Equal(t, 2, timeline.FilteredEntries)
+	assert.Equal(t, 1, timeline.ReturnedEntries)
+	require.Len(t, timeline.Timeline, 1)
+	assert.Equal(t, 2, timeline.Timeline[0].Step)
+	// The total covers only the returned steps
+	assert.Equal(t, 2.0, timeline.TotalCost)
+	assert.Equal(t, 3, timeline.TotalEntries)
+}
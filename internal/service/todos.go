@@ -0,0 +1,102 @@
+package service
+
+import (
+	"github.com/brads3290/cclogviewer/internal/constants"
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// ExtractTodos returns a snapshot of the task list for each TodoWrite call in a session.
+func (s *SessionService) ExtractTodos(sessionID, projectName string) ([]models.TodoSnapshot, error) {
+	processed, _, err := s.loadProcessedEntries(sessionID, "", projectName, false)
+	if err != nil {
+		return nil, err
+	}
+	if processed == nil {
+		return nil, nil
+	}
+
+	return extractTodoSnapshots(processed), nil
+}
+
+// ExtractTodosFromFile returns TodoWrite snapshots from a JSONL file path.
+func (s *SessionService) ExtractTodosFromFile(filePath string) ([]models.TodoSnapshot, error) {
+	processed, err := s.loadProcessedEntriesFromFile(filePath, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return extractTodoSnapshots(processed), nil
+}
+
+// extractTodoSnapshots walks main-thread TodoWrite calls in order and diffs each
+// list against the previous one to flag added and newly completed items.
+func extractTodoSnapshots(entries []*models.ProcessedEntry) []models.TodoSnapshot {
+	snapshots := make([]models.TodoSnapshot, 0)
+	previous := make(map[string]string)
+
+	for _, e := range entries {
+		for _, tc := range e.ToolCalls {
+			if tc.Name != constants.ToolNameTodoWrite {
+				continue
+			}
+
+			items := parseTodoItems(tc.RawInput)
+			snapshot := models.TodoSnapshot{
+				Step:      len(snapshots) + 1,
+				UUID:      e.UUID,
+				Timestamp: e.RawTimestamp,
+				Items:     items,
+			}
+
+			current := make(map[string]string, len(items))
+			for i := range items {
+				item := &snapshot.Items[i]
+				prevStatus, existed := previous[item.Content]
+				if !existed {
+					item.IsNew = true
+					snapshot.AddedCount++
+				}
+				if item.Status == "completed" && prevStatus != "completed" {
+					item.NewlyCompleted = true
+					snapshot.CompletedCount++
+				}
+				current[item.Content] = item.Status
+			}
+			previous = current
+
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+
+	return snapshots
+}
+
+// parseTodoItems extracts todo items from a TodoWrite tool input.
+func parseTodoItems(rawInput interface{}) []models.TodoItem {
+	items := make([]models.TodoItem, 0)
+
+	input, ok := rawInput.(map[string]interface{})
+	if !ok {
+		return items
+	}
+
+	todos, ok := input["todos"].([]interface{})
+	if !ok {
+		return items
+	}
+
+	for _, t := range todos {
+		todo, ok := t.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		item := models.TodoItem{}
+		item.Content, _ = todo["content"].(string)
+		item.Status, _ = todo["status"].(string)
+		item.ActiveForm, _ = todo["activeForm"].(string)
+		items = append(items, item)
+	}
+
+	return items
+}
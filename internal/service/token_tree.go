@@ -0,0 +1,116 @@
+package service
+
+import (
+	"sort"
+
+	"github.com/brads3290/cclogviewer/internal/constants"
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// textNodeName labels the node for turns that did not call a tool.
+const textNodeName = "(text)"
+
+// TokenAttribution breaks a session's token usage down into the main
+// conversation, each subagent, and the tools each conversation drove. A turn
+// that issued several tool calls splits its tokens evenly between them.
+func (s *SessionService) TokenAttribution(sessionID, projectName string) (*models.TokenTree, error) {
+	processed, _, err := s.loadProcessedEntries(sessionID, "", projectName, false)
+	if err != nil {
+		return nil, err
+	}
+	if processed == nil {
+		return nil, nil
+	}
+
+	return buildTokenTree(sessionID, models.TokenNodeSession, processed), nil
+}
+
+// TokenAttributionFromFile builds the token tree for a JSONL file path.
+func (s *SessionService) TokenAttributionFromFile(filePath string) (*models.TokenTree, error) {
+	processed, err := s.loadProcessedEntriesFromFile(filePath, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildTokenTree(fileLabel(filePath), models.TokenNodeSession, processed), nil
+}
+
+// buildTokenTree attributes the tokens of one conversation, recursing into the
+// subagent conversations of its Task calls.
+func buildTokenTree(name, kind string, entries []*models.ProcessedEntry) *models.TokenTree {
+	node := &models.TokenTree{Name: name, Kind: kind}
+	byName := make(map[string]*models.TokenTree)
+
+	child := func(name, kind string) *models.TokenTree {
+		if c, ok := byName[name]; ok {
+			return c
+		}
+		c := &models.TokenTree{Name: name, Kind: kind}
+		byName[name] = c
+		node.Children = append(node.Children, c)
+		return c
+	}
+
+	for _, e := range entries {
+		tokens := e.InputTokens + e.OutputTokens + e.CacheReadTokens + e.CacheCreationTokens
+
+		if len(e.ToolCalls) == 0 {
+			child(textNodeName, models.TokenNodeText).SelfTokens += tokens
+			continue
+		}
+
+		share := tokens / len(e.ToolCalls)
+		for i, tc := range e.ToolCalls {
+			toolNode := child(tc.Name, models.TokenNodeTool)
+			toolNode.SelfTokens += share
+			if i == 0 {
+				toolNode.SelfTokens += tokens % len(e.ToolCalls)
+			}
+
+			if tc.Name == constants.TaskToolName && len(tc.TaskEntries) > 0 {
+				agent := buildTokenTree(subagentName(tc), models.TokenNodeAgent, tc.TaskEntries)
+				agent.AgentID = tc.TaskEntries[0].AgentID
+				toolNode.Children = append(toolNode.Children, agent)
+			}
+		}
+	}
+
+	sumTokenTree(node)
+	return node
+}
+
+// subagentName returns the subagent type of a Task call, or its description.
+func subagentName(tc models.ToolCall) string {
+	if input, ok := tc.RawInput.(map[string]interface{}); ok {
+		if agentType, ok := input["subagent_type"].(string); ok && agentType != "" {
+			return agentType
+		}
+		if desc, ok := input["description"].(string); ok && desc != "" {
+			return desc
+		}
+	}
+	return "subagent"
+}
+
+// sumTokenTree fills in Tokens bottom-up, drops empty nodes, and orders
+// children largest first.
+func sumTokenTree(node *models.TokenTree) {
+	node.Tokens = node.SelfTokens
+
+	children := node.Children[:0]
+	for _, c := range node.Children {
+		sumTokenTree(c)
+		if c.Tokens > 0 {
+			children = append(children, c)
+			node.Tokens += c.Tokens
+		}
+	}
+	node.Children = children
+
+	sort.SliceStable(node.Children, func(i, j int) bool {
+		if node.Children[i].Tokens != node.Children[j].Tokens {
+			return node.Children[i].Tokens > node.Children[j].Tokens
+		}
+		return node.Children[i].Name < node.Children[j].Name
+	})
+}
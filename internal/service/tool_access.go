@@ -0,0 +1,69 @@
+package service
+
+import (
+	"github.com/brads3290/cclogviewer/internal/constants"
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// toolAccess returns whether a tool reads or modifies state, or
+// constants.ToolAccessUnknown for tools that are not classified.
+func toolAccess(name string) string {
+	if access, ok := constants.ToolAccess[name]; ok {
+		return access
+	}
+	return constants.ToolAccessUnknown
+}
+
+// FilterMutatingTools keeps only state-modifying tools in tool stats: the
+// per-tool counts, noise tools, and the call sequence. Patterns and
+// signatures still describe every call.
+func FilterMutatingTools(stats *models.ToolUsageStats) {
+	stats.Tools = mutatingToolStats(stats.Tools)
+	if stats.NoiseTools != nil {
+		stats.NoiseTools = mutatingToolStats(stats.NoiseTools)
+	}
+
+	sequence := make([]models.ToolSequenceEntry, 0)
+	for _, e := range stats.ToolSequence {
+		if toolAccess(e.Name) == constants.ToolAccessWrite {
+			sequence = append(sequence, e)
+		}
+	}
+	stats.ToolSequence = sequence
+}
+
+// mutatingToolStats returns the state-modifying tools in tools.
+func mutatingToolStats(tools []models.ToolUsageStat) []models.ToolUsageStat {
+	filtered := make([]models.ToolUsageStat, 0)
+	for _, t := range tools {
+		if t.Mutating {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered
+}
+
+// FilterMutatingTimeline keeps only calls to state-modifying tools in a
+// timeline and then applies limit. The timeline should be built without a
+// limit so no matches are lost.
+func FilterMutatingTimeline(timeline *models.SessionTimeline, limit int) {
+	filtered := make([]models.TimelineEntry, 0)
+	for _, e := range timeline.Timeline {
+		if e.Tool != "" && toolAccess(e.Tool) == constants.ToolAccessWrite {
+			filtered = append(filtered, e)
+		}
+	}
+
+	timeline.FilteredEntries = len(filtered)
+	if limit > 0 && len(filtered) > limit {
+		filtered = filtered[:limit]
+	}
+
+	timeline.TotalCost = 0
+	for _, e := range filtered {
+		timeline.TotalCost += e.Cost
+	}
+
+	timeline.Timeline = filtered
+	timeline.ReturnedEntries = len(filtered)
+}
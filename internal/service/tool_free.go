@@ -0,0 +1,49 @@
+package service
+
+import (
+	"github.com/brads3290/cclogviewer/internal/constants"
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// longestToolFreeStretch finds the longest run of assistant turns in the main
+// conversation with no tool calls, where Claude wrote or thought without
+// acting. The run ends at the next turn with tool calls; user prompts and
+// empty responses neither end it nor count as turns. Steps are numbered as in
+// the session timeline. Ties go to the earliest run. Returns nil if every
+// assistant turn made tool calls.
+func longestToolFreeStretch(entries []*models.ProcessedEntry) *models.ToolFreeStretch {
+	var longest, current *models.ToolFreeStretch
+
+	step := 0
+	for _, e := range entries {
+		// Timeline steps advance once per entry and once more per tool call
+		step++
+		entryStep := step
+		step += len(e.ToolCalls)
+
+		if e.IsSidechain || e.Role != constants.RoleAssistant || e.IsEmpty {
+			continue
+		}
+
+		if len(e.ToolCalls) > 0 {
+			current = nil
+			continue
+		}
+
+		if current == nil {
+			current = &models.ToolFreeStretch{StartStep: entryStep, StartUUID: e.UUID}
+		}
+		current.Turns++
+		current.EndStep = entryStep
+		current.EndUUID = e.UUID
+		current.OutputTokens += e.OutputTokens
+		current.ThinkingTokens += e.ThinkingTokens
+
+		if longest == nil || current.Turns > longest.Turns {
+			stretch := *current
+			longest = &stretch
+		}
+	}
+
+	return longest
+}
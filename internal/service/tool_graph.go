@@ -0,0 +1,153 @@
+package service
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/brads3290/cclogviewer/internal/constants"
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// toolGraphPathKeys are the tool input keys that name a file.
+var toolGraphPathKeys = []string{"file_path", "notebook_path", "path"}
+
+// ToolCallGraph builds a graph of a session's tool calls, including those made
+// by subagents. Returns nil if the session cannot be found or read.
+//
+// Edges are inferred with two simple rules:
+//   - file: a call that names a file (Read, Edit, Write, ... via file_path,
+//     notebook_path or path, or a Bash command containing the path) is linked
+//     from the previous call that named the same file.
+//   - sequence: each call is linked to the next call in the same conversation,
+//     unless a file edge already links them.
+func (s *SessionService) ToolCallGraph(sessionID, projectName string) *models.ToolGraph {
+	processed, _, err := s.loadProcessedEntries(sessionID, "", projectName, false)
+	if err != nil || processed == nil {
+		return nil
+	}
+
+	return buildToolGraph(sessionID, processed)
+}
+
+// ToolCallGraphFromFile builds a tool call graph from a JSONL file path.
+func (s *SessionService) ToolCallGraphFromFile(filePath string) (*models.ToolGraph, error) {
+	processed, err := s.loadProcessedEntriesFromFile(filePath, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildToolGraph(fileLabel(filePath), processed), nil
+}
+
+// buildToolGraph collects nodes in log order and infers edges between them.
+func buildToolGraph(sessionID string, entries []*models.ProcessedEntry) *models.ToolGraph {
+	graph := &models.ToolGraph{
+		SessionID: sessionID,
+		Nodes:     make([]models.ToolGraphNode, 0),
+		Edges:     make([]models.ToolGraphEdge, 0),
+	}
+
+	lastToucher := make(map[string]string) // path -> ID of the last call naming it
+	linked := make(map[[2]string]bool)
+
+	var walk func(entries []*models.ProcessedEntry)
+	walk = func(entries []*models.ProcessedEntry) {
+		previous := ""
+		for _, e := range entries {
+			for _, tc := range e.ToolCalls {
+				if tc.ID == "" {
+					continue
+				}
+
+				node := models.ToolGraphNode{
+					ID:          tc.ID,
+					Tool:        tc.Name,
+					Timestamp:   e.Timestamp,
+					Summary:     truncateString(extractToolSummary(tc), 100),
+					Files:       toolCallFiles(tc, lastToucher),
+					Failed:      tc.Result != nil && tc.Result.IsError,
+					IsSidechain: e.IsSidechain,
+				}
+				graph.Nodes = append(graph.Nodes, node)
+
+				for _, path := range node.Files {
+					if from, ok := lastToucher[path]; ok && from != tc.ID && !linked[[2]string{from, tc.ID}] {
+						graph.Edges = append(graph.Edges, models.ToolGraphEdge{
+							From: from,
+							To:   tc.ID,
+							Kind: models.ToolGraphEdgeFile,
+							File: path,
+						})
+						linked[[2]string{from, tc.ID}] = true
+					}
+					lastToucher[path] = tc.ID
+				}
+
+				if previous != "" && !linked[[2]string{previous, tc.ID}] {
+					graph.Edges = append(graph.Edges, models.ToolGraphEdge{
+						From: previous,
+						To:   tc.ID,
+						Kind: models.ToolGraphEdgeSequence,
+					})
+					linked[[2]string{previous, tc.ID}] = true
+				}
+				previous = tc.ID
+
+				walk(tc.TaskEntries)
+			}
+		}
+	}
+	walk(entries)
+
+	return graph
+}
+
+// toolCallFiles returns the paths a tool call names: file path inputs, plus,
+// for Bash, any already-seen path the command mentions (absolute, or relative
+// to the call's working directory).
+func toolCallFiles(tc models.ToolCall, known map[string]string) []string {
+	input, _ := tc.RawInput.(map[string]interface{})
+	if input == nil {
+		return nil
+	}
+
+	var files []string
+	for _, key := range toolGraphPathKeys {
+		if path, ok := input[key].(string); ok && path != "" {
+			files = append(files, filepath.Clean(path))
+		}
+	}
+
+	if tc.Name == constants.ToolNameBash {
+		command, _ := input["command"].(string)
+		var mentioned []string
+		for path := range known {
+			if commandMentions(command, path, tc.CWD) {
+				mentioned = append(mentioned, path)
+			}
+		}
+		sort.Strings(mentioned)
+		files = append(files, mentioned...)
+	}
+
+	return files
+}
+
+// commandMentions reports whether a shell command refers to path.
+func commandMentions(command, path, cwd string) bool {
+	if command == "" {
+		return false
+	}
+	if strings.Contains(command, path) {
+		return true
+	}
+	if cwd == "" {
+		return false
+	}
+	rel, err := filepath.Rel(cwd, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return false
+	}
+	return strings.Contains(command, rel)
+}
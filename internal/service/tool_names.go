@@ -0,0 +1,68 @@
+package service
+
+import (
+	"sort"
+
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// ListToolNames returns every distinct tool name called in a project's
+// sessions from the last days days (0 for all), including subagent and MCP
+// tools, most called first. Sessions that cannot be read are listed in the
+// report.
+func (s *SessionService) ListToolNames(projectName string, days int) ([]models.ToolNameCount, models.ScanReport, error) {
+	var report models.ScanReport
+
+	projects, err := s.projectService.ResolveProjects(projectName)
+	if err != nil {
+		return nil, report, err
+	}
+
+	var sessions []models.SessionInfo
+	for _, project := range projects {
+		projectSessions, projectReport, err := s.listProjectSessions(&project, days, false, false, 0)
+		report.Merge(projectReport)
+		if err != nil {
+			return nil, report, err
+		}
+		sessions = append(sessions, projectSessions...)
+	}
+
+	counts := make(map[string]*models.ToolNameCount)
+	for _, session := range sessions {
+		processed, err := s.loadProcessedEntriesFromFile(session.FilePath, true)
+		if err != nil {
+			report.AddFailure(session.SessionID, session.Project, session.FilePath, err)
+			continue
+		}
+
+		seen := make(map[string]bool)
+		for _, e := range processed {
+			for _, tc := range e.ToolCalls {
+				count, ok := counts[tc.Name]
+				if !ok {
+					count = &models.ToolNameCount{Name: tc.Name}
+					counts[tc.Name] = count
+				}
+				count.Count++
+				if !seen[tc.Name] {
+					seen[tc.Name] = true
+					count.Sessions++
+				}
+			}
+		}
+	}
+
+	names := make([]models.ToolNameCount, 0, len(counts))
+	for _, count := range counts {
+		names = append(names, *count)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if names[i].Count != names[j].Count {
+			return names[i].Count > names[j].Count
+		}
+		return names[i].Name < names[j].Name
+	})
+
+	return names, report, nil
+}
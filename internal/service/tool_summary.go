@@ -0,0 +1,129 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/brads3290/cclogviewer/internal/constants"
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/brads3290/cclogviewer/internal/utils"
+)
+
+// ToolSummaryFunc builds a one-line summary from a tool call's input.
+// Returning an empty string falls back to the generic key scan.
+type ToolSummaryFunc func(input map[string]interface{}) string
+
+// ToolSummaryRegistry maps tool names to summary formatters.
+type ToolSummaryRegistry struct {
+	formatters map[string]ToolSummaryFunc
+	mu         sync.RWMutex
+}
+
+// defaultSummaryKeys are scanned in order for tools without a registered formatter.
+var defaultSummaryKeys = []string{"command", "query", "url", "file_path", "pattern", "prompt"}
+
+// DefaultToolSummaries is the registry used for timeline summaries, pre-populated
+// with formatters for the built-in Claude Code tools.
+var DefaultToolSummaries = NewToolSummaryRegistry()
+
+// NewToolSummaryRegistry creates a registry with the built-in tool formatters.
+func NewToolSummaryRegistry() *ToolSummaryRegistry {
+	r := &ToolSummaryRegistry{formatters: make(map[string]ToolSummaryFunc)}
+
+	r.RegisterKeys(constants.ToolNameBash, "command")
+	r.RegisterKeys(constants.ToolNameRead, "file_path")
+	r.RegisterKeys(constants.ToolNameEdit, "file_path")
+	r.RegisterKeys(constants.ToolNameMultiEdit, "file_path")
+	r.RegisterKeys(constants.ToolNameWrite, "file_path")
+	r.RegisterKeys(constants.ToolNameNotebookEdit, "notebook_path")
+	r.RegisterKeys(constants.ToolNameGlob, "pattern")
+	r.RegisterKeys(constants.ToolNameWebFetch, "url")
+	r.RegisterKeys(constants.ToolNameWebSearch, "query")
+	r.Register(constants.ToolNameGrep, summarizeGrep)
+	r.Register(constants.TaskToolName, summarizeTask)
+	r.Register(constants.ToolNameTodoWrite, summarizeTodoWrite)
+
+	return r
+}
+
+// Register adds or replaces the formatter for a tool.
+func (r *ToolSummaryRegistry) Register(toolName string, fn ToolSummaryFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.formatters[toolName] = fn
+}
+
+// RegisterKeys registers a formatter that returns the first non-empty string
+// value among the given input keys. Useful for custom and MCP tools.
+func (r *ToolSummaryRegistry) RegisterKeys(toolName string, keys ...string) {
+	r.Register(toolName, func(input map[string]interface{}) string {
+		return firstStringValue(input, keys)
+	})
+}
+
+// Summarize returns a one-line summary for a tool call, falling back to the
+// generic key scan and finally to the tool name.
+func (r *ToolSummaryRegistry) Summarize(tc models.ToolCall) string {
+	switch input := tc.RawInput.(type) {
+	case map[string]interface{}:
+		r.mu.RLock()
+		fn, exists := r.formatters[tc.Name]
+		r.mu.RUnlock()
+
+		if exists {
+			if summary := fn(input); summary != "" {
+				return summary
+			}
+		}
+		if summary := firstStringValue(input, defaultSummaryKeys); summary != "" {
+			return summary
+		}
+	case string:
+		return input
+	}
+
+	return tc.Name
+}
+
+// firstStringValue returns the first non-empty string value among keys.
+func firstStringValue(input map[string]interface{}, keys []string) string {
+	for _, key := range keys {
+		if str := utils.ExtractString(input, key); str != "" {
+			return str
+		}
+	}
+	return ""
+}
+
+// summarizeGrep formats a Grep call as "pattern in path".
+func summarizeGrep(input map[string]interface{}) string {
+	pattern := utils.ExtractString(input, "pattern")
+	if path := utils.ExtractString(input, "path"); path != "" && pattern != "" {
+		return fmt.Sprintf("%s in %s", pattern, path)
+	}
+	return pattern
+}
+
+// summarizeTask formats a Task call as "subagent_type: description".
+func summarizeTask(input map[string]interface{}) string {
+	agentType := utils.ExtractString(input, "subagent_type")
+	detail := firstStringValue(input, []string{"description", "prompt"})
+	switch {
+	case agentType != "" && detail != "":
+		return agentType + ": " + strings.Join(strings.Fields(detail), " ")
+	case agentType != "":
+		return agentType
+	default:
+		return detail
+	}
+}
+
+// summarizeTodoWrite reports how many items the todo list contains.
+func summarizeTodoWrite(input map[string]interface{}) string {
+	todos := utils.ExtractSlice(input, "todos")
+	if todos == nil {
+		return ""
+	}
+	return fmt.Sprintf("%d todos", len(todos))
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/brads3290/cclogviewer/internal/constants"
+	"github.com/brads3290/cclogviewer/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultToolSummaries(t *testing.T) {
+	tests := []struct {
+		name  string
+		tool  string
+		input interface{}
+		want  string
+	}{
+		{name: "bash command", tool: constants.ToolNameBash, input: map[string]interface{}{"command": "ls -la", "description": "List"}, want: "ls -la"},
+		{name: "notebook path", tool: constants.ToolNameNotebookEdit, input: map[string]interface{}{"notebook_path": "a.ipynb"}, want: "a.ipynb"},
+		{name: "glob pattern", tool: constants.ToolNameGlob, input: map[string]interface{}{"pattern": "**/*.go"}, want: "**/*.go"},
+		{name: "web fetch url", tool: constants.ToolNameWebFetch, input: map[string]interface{}{"url": "https://example.com", "prompt": "Summarize"}, want: "https://example.com"},
+		{name: "grep with path", tool: constants.ToolNameGrep, input: map[string]interface{}{"pattern": "TODO", "path": "internal"}, want: "TODO in internal"},
+		{name: "grep without path", tool: constants.ToolNameGrep, input: map[string]interface{}{"pattern": "TODO"}, want: "TODO"},
+		{name: "task", tool: constants.TaskToolName, input: map[string]interface{}{"subagent_type": "explorer", "description": "Find  the\nhandler"}, want: "explorer: Find the handler"},
+		{name: "todo write", tool: constants.ToolNameTodoWrite, input: map[string]interface{}{"todos": []interface{}{map[string]interface{}{}, map[string]interface{}{}}}, want: "2 todos"},
+		{name: "unregistered falls back to key scan", tool: "mcp__db__query", input: map[string]interface{}{"query": "SELECT 1"}, want: "SELECT 1"},
+		{name: "empty formatter result falls back to key scan", tool: constants.ToolNameRead, input: map[string]interface{}{"pattern": "x"}, want: "x"},
+		{name: "no known key falls back to tool name", tool: "Custom", input: map[string]interface{}{"n": 1}, want: "Custom"},
+		{name: "string input", tool: "Custom", input: "raw input", want: "raw input"},
+		{name: "nil input", tool: "Custom", input: nil, want: "Custom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DefaultToolSummaries.Summarize(models.ToolCall{Name: tt.tool, RawInput: tt.input})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestToolSummaryRegistry_Register(t *testing.T) {
+	r := NewToolSummaryRegistry()
+	call := models.ToolCall{Name: "mcp__jira__get_issue", RawInput: map[string]interface{}{"issue_key": "ABC-1", "prompt": "p"}}
+
+	// Without a formatter the generic key scan picks prompt
+	assert.Equal(t, "p", r.Summarize(call))
+
+	r.RegisterKeys("mcp__jira__get_issue", "issue_key")
+	assert.Equal(t, "ABC-1", r.Summarize(call))
+
+	r.Register("mcp__jira__get_issue", func(input map[string]interface{}) string {
+		return "issue " + input["issue_key"].(string)
+	})
+	assert.Equal(t, "issue ABC-1", r.Summarize(call))
+
+	// Registering on one registry leaves the default untouched
+	assert.Equal(t, "p", DefaultToolSummaries.Summarize(call))
+}
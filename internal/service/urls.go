@@ -0,0 +1,126 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/brads3290/cclogviewer/internal/constants"
+	"github.com/brads3290/cclogviewer/internal/models"
+)
+
+// urlPattern matches http(s) URLs up to whitespace or common delimiters.
+var urlPattern = regexp.MustCompile(`https?://[^\s"'<>()\[\]{}` + "`" + `]+`)
+
+// webTools are the tools whose inputs and results are scanned for URLs.
+var webTools = map[string]bool{
+	constants.ToolNameWebFetch:  true,
+	constants.ToolNameWebSearch: true,
+}
+
+// ExtractURLs returns the URLs fetched or returned by WebFetch and WebSearch in
+// a session, including subagent conversations, in first-seen order.
+func (s *SessionService) ExtractURLs(sessionID, projectName string) ([]models.URLHit, error) {
+	processed, _, err := s.loadProcessedEntries(sessionID, "", projectName, false)
+	if err != nil {
+		return nil, err
+	}
+	if processed == nil {
+		return nil, nil
+	}
+
+	return collectURLs(processed), nil
+}
+
+// ExtractURLsFromFile returns the URLs visited by web tools in a JSONL file path.
+func (s *SessionService) ExtractURLsFromFile(filePath string) ([]models.URLHit, error) {
+	processed, err := s.loadProcessedEntriesFromFile(filePath, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectURLs(processed), nil
+}
+
+// collectURLs scans web tool inputs and results and merges repeated URLs.
+func collectURLs(entries []*models.ProcessedEntry) []models.URLHit {
+	hits := make([]models.URLHit, 0)
+	index := make(map[string]int)
+
+	record := func(url, tool, timestamp string, sidechain bool) {
+		if i, ok := index[url]; ok {
+			hit := &hits[i]
+			hit.Count++
+			if timestamp != "" {
+				hit.LastSeen = timestamp
+			}
+			hit.IsSidechain = hit.IsSidechain && sidechain
+			for _, t := range hit.Tools {
+				if t == tool {
+					return
+				}
+			}
+			hit.Tools = append(hit.Tools, tool)
+			return
+		}
+		index[url] = len(hits)
+		hits = append(hits, models.URLHit{
+			URL:         url,
+			Tools:       []string{tool},
+			Count:       1,
+			FirstSeen:   timestamp,
+			LastSeen:    timestamp,
+			IsSidechain: sidechain,
+		})
+	}
+
+	var walk func(entries []*models.ProcessedEntry)
+	walk = func(entries []*models.ProcessedEntry) {
+		for _, e := range entries {
+			for _, tc := range e.ToolCalls {
+				if webTools[tc.Name] {
+					// A URL repeated within one call counts as a single access
+					seen := make(map[string]bool)
+					urls := findURLs(toolInputText(tc.RawInput))
+					if tc.Result != nil {
+						urls = append(urls, findURLs(tc.Result.Content)...)
+					}
+					for _, url := range urls {
+						if !seen[url] {
+							seen[url] = true
+							record(url, tc.Name, e.RawTimestamp, e.IsSidechain)
+						}
+					}
+				}
+				walk(tc.TaskEntries)
+			}
+		}
+	}
+	walk(entries)
+
+	return hits
+}
+
+// toolInputText returns the url input of a tool call, or the whole input when
+// there is no url field (e.g. a WebSearch query that embeds a link).
+func toolInputText(raw interface{}) string {
+	if input, ok := raw.(map[string]interface{}); ok {
+		if url, ok := input["url"].(string); ok {
+			return url
+		}
+	}
+	return fmt.Sprint(raw)
+}
+
+// findURLs returns the URLs in text with trailing punctuation trimmed.
+func findURLs(text string) []string {
+	matches := urlPattern.FindAllString(text, -1)
+	urls := make([]string, 0, len(matches))
+	for _, m := range matches {
+		m = strings.TrimRight(m, ".,;:!?")
+		if m != "" {
+			urls = append(urls, m)
+		}
+	}
+	return urls
+}
@@ -0,0 +1,268 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultWatchInterval is how often the polling watcher rescans a project.
+const DefaultWatchInterval = 2 * time.Second
+
+// SessionEventOp is the kind of change seen on a session file.
+type SessionEventOp string
+
+const (
+	SessionCreated  SessionEventOp = "created"
+	SessionModified SessionEventOp = "modified"
+	SessionRemoved  SessionEventOp = "removed"
+)
+
+// SessionEvent reports a change to one session file in a watched project.
+type SessionEvent struct {
+	Project   string         `json:"project"`
+	SessionID string         `json:"session_id"`
+	FilePath  string         `json:"file_path"`
+	Op        SessionEventOp `json:"op"`
+}
+
+// WatchBackend reports changes to the .jsonl files in a directory, such as
+// FSNotifyBackend. Watch should return an error straight away if the backend
+// is unavailable; it must stop sending once done is closed.
+type WatchBackend interface {
+	Watch(dir string, events chan<- SessionEvent, done <-chan struct{}) error
+}
+
+// FSNotifyBackend is the WatchBackend that receives change notifications from
+// the OS through fsnotify.
+type FSNotifyBackend struct{}
+
+// Watch reports file creation, writes and removal in dir until done is closed.
+func (FSNotifyBackend) Watch(dir string, events chan<- SessionEvent, done <-chan struct{}) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err := watcher.Add(dir); err != nil {
+		watcher.Close()
+		return err
+	}
+
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case <-done:
+				return
+			case <-watcher.Errors:
+				// Overflowed or failed notifications are skipped; the next write is reported
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				var op SessionEventOp
+				switch {
+				case event.Has(fsnotify.Create):
+					op = SessionCreated
+				case event.Has(fsnotify.Write):
+					op = SessionModified
+				case event.Has(fsnotify.Remove), event.Has(fsnotify.Rename):
+					op = SessionRemoved
+				default:
+					continue
+				}
+				select {
+				case events <- SessionEvent{FilePath: event.Name, Op: op}:
+				case <-done:
+					return
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// WatchConfig configures a SessionWatcher.
+type WatchConfig struct {
+	// Backend receives change notifications from the OS (nil means
+	// FSNotifyBackend). When it fails, the watcher falls back to polling.
+	Backend WatchBackend
+	// Interval between polling scans (0 means DefaultWatchInterval)
+	Interval time.Duration
+}
+
+// SessionWatcher emits change events for the session files of one project.
+// Nothing is watched until a watcher is created, so other callers pay nothing.
+type SessionWatcher struct {
+	project string
+	events  chan SessionEvent
+	done    chan struct{}
+	once    sync.Once
+	wg      sync.WaitGroup
+	// Polling is true when the watcher fell back to rescanning the directory
+	Polling bool
+}
+
+// WatchSessions starts watching the session files of a project.
+// Call Close when done to stop the watcher and close Events.
+func (s *SessionService) WatchSessions(projectName string, config WatchConfig) (*SessionWatcher, error) {
+	project, err := s.projectService.FindProjectByName(projectName)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, fmt.Errorf("project not found: %s", projectName)
+	}
+	return newSessionWatcher(project.Name, s.projectService.GetProjectDir(project.EncodedPath), config)
+}
+
+func newSessionWatcher(project, dir string, config WatchConfig) (*SessionWatcher, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, err
+	}
+
+	w := &SessionWatcher{
+		project: project,
+		events:  make(chan SessionEvent, 64),
+		done:    make(chan struct{}),
+	}
+
+	backend := config.Backend
+	if backend == nil {
+		backend = FSNotifyBackend{}
+	}
+
+	raw := make(chan SessionEvent, 64)
+	if backend.Watch(dir, raw, w.done) != nil {
+		interval := config.Interval
+		if interval <= 0 {
+			interval = DefaultWatchInterval
+		}
+		w.Polling = true
+		// Scan before returning so changes made straight after are not missed
+		known := scanSessionFiles(dir)
+		w.wg.Add(1)
+		go func() {
+			defer w.wg.Done()
+			pollSessionFiles(dir, known, interval, raw, w.done)
+		}()
+	}
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		w.forward(raw)
+	}()
+
+	return w, nil
+}
+
+// Events returns the channel of session changes. It is closed by Close.
+func (w *SessionWatcher) Events() <-chan SessionEvent {
+	return w.events
+}
+
+// Close stops the watcher and closes the Events channel.
+func (w *SessionWatcher) Close() error {
+	w.once.Do(func() {
+		close(w.done)
+		w.wg.Wait()
+		close(w.events)
+	})
+	return nil
+}
+
+// forward tags backend events with the project and passes on session files only.
+func (w *SessionWatcher) forward(raw <-chan SessionEvent) {
+	for {
+		select {
+		case <-w.done:
+			return
+		case event := <-raw:
+			if !strings.HasSuffix(event.FilePath, ".jsonl") {
+				continue
+			}
+			event.Project = w.project
+			if event.SessionID == "" {
+				event.SessionID = fileLabel(event.FilePath)
+			}
+			select {
+			case w.events <- event:
+			case <-w.done:
+				return
+			}
+		}
+	}
+}
+
+// fileState is what the polling watcher compares between scans.
+type fileState struct {
+	size    int64
+	modTime time.Time
+}
+
+// pollSessionFiles rescans dir every interval and reports .jsonl files added,
+// changed or removed since known until done is closed.
+func pollSessionFiles(dir string, known map[string]fileState, interval time.Duration, events chan<- SessionEvent, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+
+		current := scanSessionFiles(dir)
+		var changes []SessionEvent
+		for path, state := range current {
+			old, ok := known[path]
+			switch {
+			case !ok:
+				changes = append(changes, SessionEvent{FilePath: path, Op: SessionCreated})
+			case old != state:
+				changes = append(changes, SessionEvent{FilePath: path, Op: SessionModified})
+			}
+		}
+		for path := range known {
+			if _, ok := current[path]; !ok {
+				changes = append(changes, SessionEvent{FilePath: path, Op: SessionRemoved})
+			}
+		}
+		known = current
+
+		for _, event := range changes {
+			select {
+			case events <- event:
+			case <-done:
+				return
+			}
+		}
+	}
+}
+
+// scanSessionFiles returns the size and modification time of each .jsonl file in dir.
+func scanSessionFiles(dir string) map[string]fileState {
+	states := make(map[string]fileState)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return states
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".jsonl") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		states[filepath.Join(dir, entry.Name())] = fileState{size: info.Size(), modTime: info.ModTime()}
+	}
+	return states
+}
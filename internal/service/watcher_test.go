@@ -0,0 +1,76 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingBackend is a WatchBackend that is never available.
+type failingBackend struct{}
+
+func (failingBackend) Watch(string, chan<- SessionEvent, <-chan struct{}) error {
+	return errors.New("unavailable")
+}
+
+// expectEvent waits for an event on path with op, skipping any others.
+func expectEvent(t *testing.T, w *SessionWatcher, path string, op SessionEventOp) {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event := <-w.Events():
+			if event.FilePath == path && event.Op == op {
+				assert.Equal(t, "app", event.Project)
+				assert.Equal(t, "11111111-1111-1111-1111-111111111111", event.SessionID)
+				return
+			}
+		case <-timeout:
+			t.Fatalf("no %s event for %s", op, path)
+		}
+	}
+}
+
+// appendLine appends a JSONL line to path.
+func appendLine(t *testing.T, path, line string) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = f.WriteString(line + "\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+}
+
+func TestSessionWatcher_FSNotify(t *testing.T) {
+	claudeDir := t.TempDir()
+	path := writeSession(t, claudeDir, "-home-u-app", "11111111-1111-1111-1111-111111111111",
+		userLine("u1", "2024-01-01T10:00:00Z", "Hello"))
+	services := NewServices(claudeDir)
+
+	w, err := services.Session.WatchSessions("app", WatchConfig{})
+	require.NoError(t, err)
+	defer w.Close()
+	assert.False(t, w.Polling)
+
+	appendLine(t, path, assistantLine("a1", "2024-01-01T10:00:01Z", "Hi"))
+	expectEvent(t, w, path, SessionModified)
+}
+
+func TestSessionWatcher_PollingFallback(t *testing.T) {
+	claudeDir := t.TempDir()
+	path := writeSession(t, claudeDir, "-home-u-app", "11111111-1111-1111-1111-111111111111",
+		userLine("u1", "2024-01-01T10:00:00Z", "Hello"))
+	services := NewServices(claudeDir)
+
+	w, err := services.Session.WatchSessions("app", WatchConfig{Backend: failingBackend{}, Interval: 20 * time.Millisecond})
+	require.NoError(t, err)
+	defer w.Close()
+	assert.True(t, w.Polling)
+
+	appendLine(t, path, assistantLine("a1", "2024-01-01T10:00:01Z", "Hi"))
+	expectEvent(t, w, path, SessionModified)
+}
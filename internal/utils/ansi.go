@@ -0,0 +1,18 @@
+package utils
+
+import "regexp"
+
+// ansiEscapePattern matches terminal escape sequences: CSI sequences such as
+// colors and cursor movement, OSC sequences such as titles and hyperlinks,
+// character set selection, and other two-byte escapes.
+var ansiEscapePattern = regexp.MustCompile(
+	`\x1b\[[0-?]*[ -/]*[@-~]` + // CSI: ESC [ params intermediates final
+		`|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)` + // OSC: ESC ] ... BEL or ST
+		`|\x1b[()][0-9A-Za-z]` + // Character set selection
+		`|\x1b[@-Z\\-_]`, // Other two-byte escapes
+)
+
+// StripANSI removes terminal escape sequences from s, leaving the plain text.
+func StripANSI(s string) string {
+	return ansiEscapePattern.ReplaceAllString(s, "")
+}
@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStripANSI(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "plain text is unchanged",
+			input:    "hello world",
+			expected: "hello world",
+		},
+		{
+			name:     "basic color and reset",
+			input:    "\x1b[31merror\x1b[0m: failed",
+			expected: "error: failed",
+		},
+		{
+			name:     "combined attributes",
+			input:    "\x1b[1;32mPASS\x1b[m ok",
+			expected: "PASS ok",
+		},
+		{
+			name:     "256 and true color",
+			input:    "\x1b[38;5;208morange\x1b[0m \x1b[38;2;255;0;0mred\x1b[0m",
+			expected: "orange red",
+		},
+		{
+			name:     "cursor movement and line erase",
+			input:    "progress 50%\x1b[2K\x1b[1G\x1b[1Aprogress 100%",
+			expected: "progress 50%progress 100%",
+		},
+		{
+			name:     "private mode sequences",
+			input:    "\x1b[?25lworking\x1b[?25h",
+			expected: "working",
+		},
+		{
+			name:     "OSC hyperlink terminated by BEL",
+			input:    "see \x1b]8;;https://example.com\x07docs\x1b]8;;\x07",
+			expected: "see docs",
+		},
+		{
+			name:     "OSC title terminated by ST",
+			input:    "\x1b]0;my title\x1b\\done",
+			expected: "done",
+		},
+		{
+			name:     "character set selection",
+			input:    "\x1b(Bplain\x1b(0",
+			expected: "plain",
+		},
+		{
+			name:     "multiline output keeps newlines",
+			input:    "\x1b[32mline1\x1b[0m\n\x1b[33mline2\x1b[0m\n",
+			expected: "line1\nline2\n",
+		},
+		{
+			name:     "bracketed text without escape is unchanged",
+			input:    "[Request interrupted by user]",
+			expected: "[Request interrupted by user]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, StripANSI(tt.input))
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// NormalizeText normalizes text for comparison by removing extra whitespace and newlines
+func NormalizeText(text string) string {
+	// Replace all newlines with spaces
+	text = strings.ReplaceAll(text, "\n", " ")
+	text = strings.ReplaceAll(text, "\r", " ")
+
+	// Replace multiple spaces with single space
+	text = strings.Join(strings.Fields(text), " ")
+
+	return strings.TrimSpace(text)
+}
+
+// SanitizeUTF8 replaces invalid UTF-8 sequences with the Unicode replacement
+// character so the text always encodes to valid JSON and HTML.
+func SanitizeUTF8(text string) string {
+	if utf8.ValidString(text) {
+		return text
+	}
+	return strings.ToValidUTF8(text, string(utf8.RuneError))
+}
+
+// CutAtRune returns at most the first maxLen bytes of s, backing up to a rune
+// boundary so the cut never leaves invalid UTF-8.
+func CutAtRune(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	for maxLen > 0 && !utf8.RuneStart(s[maxLen]) {
+		maxLen--
+	}
+	return s[:maxLen]
+}
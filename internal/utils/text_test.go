@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeText_Simple(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "removes extra spaces",
+			input: "hello   world",
+			want:  "hello world",
+		},
+		{
+			name:  "removes newlines",
+			input: "hello\nworld",
+			want:  "hello world",
+		},
+		{
+			name:  "trims whitespace",
+			input: "  hello world  ",
+			want:  "hello world",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NormalizeText(tt.input)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSanitizeUTF8(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"valid text unchanged", "héllo wörld", "héllo wörld"},
+		{"invalid byte replaced", "ok\xffok", "ok�ok"},
+		{"truncated multibyte sequence", "caf\xc3", "caf�"},
+		{"binary run collapses", "\x00\xfe\xff\xfdend", "\x00�end"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SanitizeUTF8(tt.input)
+			assert.Equal(t, tt.want, got)
+			assert.True(t, utf8.ValidString(got))
+		})
+	}
+}
+
+func TestCutAtRune(t *testing.T) {
+	assert.Equal(t, "hello", CutAtRune("hello", 10))
+	assert.Equal(t, "hel", CutAtRune("hello", 3))
+	// "é" is two bytes; a cut through it backs up to the rune start
+	assert.Equal(t, "a", CutAtRune("aé", 2))
+	assert.Equal(t, "", CutAtRune("é", 1))
+	assert.True(t, utf8.ValidString(CutAtRune("日本語", 4)))
+}
@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+)
+
+// timestampLayouts are the timestamp formats seen in session logs, tried in
+// order. The fractional seconds are optional in each layout.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999-0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// ParseTimestamp parses a session log timestamp. Besides RFC3339 it accepts
+// offsets without a colon, a space instead of the T, and timestamps with no
+// zone at all, which are taken to be UTC.
+func ParseTimestamp(s string) (time.Time, error) {
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid timestamp %q", s)
+}
@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  time.Time
+	}{
+		{
+			name:  "RFC3339 UTC",
+			input: "2024-01-01T10:00:00Z",
+			want:  time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "RFC3339 with milliseconds",
+			input: "2024-01-01T10:00:00.123Z",
+			want:  time.Date(2024, 1, 1, 10, 0, 0, 123000000, time.UTC),
+		},
+		{
+			name:  "RFC3339 with offset",
+			input: "2024-01-01T12:00:00+02:00",
+			want:  time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "offset without colon",
+			input: "2024-01-01T12:00:00.5+0200",
+			want:  time.Date(2024, 1, 1, 10, 0, 0, 500000000, time.UTC),
+		},
+		{
+			name:  "no zone is UTC",
+			input: "2024-01-01T10:00:00",
+			want:  time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "no zone with milliseconds",
+			input: "2024-01-01T10:00:00.250",
+			want:  time.Date(2024, 1, 1, 10, 0, 0, 250000000, time.UTC),
+		},
+		{
+			name:  "space separator with zone",
+			input: "2024-01-01 10:00:00Z",
+			want:  time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "space separator without zone",
+			input: "2024-01-01 10:00:00.001",
+			want:  time.Date(2024, 1, 1, 10, 0, 0, 1000000, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTimestamp(tt.input)
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %s, want %s", got, tt.want)
+		})
+	}
+}
+
+func TestParseTimestamp_Invalid(t *testing.T) {
+	for _, input := range []string{"", "invalid", "10:00:00", "2024-01-01"} {
+		_, err := ParseTimestamp(input)
+		assert.Error(t, err, input)
+	}
+}